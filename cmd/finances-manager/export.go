@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xfrr/finantrack/internal/contexts/transactions/exports"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/services"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const exportUsage = `Usage: finances-manager export [flags] ledger|hledger|beancount
+
+Export the assets, transactions, transfers and prices of a household
+as a plain-text accounting journal.
+
+Flags:
+`
+
+// runExport runs the export subcommand.
+func runExport(ctx context.Context, args []string, opts []services.InitializeOption) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), exportUsage)
+		fs.PrintDefaults()
+	}
+
+	var (
+		householdID = fs.String("household", "", "ID of the household to export (required)")
+		assetID     = fs.String("asset", "", "export only the given asset")
+		output      = fs.String("o", "", "file to write the journal to, the standard output by default")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() != 1 || *householdID == "" {
+		fs.Usage()
+		return errors.New("the format and the household are required")
+	}
+
+	format, err := exports.ParseFormat(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	service, err := assets.NewService(opts...)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return service.ExportJournal(xtenant.WithTenant(ctx, *householdID), w, format, transactionsqueries.GetJournalQuery{
+		AssetID: *assetID,
+	})
+}
@@ -2,50 +2,54 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 
+	"github.com/xfrr/finantrack/internal/shared/xconfig"
 	"github.com/xfrr/finantrack/internal/shared/xos"
 	"github.com/xfrr/finantrack/services"
 
 	assets "github.com/xfrr/finantrack/services/assets/wire"
 )
 
-func main() {
-	var (
-		httpServerPort   = xos.GetEnvWithDefault("FINANCES_MANAGER_HTTP_SERVER_PORT", "6000")
-		environment      = xos.GetEnvWithDefault("FINANCES_MANAGER_ENVIRONMENT", "development")
-		otelCollectorURL = xos.GetEnvWithDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
-
-		dbHost   = xos.GetEnvWithDefault("FINANCES_MANAGER_DB_HOST", "localhost")
-		dbPort   = xos.GetEnvWithDefault("FINANCES_MANAGER_DB_PORT", "27017")
-		dbUser   = xos.GetEnvWithDefault("FINANCES_MANAGER_DB_USER", "root")
-		dbPass   = xos.GetEnvWithDefault("FINANCES_MANAGER_DB_PASS", "root")
-		dbName   = xos.GetEnvWithDefault("FINANCES_MANAGER_DB_NAME", "finantrack")
-		dbEngine = services.DatabaseEngineType(xos.GetEnvWithDefault(
-			"FINANCES_MANAGER_DB_ENGINE",
-			string(services.MongoDatabaseEngine)),
-		)
-	)
+// subcommands are the administration commands, by name.
+var subcommands = map[string]func(ctx context.Context, args []string, opts []services.InitializeOption) error{
+	"export": runExport,
+	"report": runReport,
+}
 
+func main() {
 	ctx, stopNotification := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stopNotification()
 
-	service, err := assets.NewService(
-		services.Environment(environment),
-		services.Traces(otelCollectorURL),
-		services.HTTPServer(
-			services.Port(httpServerPort),
-		),
-		services.Database(
-			services.DatabaseEngine(dbEngine),
-			services.DatabaseHost(dbHost),
-			services.DatabasePort(dbPort),
-			services.DatabaseUser(dbUser),
-			services.DatabasePass(dbPass),
-			services.DatabaseName(dbName),
-		),
-	)
+	// subcommands run against the database of the service instead of serving the api
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			opts, err := options(nil)
+			if err == nil {
+				err = run(ctx, os.Args[2:], opts)
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	opts, err := options(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	service, err := assets.NewService(opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -54,3 +58,23 @@ func main() {
 		panic(err)
 	}
 }
+
+// options returns the service options loaded from the configuration file, the environment
+// and the flags of the arguments. The subcommands parse their own flags, so they are
+// configured by the file and the environment only.
+func options(args []string) ([]services.InitializeOption, error) {
+	cfg := assets.DefaultConfig()
+
+	opts := []xconfig.Option{
+		xconfig.WithFile(xos.GetEnvWithDefault("FINANCES_MANAGER_CONFIG_FILE", "")),
+		xconfig.WithEnv(os.LookupEnv),
+	}
+	if args != nil {
+		opts = append(opts, xconfig.WithFlags(flag.NewFlagSet("finances-manager", flag.ContinueOnError), args))
+	}
+
+	if err := xconfig.Load(&cfg, opts...); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg.Options(), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+	"github.com/xfrr/finantrack/internal/contexts/reports/render"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/services"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const reportUsage = `Usage: finances-manager report [flags] income-statement|categories|allocation
+
+Render a report of a household as a PDF document, a self-contained HTML
+page with charts, or CSV.
+
+Flags:
+`
+
+// runReport runs the report subcommand.
+func runReport(ctx context.Context, args []string, opts []services.InitializeOption) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), reportUsage)
+		fs.PrintDefaults()
+	}
+
+	var (
+		householdID = fs.String("household", "", "ID of the household to report on (required)")
+		formatName  = fs.String("format", string(render.FormatPDF), "format of the report: pdf, html or csv")
+		period      = fs.String("period", string(reports.GranularityMonthly), "length of the periods: monthly, quarterly or yearly")
+		from        = fs.String("from", "", "first day of the report, as YYYY-MM-DD")
+		to          = fs.String("to", "", "last day of the report, as YYYY-MM-DD, today by default")
+		asOf        = fs.String("as-of", "", "point in time of the allocation, as RFC 3339")
+		output      = fs.String("o", "", "file to write the report to, the standard output by default")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() != 1 || *householdID == "" {
+		fs.Usage()
+		return errors.New("the report and the household are required")
+	}
+
+	format, err := render.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	var fromDate, toDate time.Time
+	if *from != "" {
+		if fromDate, err = time.Parse(time.DateOnly, *from); err != nil {
+			return errors.New("from must be a date formatted as YYYY-MM-DD")
+		}
+	}
+	if *to != "" {
+		if toDate, err = time.Parse(time.DateOnly, *to); err != nil {
+			return errors.New("to must be a date formatted as YYYY-MM-DD")
+		}
+	}
+
+	var query any
+	switch fs.Arg(0) {
+	case "income-statement":
+		query = reportsqueries.GetIncomeStatementQuery{
+			Granularity: reports.Granularity(*period),
+			From:        fromDate,
+			To:          toDate,
+		}
+	case "categories":
+		query = reportsqueries.GetCategoryBreakdownQuery{
+			Granularity: reports.Granularity(*period),
+			From:        fromDate,
+			To:          toDate,
+		}
+	case "allocation":
+		var t time.Time
+		if *asOf != "" {
+			if t, err = time.Parse(time.RFC3339, *asOf); err != nil {
+				return errors.New("as-of must be a time formatted as RFC 3339")
+			}
+		}
+		query = reportsqueries.GetAllocationQuery{AsOf: t}
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown report %q", fs.Arg(0))
+	}
+
+	service, err := assets.NewService(opts...)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return service.RenderReport(xtenant.WithTenant(ctx, *householdID), w, format, query)
+}
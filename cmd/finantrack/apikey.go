@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const apiKeyUsage = `Usage: finantrack apikey create [flags]
+
+Issue an API key authenticating the subject, e.g. the user ID of a member
+of a household, in the X-API-Key header. Only the hash of the key is
+stored: the key is printed once and cannot be recovered afterwards.
+
+Flags:
+`
+
+// runAPIKey runs the apikey command.
+func runAPIKey(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("apikey", apiKeyUsage)
+
+	name := fs.String("name", "", "name describing the use of the key")
+	subject := fs.String("subject", "", "subject authenticated by the key, required")
+	scopes := fs.String("scopes", "", "comma separated scopes granted to the key")
+	expiresIn := fs.Duration("expires-in", 0, "validity of the key, e.g. 720h, it never expires by default")
+
+	if len(args) == 0 || args[0] != "create" {
+		fs.Usage()
+		return errors.New("the subcommand must be create")
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("apikey create takes no arguments")
+	}
+
+	var expiresAt time.Time
+	if *expiresIn > 0 {
+		expiresAt = time.Now().UTC().Add(*expiresIn)
+	}
+
+	key, apiKey, err := service.CreateAPIKey(ctx, *name, *subject, splitScopes(*scopes), expiresAt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, key)
+	fmt.Fprintf(os.Stderr, "created api key %s for %s, store it safely as it is not shown again\n", apiKey.ID, apiKey.Subject)
+	return nil
+}
+
+// splitScopes returns the scopes of a comma separated list.
+func splitScopes(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
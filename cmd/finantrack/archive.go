@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/services"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const exportUsage = `Usage: finantrack export [flags]
+
+Back up the complete event log of a household as a portable archive:
+a tar.gz with the events as JSON Lines, a manifest and their checksums.
+
+Flags:
+`
+
+const importUsage = `Usage: finantrack import [flags] archive
+
+Restore a backup archive into the database, replaying its events in the
+household it was taken from. The archive is verified before any event is
+written, then only the events missing from the database are replayed.
+
+Flags:
+`
+
+// runExport runs the export command.
+func runExport(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("export", exportUsage)
+
+	var (
+		householdID = fs.String("household", "", "ID of the household to back up (required)")
+		output      = fs.String("o", "", "file to write the archive to, the standard output by default")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 || *householdID == "" {
+		fs.Usage()
+		return errors.New("the household is required")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	manifest, err := service.Backup(xtenant.WithTenant(ctx, *householdID), w)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d events of %d aggregates\n", manifest.Events, manifest.Aggregates)
+	return nil
+}
+
+// runImport runs the import command.
+func runImport(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("import", importUsage)
+
+	engine := fs.String("engine", "", "database engine to import into, the configured one by default")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("the archive is required")
+	}
+
+	target := service.Config().DatabaseEngine
+	if *engine != "" {
+		target = services.DatabaseEngineType(*engine)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := service.Restore(ctx, f, target)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "imported %d events of %d aggregates into household %s, skipped %d up to date aggregates\n",
+		result.Events, result.Aggregates, result.Manifest.TenantID, result.Skipped)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const assignUsage = `Usage: finantrack assign [flags]
+
+Assign the assets created before households were introduced to a household.
+Those assets belong to no household, so they are hidden from every household
+and cannot be modified until they are assigned. Running it again once they
+are assigned does nothing.
+
+Flags:
+`
+
+// runAssign runs the assign command.
+func runAssign(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("assign", assignUsage)
+
+	householdID := fs.String("household", "", "ID of the household to assign the assets to, required")
+	dryRun := fs.Bool("dry-run", false, "print the assets to assign without assigning them")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 || *householdID == "" {
+		fs.Usage()
+		return errors.New("the household is required")
+	}
+
+	assigned, err := service.AssignAssets(ctx, *householdID, *dryRun)
+	for _, asset := range assigned {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", asset.ID(), asset.Name())
+	}
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "%d assets to assign to %s\n", len(assigned), *householdID)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "assigned %d assets to %s\n", len(assigned), *householdID)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const dumpUsage = `Usage: finantrack dump [flags] aggregate-id
+
+Write the events of an aggregate, in any household, ordered by version:
+one JSON object per line, as in the event log of the archives.
+
+Flags:
+`
+
+// runDump runs the dump command.
+func runDump(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("dump", dumpUsage)
+
+	output := fs.String("o", "", "file to write the events to, the standard output by default")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("the aggregate is required")
+	}
+
+	aggregateID, err := uuid.Parse(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid aggregate id %q: %w", fs.Arg(0), err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	n, err := service.Dump(ctx, w, aggregateID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "dumped %d events\n", n)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Command finantrack operates the event store of the finances manager: it applies the schema
+// of the database, dumps and verifies the events, exports and imports the archives of
+// the households, replays the projections, rebuilds the snapshots and issues API keys. The commands run against any database engine.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/xfrr/finantrack/internal/shared/xconfig"
+	"github.com/xfrr/finantrack/internal/shared/xos"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const usage = `Usage: finantrack [flags] command [command flags]
+
+Operate the event store of the finances manager, in any database engine.
+The database is configured as the service: with the file of -config or
+FINANCES_MANAGER_CONFIG_FILE, the environment and the flags below.
+
+Commands:
+  migrate            apply the indexes and tables of the database
+  dump               write the events of an aggregate as JSON Lines
+  export             back up the event log of a household as an archive
+  import             restore an archive into the database
+  verify             check the integrity of the event log of a household or of an archive
+  assign             assign the assets created before households to a household
+  apikey             issue an API key with apikey create
+  replay-projection  replay the events into a projection
+  rebuild-snapshots  save the snapshots of the assets at their current version
+
+Run finantrack command -h for the flags of a command.
+
+Flags:
+`
+
+// command runs a command with its arguments.
+type command func(ctx context.Context, service *assets.Service, args []string) error
+
+// commands are the commands, by name.
+var commands = map[string]command{
+	"migrate":           runMigrate,
+	"dump":              runDump,
+	"export":            runExport,
+	"import":            runImport,
+	"verify":            runVerify,
+	"assign":            runAssign,
+	"apikey":            runAPIKey,
+	"replay-projection": runReplay,
+	"rebuild-snapshots": runRebuildSnapshots,
+}
+
+func main() {
+	ctx, stopNotification := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stopNotification()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+			stopNotification()
+			os.Exit(1)
+		}
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("finantrack", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	cfg := assets.DefaultConfig()
+	err := xconfig.Load(&cfg,
+		xconfig.WithFile(xos.GetEnvWithDefault("FINANCES_MANAGER_CONFIG_FILE", "")),
+		xconfig.WithEnv(os.LookupEnv),
+		xconfig.WithFlags(fs, args),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("the command is required")
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q, must be one of %v", fs.Arg(0), commandNames())
+	}
+
+	service, err := assets.NewService(cfg.Options()...)
+	if err != nil {
+		return err
+	}
+
+	return cmd(ctx, service, fs.Args()[1:])
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newFlagSet returns the flag set of a command, printing its usage before the flags.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/xfrr/finantrack/internal/shared/xmigrate"
+	"github.com/xfrr/finantrack/services"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const migrateUsage = `Usage: finantrack migrate [flags]
+
+Apply the pending migrations of the database: the indexes of the MongoDB
+collections or the tables of immudb. The migrations applied are recorded
+with their checksums, so each one runs once, and nothing is applied when
+the ones recorded drifted from the migrations of this version.
+
+Flags:
+`
+
+// runMigrate runs the migrate command.
+func runMigrate(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("migrate", migrateUsage)
+
+	engine := fs.String("engine", "", "database engine to migrate, the configured one by default")
+	dryRun := fs.Bool("dry-run", false, "print the pending migrations without applying them")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("migrate takes no arguments")
+	}
+
+	target := service.Config().DatabaseEngine
+	if *engine != "" {
+		target = services.DatabaseEngineType(*engine)
+	}
+
+	var opts []xmigrate.Option
+	if *dryRun {
+		opts = append(opts, xmigrate.WithDryRun())
+	}
+
+	plan, err := service.Migrate(ctx, target, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range plan.Pending {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", step.ID, step.Checksum)
+	}
+
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "%s has %d migrations applied, %d pending\n", target, len(plan.Applied), len(plan.Pending))
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "migrated %s, %d migrations applied\n", target, len(plan.Pending))
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const replayUsage = `Usage: finantrack replay-projection [flags] projection
+
+Replay all the events, of every household, into a projection, e.g. after the
+way it is computed changed. The projections are rebuilt from the events, so
+replaying one again only refreshes it.
+
+Projections:
+  event-index  the events by the date and the parent of their payloads
+
+Flags:
+`
+
+// runReplay runs the replay-projection command.
+func runReplay(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("replay-projection", replayUsage)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("the projection is required")
+	}
+
+	n, err := service.ReplayProjection(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "replayed %d events into %s\n", n, fs.Arg(0))
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const snapshotsUsage = `Usage: finantrack rebuild-snapshots [flags]
+
+Save the snapshot of every asset, of every household, at its current version,
+so the assets are restored from it and the events after it instead of
+replaying all their events. The snapshots are derived from the events, so
+rebuilding them again only refreshes them.
+
+Flags:
+`
+
+// runRebuildSnapshots runs the rebuild-snapshots command.
+func runRebuildSnapshots(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("rebuild-snapshots", snapshotsUsage)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("rebuild-snapshots takes no arguments")
+	}
+
+	rebuilt, err := service.RebuildSnapshots(ctx)
+	for _, asset := range rebuilt {
+		fmt.Fprintf(os.Stdout, "%s\t%d\n", asset.ID(), asset.AggregateVersion())
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "rebuilt the snapshots of %d assets\n", len(rebuilt))
+	return nil
+}
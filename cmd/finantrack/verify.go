@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	assets "github.com/xfrr/finantrack/services/assets/wire"
+)
+
+const verifyUsage = `Usage: finantrack verify [flags] [archive]
+
+Check the integrity of the event log of a household in the database, or of
+an archive without writing anything: every event must be decodable, have a
+unique ID and follow the previous version of its aggregate. The checksums
+and the manifest of the archives are verified too.
+
+Flags:
+`
+
+// runVerify runs the verify command.
+func runVerify(ctx context.Context, service *assets.Service, args []string) error {
+	fs := newFlagSet("verify", verifyUsage)
+
+	householdID := fs.String("household", "", "ID of the household to verify in the database")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		v   xbackup.Verification
+		err error
+	)
+	switch {
+	case fs.NArg() == 1 && *householdID == "":
+		v, err = verifyArchive(service, fs.Arg(0))
+	case fs.NArg() == 0 && *householdID != "":
+		v, err = service.Verify(xtenant.WithTenant(ctx, *householdID))
+	default:
+		fs.Usage()
+		return errors.New("either the household or the archive is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range v.Issues {
+		fmt.Fprintln(os.Stdout, issue)
+	}
+
+	fmt.Fprintf(os.Stderr, "verified %d events of %d aggregates\n", v.Events, v.Aggregates)
+	if !v.OK() {
+		return fmt.Errorf("found %d issues", len(v.Issues))
+	}
+	return nil
+}
+
+func verifyArchive(service *assets.Service, path string) (xbackup.Verification, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return xbackup.Verification{}, err
+	}
+	defer f.Close()
+
+	return service.VerifyArchive(f)
+}
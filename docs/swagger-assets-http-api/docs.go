@@ -21,9 +21,169 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/assets": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the portfolio with all the assets and their totals per currency, currently or as of a point in time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Get all the assets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-12-31T23:59:59Z",
+                        "description": "Point in time (RFC 3339)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.GetAssetsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
         "/assets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current state of an asset, or its state as of a point in time or version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Get an asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-12-31T23:59:59Z",
+                        "description": "Point in time (RFC 3339)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Asset version",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.AssetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            },
             "put": {
-                "description": "Modify an asset",
+                "description": "Modify an asset, not implemented yet",
                 "consumes": [
                     "application/json"
                 ],
@@ -54,8 +214,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "501": {
+                        "description": "Not Implemented",
                         "schema": {
                             "type": "string"
                         }
@@ -63,6 +223,14 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Create a new asset",
                 "consumes": [
                     "application/json"
@@ -91,6 +259,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/assetshttp.CreateAssetRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -99,10 +280,54 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
                     }
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Delete an asset",
                 "consumes": [
                     "application/json"
@@ -122,6 +347,19 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -130,41 +368,2432 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "assetshttp.CreateAssetRequest": {
-            "type": "object",
-            "properties": {
-                "assetMoneyAmount": {
-                    "type": "number",
-                    "example": 1000
-                },
-                "assetMoneyCurrency": {
-                    "type": "string",
-                    "example": "USD"
-                },
-                "assetName": {
-                    "type": "string",
-                    "example": "My Asset"
-                },
-                "assetType": {
-                    "type": "string",
-                    "example": "cash"
+        },
+        "/assets/{id}/account": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Link the bank account with the given IBAN to the asset, so the statements of the account are imported into it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Link a bank account to an asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bank account",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.LinkAssetAccountRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
                 }
             }
         },
-        "assetshttp.ModifyAssetRequest": {
-            "type": "object",
-            "required": [
-                "asset_money_amount",
-                "asset_money_currency",
-                "asset_name",
-                "asset_type"
-            ],
+        "/assets/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the ordered list of changes of an asset. When asOf is given, only the changes made up to that instant are returned along with the asset state at that time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Get the events of an asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First version to return",
+                        "name": "fromVersion",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "description": "Maximum number of events to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-12-31T23:59:59Z",
+                        "description": "Point in time (RFC 3339)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.GetAssetEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/imports/csv": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import the entries of a CSV bank statement as transactions of the asset. The columns are mapped by a named profile or by an inline mapping. Entries already imported are detected by their fingerprint and skipped. With dryRun the import is previewed without recording the transactions.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Import a CSV bank statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "generic",
+                        "description": "Name of the CSV profile",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV profile as JSON, overrides the named profile",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without recording the transactions",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.ImportTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/imports/ofx": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import the transactions of an OFX 1.x (SGML) or 2.x (XML) statement into the asset. Transactions already imported are detected by their FITID and skipped. The asset balance is updated with the ledger balance of the statement. With dryRun the import is previewed without recording any change.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Import an OFX/QFX bank statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "OFX or QFX statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without recording any change",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.ImportTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the transactions of an asset sorted by booking date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get the transactions of an asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.GetTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the transaction categories of the household sorted by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get the categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.GetCategoriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/beancount": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the assets, transactions, transfers and prices of the household as a ledger or hledger journal, or as a beancount file, with the commodity and account declarations. Both legs of the transfers between assets are merged into one transaction, and the exchange rates of the transfers between currencies are exported as prices.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Export a plain-text accounting journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export only the given asset",
+                        "name": "assetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/hledger": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the assets, transactions, transfers and prices of the household as a ledger or hledger journal, or as a beancount file, with the commodity and account declarations. Both legs of the transfers between assets are merged into one transaction, and the exchange rates of the transfers between currencies are exported as prices.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Export a plain-text accounting journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export only the given asset",
+                        "name": "assetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the assets, transactions, transfers and prices of the household as a ledger or hledger journal, or as a beancount file, with the commodity and account declarations. Both legs of the transfers between assets are merged into one transaction, and the exchange rates of the transfers between currencies are exported as prices.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Export a plain-text accounting journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export only the given asset",
+                        "name": "assetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/qif": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the assets, categories and transactions of the household as a QIF file, one account per asset. Investment transactions are exported as cash movements.",
+                "produces": [
+                    "application/qif"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Export a QIF file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export only the given asset",
+                        "name": "assetId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "mdy",
+                            "dmy"
+                        ],
+                        "type": "string",
+                        "default": "mdy",
+                        "description": "Order of the dates",
+                        "name": "dateOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/households": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the households the authenticated user is a member of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get the households of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/assetshttp.HouseholdResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a household and its members. Only the members can read it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get a household",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.HouseholdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new household owned by the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Create a new household",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Household data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.CreateHouseholdRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/members/{userId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Share the household with a user, or change its role. Only the owners can manage the members.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Add or update a household member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.SetHouseholdMemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop sharing the household with a user. Members can leave, but only the owners can remove others.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Remove a household member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "00000000-0000-0000-0000-000000000000",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/camt053": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import the booked entries of a camt.053 XML statement into the asset linked to the IBAN of the statement account. Entries already imported are detected by their bank references and skipped. Booking and value dates are preserved, and the asset balance is updated with the closing balance. With dryRun the import is previewed without recording any change.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Import a ISO 20022 camt.053 statement",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "camt.053 XML statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without recording any change",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.ImportTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/mt940": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import the booked entries of a MT940 statement into the asset linked to the IBAN of the statement account. Entries already imported are detected by their bank references and skipped. Booking and value dates are preserved, and the asset balance is updated with the closing balance. With dryRun the import is previewed without recording any change.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Import a SWIFT MT940 statement",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "MT940 statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without recording any change",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.ImportTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/qif": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import the accounts, categories and transactions of a QIF file. Bank, Cash, CCard, Invst, Oth A and Oth L accounts are supported, including split transactions and transfers. Transactions are imported into the asset named after their account, which is created with the given currency when missing, and the categories are created when missing. Investment transactions are imported as their cash movement. With dryRun the import is previewed without recording any change.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Import a QIF file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "QIF file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "EUR",
+                        "description": "Currency of the assets created for unknown accounts",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account of the transactions without account record, the file name by default",
+                        "name": "account",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "mdy",
+                            "dmy"
+                        ],
+                        "type": "string",
+                        "default": "mdy",
+                        "description": "Order of the dates",
+                        "name": "dateOrder",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": ".",
+                        "description": "Decimal separator of the amounts, a dot or a comma",
+                        "name": "decimalSeparator",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "windows-1252",
+                        "description": "Character encoding of the file",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the import without recording any change",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.ImportQIFResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/allocation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get how the money of the household is distributed among the asset types, per currency. Credit cards hold debts and are left out. The html and pdf formats lay the report out with charts, ready to be printed or shared.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get the asset allocation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-12-31T23:59:59Z",
+                        "description": "Point in time (RFC 3339)",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "html",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Format of the report",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.AllocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/categories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the money earned and spent by the household per month, quarter or year and category, along with the share of each category in the income or expenses of the period. Without range, the last twelve months, four quarters or five years are returned. The html and pdf formats lay the report out with charts, ready to be printed or shared.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get the category breakdown",
+                "parameters": [
+                    {
+                        "enum": [
+                            "monthly",
+                            "quarterly",
+                            "yearly"
+                        ],
+                        "type": "string",
+                        "default": "monthly",
+                        "description": "Length of the periods",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-01-01",
+                        "description": "First day of the report",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-12-31",
+                        "description": "Last day of the report, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "html",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Format of the report",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.CategoryBreakdownResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/income-statement": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the income, expenses, net savings and savings rate of the household per month, quarter or year, one row per currency. Transfers between accounts are neither income nor expenses. Without range, the last twelve months, four quarters or five years are returned. The html and pdf formats lay the report out with charts, ready to be printed or shared.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get the income statement",
+                "parameters": [
+                    {
+                        "enum": [
+                            "monthly",
+                            "quarterly",
+                            "yearly"
+                        ],
+                        "type": "string",
+                        "default": "monthly",
+                        "description": "Length of the periods",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-01-01",
+                        "description": "First day of the report",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-12-31",
+                        "description": "Last day of the report, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "html",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Format of the report",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "X-Household-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/assetshttp.IncomeStatementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/xhttp.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "assetshttp.AllocationItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 12500
+                },
+                "assetType": {
+                    "type": "string",
+                    "example": "bank"
+                },
+                "assets": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "share": {
+                    "type": "number",
+                    "example": 0.625
+                }
+            }
+        },
+        "assetshttp.AllocationResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.AllocationItemResponse"
+                    }
+                },
+                "asOf": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                }
+            }
+        },
+        "assetshttp.AssetEventMetadataResponse": {
+            "type": "object",
+            "properties": {
+                "causationId": {
+                    "type": "string"
+                },
+                "correlationId": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "assets"
+                },
+                "traceId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "assetshttp.AssetEventResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/assetshttp.AssetEventMetadataResponse"
+                },
+                "payload": {},
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "asset.created"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "assetshttp.AssetResponse": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "assetMoneyAmount": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "assetMoneyCurrency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "assetName": {
+                    "type": "string",
+                    "example": "My Asset"
+                },
+                "assetType": {
+                    "type": "string",
+                    "example": "cash"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "iban": {
+                    "type": "string",
+                    "example": "DE89370400440532013000"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "assetshttp.CategoryBreakdownResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.PeriodCategoryResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "granularity": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-12-31"
+                }
+            }
+        },
+        "assetshttp.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "expense"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Food:Groceries"
+                }
+            }
+        },
+        "assetshttp.CreateAssetRequest": {
+            "type": "object",
+            "properties": {
+                "assetMoneyAmount": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "assetMoneyCurrency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "assetName": {
+                    "type": "string",
+                    "example": "My Asset"
+                },
+                "assetType": {
+                    "type": "string",
+                    "example": "cash"
+                },
+                "iban": {
+                    "type": "string",
+                    "example": "DE89370400440532013000"
+                }
+            }
+        },
+        "assetshttp.CreateHouseholdRequest": {
+            "type": "object",
+            "properties": {
+                "householdName": {
+                    "type": "string",
+                    "example": "Home"
+                }
+            }
+        },
+        "assetshttp.GetAssetEventsResponse": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "$ref": "#/definitions/assetshttp.AssetResponse"
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.AssetEventResponse"
+                    }
+                },
+                "nextFromVersion": {
+                    "type": "integer"
+                }
+            }
+        },
+        "assetshttp.GetAssetsResponse": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string"
+                },
+                "assets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.AssetResponse"
+                    }
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.MoneyResponse"
+                    }
+                }
+            }
+        },
+        "assetshttp.GetCategoriesResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.CategoryResponse"
+                    }
+                }
+            }
+        },
+        "assetshttp.GetTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.TransactionResponse"
+                    }
+                }
+            }
+        },
+        "assetshttp.HouseholdResponse": {
+            "type": "object",
+            "properties": {
+                "householdId": {
+                    "type": "string"
+                },
+                "householdName": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.MemberResponse"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "assetshttp.ImportQIFResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.ImportedAccountResponse"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.ImportedCategoryResponse"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "assetshttp.ImportTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "balance": {
+                    "$ref": "#/definitions/assetshttp.ImportedBalanceResponse"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "duplicates": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.ImportedTransactionResponse"
+                    }
+                }
+            }
+        },
+        "assetshttp.ImportedAccountResponse": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "assetType": {
+                    "type": "string",
+                    "example": "bank"
+                },
+                "balance": {
+                    "$ref": "#/definitions/assetshttp.ImportedBalanceResponse"
+                },
+                "created": {
+                    "type": "boolean"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "duplicates": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Checking"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.ImportedTransactionResponse"
+                    }
+                }
+            }
+        },
+        "assetshttp.ImportedBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1250
+                },
+                "asOf": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "outdated": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "assetshttp.ImportedCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "expense"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Food:Groceries"
+                }
+            }
+        },
+        "assetshttp.ImportedTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -42.5
+                },
+                "bookingDate": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duplicate": {
+                    "type": "boolean"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "payee": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "transactionId": {
+                    "type": "string"
+                },
+                "valueDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "assetshttp.IncomeStatementResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "granularity": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.PeriodSummaryResponse"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-12-31"
+                }
+            }
+        },
+        "assetshttp.LinkAssetAccountRequest": {
+            "type": "object",
+            "required": [
+                "iban"
+            ],
+            "properties": {
+                "iban": {
+                    "type": "string",
+                    "example": "DE89 3704 0044 0532 0130 00"
+                }
+            }
+        },
+        "assetshttp.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "editor"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "assetshttp.ModifyAssetRequest": {
+            "type": "object",
+            "required": [
+                "asset_money_amount",
+                "asset_money_currency",
+                "asset_name",
+                "asset_type"
+            ],
             "properties": {
                 "asset_money_amount": {
                     "type": "number"
@@ -179,6 +2808,223 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "assetshttp.MoneyResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "assetshttp.PeriodCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 420.5
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Food:Groceries"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "expense"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2024-01"
+                },
+                "share": {
+                    "type": "number",
+                    "example": 0.2336
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                }
+            }
+        },
+        "assetshttp.PeriodSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2024-01-31"
+                },
+                "expenses": {
+                    "type": "number",
+                    "example": 1800
+                },
+                "income": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "net": {
+                    "type": "number",
+                    "example": 700
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2024-01"
+                },
+                "savingsRate": {
+                    "type": "number",
+                    "example": 0.28
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2024-01-01"
+                }
+            }
+        },
+        "assetshttp.SetHouseholdMemberRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ],
+                    "example": "editor"
+                }
+            }
+        },
+        "assetshttp.SplitResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -42.5
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Food:Groceries"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "transfer": {
+                    "type": "string"
+                }
+            }
+        },
+        "assetshttp.TransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -42.5
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "bookingDate": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Food:Groceries"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payee": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "csv"
+                },
+                "splits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/assetshttp.SplitResponse"
+                    }
+                },
+                "transfer": {
+                    "type": "string",
+                    "example": "Savings"
+                },
+                "valueDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "xhttp.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "asset_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "asset not found"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/assets/00000000-0000-0000-0000-000000000000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
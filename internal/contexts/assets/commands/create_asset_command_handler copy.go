@@ -2,9 +2,13 @@ package assetscommands
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
 )
 
 type CreateAssetCommand struct {
@@ -13,6 +17,9 @@ type CreateAssetCommand struct {
 	AssetType          string
 	AssetMoneyAmount   float64
 	AssetMoneyCurrency string
+
+	// AssetIBAN is the bank account linked to the asset, if any.
+	AssetIBAN string
 }
 
 func (c CreateAssetCommand) CommandName() string {
@@ -39,13 +46,21 @@ func (h *CreateAssetCommandHandler) Handle(ctx context.Context, cmd CreateAssetC
 		assetType = assets.AssetType(cmd.AssetType)
 	)
 
+	// Assets always belong to the household of the request
+	tenantID, ok := xtenant.FromContext(ctx)
+	if !ok {
+		return nil, xtenant.ErrTenantRequired
+	}
+
+	// The owner is the authenticated user, if any
+	principal, _ := xauth.PrincipalFromContext(ctx)
+
 	assetID, err := uuid.Parse(cmd.AssetID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
 	}
 
 	// Check if the asset already exists
-	var ok bool
 	if ok, err = h.assets.Exists(ctx, assetID); err != nil {
 		return nil, err
 	} else if ok {
@@ -61,6 +76,8 @@ func (h *CreateAssetCommandHandler) Handle(ctx context.Context, cmd CreateAssetC
 	// Creates a new asset entity from the given data
 	asset, err = assets.NewAsset(
 		assetID,
+		tenantID,
+		principal.Subject,
 		assetName,
 		assetType,
 		assetMoney,
@@ -69,8 +86,24 @@ func (h *CreateAssetCommandHandler) Handle(ctx context.Context, cmd CreateAssetC
 		return nil, err
 	}
 
+	// Link the bank account of the asset
+	if cmd.AssetIBAN != "" {
+		if err = linkAccount(ctx, h.assets, asset, cmd.AssetIBAN); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: Publish event
 
 	// Save the asset
-	return nil, h.assets.Save(ctx, asset)
+	if err = h.assets.Save(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("asset_id", asset.ID().String()).
+		Str("asset_type", asset.Type().String()).
+		Msg("asset created")
+
+	return nil, nil
 }
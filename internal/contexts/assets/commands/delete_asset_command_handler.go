@@ -2,9 +2,11 @@ package assetscommands
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
 )
 
 type DeleteAssetCommand struct {
@@ -34,7 +36,7 @@ func (h *DeleteAssetCommandHandler) Handle(ctx context.Context, cmd DeleteAssetC
 	// Parse the asset ID
 	assetID, err := uuid.Parse(cmd.AssetID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
 	}
 
 	// Get the asset by ID
@@ -51,5 +53,13 @@ func (h *DeleteAssetCommandHandler) Handle(ctx context.Context, cmd DeleteAssetC
 	asset.MarkAsDeleted()
 
 	// Save the asset
-	return nil, h.assets.Save(ctx, asset)
+	if err = h.assets.Save(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("asset_id", asset.ID().String()).
+		Msg("asset deleted")
+
+	return nil, nil
 }
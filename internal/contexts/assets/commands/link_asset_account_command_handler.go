@@ -0,0 +1,72 @@
+package assetscommands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+)
+
+type LinkAssetAccountCommand struct {
+	AssetID string
+	IBAN    string
+}
+
+func (c LinkAssetAccountCommand) CommandName() string {
+	return "LinkAssetAccountCommand"
+}
+
+type LinkAssetAccountCommandHandler struct {
+	assets assets.Repository
+}
+
+func NewLinkAssetAccountCommandHandler(assets assets.Repository) *LinkAssetAccountCommandHandler {
+	return &LinkAssetAccountCommandHandler{
+		assets: assets,
+	}
+}
+
+func (h *LinkAssetAccountCommandHandler) Handle(ctx context.Context, cmd LinkAssetAccountCommand) (interface{}, error) {
+	assetID, err := uuid.Parse(cmd.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
+	}
+
+	asset, err := h.assets.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = linkAccount(ctx, h.assets, asset, cmd.IBAN); err != nil {
+		return nil, err
+	}
+
+	if err = h.assets.Save(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("asset_id", asset.ID().String()).
+		Msg("asset account linked")
+
+	return nil, nil
+}
+
+// linkAccount links the account to the asset, unless it is linked
+// to another asset of the household already.
+func linkAccount(ctx context.Context, repository assets.Repository, asset *assets.Asset, iban string) error {
+	all, err := repository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range all {
+		if other.ID() != asset.ID() && other.IBAN() == assets.NormalizeIBAN(iban) {
+			return assets.ErrAssetAccountAlreadyLinked
+		}
+	}
+
+	return asset.LinkAccount(iban)
+}
@@ -0,0 +1,52 @@
+package assetdomain
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidIBAN represents the error when the account IBAN is not valid.
+var ErrInvalidIBAN = errors.New("invalid iban")
+
+// NormalizeIBAN returns the electronic format of an IBAN, in upper case and without spaces.
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+// ValidateIBAN checks the format and the check digits of an IBAN.
+func ValidateIBAN(iban string) error {
+	iban = NormalizeIBAN(iban)
+	if len(iban) < 15 || len(iban) > 34 {
+		return ErrInvalidIBAN
+	}
+
+	for i, r := range iban {
+		switch {
+		case i < 2 && (r < 'A' || r > 'Z'):
+			return ErrInvalidIBAN
+		case i >= 2 && i < 4 && (r < '0' || r > '9'):
+			return ErrInvalidIBAN
+		case (r < 'A' || r > 'Z') && (r < '0' || r > '9'):
+			return ErrInvalidIBAN
+		}
+	}
+
+	// move the country code and check digits to the end and convert the letters to numbers
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+			continue
+		}
+		digits.WriteRune(r)
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok || new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return ErrInvalidIBAN
+	}
+
+	return nil
+}
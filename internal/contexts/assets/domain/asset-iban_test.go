@@ -0,0 +1,30 @@
+package assetdomain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+)
+
+func TestValidateIBAN(t *testing.T) {
+	var specs = []struct {
+		name        string
+		iban        string
+		expectedErr error
+	}{
+		{name: "valid", iban: "DE89370400440532013000"},
+		{name: "valid with spaces and lower case", iban: "gb82 west 1234 5698 7654 32"},
+		{name: "invalid check digits", iban: "DE88370400440532013000", expectedErr: ErrInvalidIBAN},
+		{name: "invalid characters", iban: "DE89-3704-0044-0532-0130-00", expectedErr: ErrInvalidIBAN},
+		{name: "too short", iban: "DE89", expectedErr: ErrInvalidIBAN},
+		{name: "not an iban", iban: "123456789012345", expectedErr: ErrInvalidIBAN},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			assert.Equal(t, spec.expectedErr, ValidateIBAN(spec.iban))
+		})
+	}
+}
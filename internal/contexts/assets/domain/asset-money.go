@@ -30,6 +30,20 @@ func (m Money) Validate() error {
 	return nil
 }
 
+// ValidateFor validates the money held by an asset of the given type,
+// which can be negative if the type allows a negative balance.
+func (m Money) ValidateFor(assetType AssetType) error {
+	if m.Amount < 0 && !assetType.AllowsNegativeBalance() {
+		return ErrMoneyAmountCannotBeNegative
+	}
+
+	if !m.Currency.IsValid() {
+		return ErrUnsupportedCurrency
+	}
+
+	return nil
+}
+
 // NewMoney creates a new Money object with the given amount and currency.
 func NewMoney(amount float64, currency string) (Money, error) {
 	money := Money{
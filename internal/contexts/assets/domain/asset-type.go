@@ -9,6 +9,9 @@ const (
 	// AssetTypeBank represents the bank asset type.
 	AssetTypeBank AssetType = "bank"
 
+	// AssetTypeCreditCard represents the credit card asset type.
+	AssetTypeCreditCard AssetType = "credit_card"
+
 	// AssetTypeInvestment represents the investment asset type.
 	AssetTypeInvestment AssetType = "investment"
 
@@ -32,9 +35,15 @@ func (at AssetType) String() string {
 // Validate validates the asset type.
 func (at AssetType) Validate() error {
 	switch at {
-	case AssetTypeCash, AssetTypeBank, AssetTypeInvestment, AssetTypeOther:
+	case AssetTypeCash, AssetTypeBank, AssetTypeCreditCard, AssetTypeInvestment, AssetTypeOther:
 		return nil
 	}
 
 	return ErrInvalidAssetType
 }
+
+// AllowsNegativeBalance reports whether the assets of the type can hold a negative balance:
+// bank accounts can be overdrawn and credit cards owe their balance.
+func (at AssetType) AllowsNegativeBalance() bool {
+	return at == AssetTypeBank || at == AssetTypeCreditCard
+}
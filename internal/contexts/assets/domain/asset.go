@@ -2,6 +2,9 @@ package assetdomain
 
 import (
 	"errors"
+	"fmt"
+	"iter"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/xfrr/go-cqrsify/aggregate"
@@ -22,9 +25,30 @@ var (
 	// ErrAssetMoneyIsInvalid represents the error when the asset money is invalid.
 	ErrAssetMoneyIsInvalid = errors.New("asset money is invalid")
 
+	// ErrInvalidAssetID represents the error when the asset ID is not a valid UUID.
+	ErrInvalidAssetID = errors.New("invalid asset identifier")
+
 	// ErrAssetNotFound represents the error when the asset is not found.
 	ErrAssetNotFound = errors.New("asset not found")
 
+	// ErrAssetTenantMismatch represents the error when an asset is saved on behalf of another tenant.
+	ErrAssetTenantMismatch = errors.New("asset belongs to another tenant")
+
+	// ErrAssetTenantRequired represents the error when an asset is assigned to no tenant.
+	ErrAssetTenantRequired = errors.New("asset tenant is required")
+
+	// ErrAssetCurrencyMismatch represents the error when the asset balance is given in another currency.
+	ErrAssetCurrencyMismatch = errors.New("balance currency does not match the asset currency")
+
+	// ErrAssetAccountAlreadyLinked represents the error when the bank account is linked to another asset.
+	ErrAssetAccountAlreadyLinked = errors.New("bank account is already linked to another asset")
+
+	// ErrAssetSnapshotsDisabled represents the error when the assets are saved without a snapshot store.
+	ErrAssetSnapshotsDisabled = errors.New("asset snapshots are not enabled")
+
+	// ErrAssetHasUncommittedChanges represents the error when the snapshot of an asset is taken before saving its changes.
+	ErrAssetHasUncommittedChanges = errors.New("asset has uncommitted changes")
+
 	// ErrAssetAlreadyExists represents the error when the asset already exists.
 	ErrAssetAlreadyExists = errors.New("asset already exists with given identifier")
 )
@@ -34,16 +58,25 @@ var (
 type Asset struct {
 	*aggregate.Base[uuid.UUID]
 
+	tenantID  string
+	ownerID   string
 	name      string
 	assetType AssetType
 	money     Money
+	iban      string
 	deleted   bool
+
+	// balanceAsOf is the time of the last balance applied to the money, if any.
+	balanceAsOf time.Time
 }
 
 // NewAsset creates a new Asset instance with the given data.
-func NewAsset(id uuid.UUID, name string, assetType AssetType, money Money) (*Asset, error) {
+// The tenant is the household the asset belongs to and the owner the user that created it.
+func NewAsset(id uuid.UUID, tenantID, ownerID string, name string, assetType AssetType, money Money) (*Asset, error) {
 	asset := &Asset{
 		Base:      aggregate.New(id, AggregateType),
+		tenantID:  tenantID,
+		ownerID:   ownerID,
 		name:      name,
 		assetType: assetType,
 		money:     money,
@@ -56,6 +89,8 @@ func NewAsset(id uuid.UUID, name string, assetType AssetType, money Money) (*Ass
 		assetevents.AssetCreatedEventType,
 		&assetevents.AssetCreatedEvent{
 			AssetID:            id.String(),
+			TenantID:           tenantID,
+			OwnerID:            ownerID,
 			AssetName:          name,
 			AssetType:          assetType.String(),
 			AssetMoneyAmount:   money.Amount,
@@ -66,6 +101,9 @@ func NewAsset(id uuid.UUID, name string, assetType AssetType, money Money) (*Ass
 	// Register the event handlers
 	asset.When(assetevents.AssetCreatedEventType, asset.assetCreatedEventHandler)
 	asset.When(assetevents.AssetDeletedEventType, asset.assetDeletedEventHandler)
+	asset.When(assetevents.AssetBalanceUpdatedEventType, asset.assetBalanceUpdatedEventHandler)
+	asset.When(assetevents.AssetAccountLinkedEventType, asset.assetAccountLinkedEventHandler)
+	asset.When(assetevents.AssetTenantAssignedEventType, asset.assetTenantAssignedEventHandler)
 
 	err := asset.Validate()
 	if err != nil {
@@ -80,6 +118,17 @@ func (a *Asset) ID() uuid.UUID {
 	return a.Base.AggregateID()
 }
 
+// TenantID returns the ID of the household the asset belongs to.
+// It is empty for the assets created before households were introduced, until they are assigned one.
+func (a *Asset) TenantID() string {
+	return a.tenantID
+}
+
+// OwnerID returns the ID of the user that created the asset.
+func (a *Asset) OwnerID() string {
+	return a.ownerID
+}
+
 // Name returns the asset name.
 func (a *Asset) Name() string {
 	return a.name
@@ -95,6 +144,11 @@ func (a *Asset) Money() Money {
 	return a.money
 }
 
+// IBAN returns the IBAN of the bank account linked to the asset, if any.
+func (a *Asset) IBAN() string {
+	return a.iban
+}
+
 // IsDeleted checks if the asset is deleted.
 func (a *Asset) IsDeleted() bool {
 	return a.deleted
@@ -116,6 +170,101 @@ func (a *Asset) MarkAsDeleted() {
 	)
 }
 
+// LinkAccount links the bank account with the given IBAN to the asset,
+// so its statements are imported into the asset.
+func (a *Asset) LinkAccount(iban string) error {
+	if err := ValidateIBAN(iban); err != nil {
+		return err
+	}
+
+	iban = NormalizeIBAN(iban)
+	if iban == a.iban {
+		return nil
+	}
+
+	aggregate.NextChange(
+		a,
+		uuid.New(),
+		assetevents.AssetAccountLinkedEventType,
+		&assetevents.AssetAccountLinkedEvent{
+			AssetID: a.ID().String(),
+			IBAN:    iban,
+		},
+	)
+
+	return nil
+}
+
+// AssignTenant assigns the asset, created before households were introduced,
+// to the household with the given ID. An asset of another household cannot be reassigned.
+func (a *Asset) AssignTenant(tenantID string) error {
+	if tenantID == "" {
+		return ErrAssetTenantRequired
+	}
+
+	if tenantID == a.tenantID {
+		return nil
+	}
+
+	if a.tenantID != "" {
+		return ErrAssetTenantMismatch
+	}
+
+	aggregate.NextChange(
+		a,
+		uuid.New(),
+		assetevents.AssetTenantAssignedEventType,
+		&assetevents.AssetTenantAssignedEvent{
+			AssetID:  a.ID().String(),
+			TenantID: tenantID,
+		},
+	)
+
+	return nil
+}
+
+// BalanceAsOf returns the time of the last balance applied to the asset money,
+// which is zero if no balance was applied.
+func (a *Asset) BalanceAsOf() time.Time {
+	return a.balanceAsOf
+}
+
+// UpdateBalance sets the asset money to the balance reported at the given time,
+// e.g. the closing balance of a bank statement. The currency cannot change, and the balance
+// can only be negative if the asset type allows it. Balances reported before the last one
+// applied are outdated, e.g. of a statement imported late, so they are ignored.
+func (a *Asset) UpdateBalance(money Money, asOf time.Time) error {
+	if err := money.ValidateFor(a.assetType); err != nil {
+		return err
+	}
+
+	if money.Currency != a.money.Currency {
+		return ErrAssetCurrencyMismatch
+	}
+
+	if asOf.Before(a.balanceAsOf) {
+		return nil
+	}
+
+	if money.Amount == a.money.Amount && !asOf.After(a.balanceAsOf) {
+		return nil
+	}
+
+	aggregate.NextChange(
+		a,
+		uuid.New(),
+		assetevents.AssetBalanceUpdatedEventType,
+		&assetevents.AssetBalanceUpdatedEvent{
+			AssetID:            a.ID().String(),
+			AssetMoneyAmount:   money.Amount,
+			AssetMoneyCurrency: money.Currency.String(),
+			AsOf:               asOf,
+		},
+	)
+
+	return nil
+}
+
 // Validate validates the asset.
 func (a *Asset) Validate() error {
 	if _, err := uuid.Parse(a.ID().String()); err != nil {
@@ -131,7 +280,7 @@ func (a *Asset) Validate() error {
 		return err
 	}
 
-	err = a.money.Validate()
+	err = a.money.ValidateFor(a.assetType)
 	if err != nil {
 		return err
 	}
@@ -139,7 +288,25 @@ func (a *Asset) Validate() error {
 	return nil
 }
 
+// HydrateAsset rebuilds the asset with the given ID from its changes.
 func HydrateAsset(id uuid.UUID, events []aggregate.Change) (*Asset, error) {
+	asset := emptyAsset(id)
+
+	err := aggregate.Hydrate(asset, events)
+	if err != nil {
+		return nil, err
+	}
+
+	err = asset.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+// emptyAsset returns the asset with the given ID and no state, ready to apply its changes.
+func emptyAsset(id uuid.UUID) *Asset {
 	asset := &Asset{
 		Base: aggregate.New(id, AggregateType),
 	}
@@ -147,18 +314,69 @@ func HydrateAsset(id uuid.UUID, events []aggregate.Change) (*Asset, error) {
 	// Register the event handlers
 	asset.When(assetevents.AssetCreatedEventType, asset.assetCreatedEventHandler)
 	asset.When(assetevents.AssetDeletedEventType, asset.assetDeletedEventHandler)
+	asset.When(assetevents.AssetBalanceUpdatedEventType, asset.assetBalanceUpdatedEventHandler)
+	asset.When(assetevents.AssetAccountLinkedEventType, asset.assetAccountLinkedEventHandler)
+	asset.When(assetevents.AssetTenantAssignedEventType, asset.assetTenantAssignedEventHandler)
 
-	err := aggregate.Hydrate(asset, events)
-	if err != nil {
-		return nil, err
+	return asset
+}
+
+// HydrateAssets rebuilds the assets from a sequence of changes
+// sorted by aggregate ID and version. Deleted assets are skipped.
+func HydrateAssets(changes iter.Seq2[aggregate.Change, error]) ([]*Asset, error) {
+	var (
+		assets  []*Asset
+		id      uuid.UUID
+		pending []aggregate.Change
+	)
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		asset, err := HydrateAsset(id, pending)
+		if err != nil {
+			return fmt.Errorf("failed to hydrate asset %s: %w", id, err)
+		}
+
+		if !asset.IsDeleted() {
+			assets = append(assets, asset)
+		}
+
+		pending = nil
+		return nil
 	}
 
-	err = asset.Validate()
-	if err != nil {
+	for change, err := range changes {
+		if err != nil {
+			return nil, err
+		}
+
+		if change.Aggregate() == nil {
+			return nil, aggregate.ErrInvalidEventAggregateReference
+		}
+
+		changeAggregateID, ok := change.Aggregate().ID.(uuid.UUID)
+		if !ok {
+			return nil, aggregate.ErrInvalidAggregateID
+		}
+
+		if changeAggregateID != id {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+			id = changeAggregateID
+		}
+
+		pending = append(pending, change)
+	}
+
+	if err := flush(); err != nil {
 		return nil, err
 	}
 
-	return asset, nil
+	return assets, nil
 }
 
 // assetCreatedEventHandler is the event handler for the asset created event.
@@ -168,6 +386,8 @@ func (a *Asset) assetCreatedEventHandler(event aggregate.Change) {
 		return
 	}
 
+	a.tenantID = evt.TenantID
+	a.ownerID = evt.OwnerID
 	a.name = evt.AssetName
 	a.assetType = AssetType(evt.AssetType)
 	a.money = Money{
@@ -176,6 +396,40 @@ func (a *Asset) assetCreatedEventHandler(event aggregate.Change) {
 	}
 }
 
+// assetBalanceUpdatedEventHandler is the event handler for the asset balance updated event.
+func (a *Asset) assetBalanceUpdatedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*assetevents.AssetBalanceUpdatedEvent)
+	if !ok {
+		return
+	}
+
+	a.money = Money{
+		Amount:   evt.AssetMoneyAmount,
+		Currency: Currency(evt.AssetMoneyCurrency),
+	}
+	a.balanceAsOf = evt.AsOf
+}
+
+// assetAccountLinkedEventHandler is the event handler for the asset account linked event.
+func (a *Asset) assetAccountLinkedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*assetevents.AssetAccountLinkedEvent)
+	if !ok {
+		return
+	}
+
+	a.iban = evt.IBAN
+}
+
+// assetTenantAssignedEventHandler is the event handler for the asset tenant assigned event.
+func (a *Asset) assetTenantAssignedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*assetevents.AssetTenantAssignedEvent)
+	if !ok {
+		return
+	}
+
+	a.tenantID = evt.TenantID
+}
+
 // assetDeletedEventHandler is the event handler for the asset deleted event.
 func (a *Asset) assetDeletedEventHandler(_ aggregate.Change) {
 	a.deleted = true
@@ -0,0 +1,180 @@
+package assetdomain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetevents "github.com/xfrr/finantrack/internal/contexts/assets/domain/events"
+
+	. "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+)
+
+func TestAsset_AssignTenant(t *testing.T) {
+	newAsset := func(t *testing.T, tenantID string) *Asset {
+		t.Helper()
+		asset, err := NewAsset(uuid.New(), tenantID, "", "Savings", AssetTypeBank, Money{Amount: 100, Currency: EUR})
+		require.NoError(t, err)
+		return asset
+	}
+
+	t.Run("assign an asset without tenant", func(t *testing.T) {
+		asset := newAsset(t, "")
+
+		require.NoError(t, asset.AssignTenant("household"))
+		assert.Equal(t, "household", asset.TenantID())
+
+		changes := asset.AggregateChanges()
+		require.Len(t, changes, 2)
+		assert.Equal(t, assetevents.AssetTenantAssignedEventType, changes[1].Reason())
+
+		hydrated, err := HydrateAsset(asset.ID(), changes)
+		require.NoError(t, err)
+		assert.Equal(t, "household", hydrated.TenantID())
+	})
+
+	t.Run("assign an asset to its tenant does nothing", func(t *testing.T) {
+		asset := newAsset(t, "household")
+
+		require.NoError(t, asset.AssignTenant("household"))
+		assert.Len(t, asset.AggregateChanges(), 1)
+	})
+
+	t.Run("assign an asset of another tenant should return error", func(t *testing.T) {
+		asset := newAsset(t, "household")
+
+		require.ErrorIs(t, asset.AssignTenant("other"), ErrAssetTenantMismatch)
+		assert.Equal(t, "household", asset.TenantID())
+	})
+
+	t.Run("assign an asset to no tenant should return error", func(t *testing.T) {
+		require.ErrorIs(t, newAsset(t, "").AssignTenant(""), ErrAssetTenantRequired)
+	})
+}
+
+func TestAsset_UpdateBalance(t *testing.T) {
+	var (
+		january  = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		february = time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	)
+
+	newAsset := func(t *testing.T, assetType AssetType) *Asset {
+		t.Helper()
+		asset, err := NewAsset(uuid.New(), "household", "", "Account", assetType, Money{Amount: 100, Currency: EUR})
+		require.NoError(t, err)
+		return asset
+	}
+
+	t.Run("update the balance of an overdrawn bank account", func(t *testing.T) {
+		asset := newAsset(t, AssetTypeBank)
+
+		require.NoError(t, asset.UpdateBalance(Money{Amount: -250.5, Currency: EUR}, january))
+		assert.Equal(t, -250.5, asset.Money().Amount)
+		assert.Equal(t, january, asset.BalanceAsOf())
+
+		hydrated, err := HydrateAsset(asset.ID(), asset.AggregateChanges())
+		require.NoError(t, err)
+		assert.Equal(t, -250.5, hydrated.Money().Amount)
+		assert.Equal(t, january, hydrated.BalanceAsOf())
+	})
+
+	t.Run("update the balance of a credit card to a debt", func(t *testing.T) {
+		asset := newAsset(t, AssetTypeCreditCard)
+
+		require.NoError(t, asset.UpdateBalance(Money{Amount: -120, Currency: EUR}, january))
+		assert.Equal(t, -120.0, asset.Money().Amount)
+	})
+
+	t.Run("update the balance of cash to a negative amount should return error", func(t *testing.T) {
+		asset := newAsset(t, AssetTypeCash)
+
+		require.ErrorIs(t, asset.UpdateBalance(Money{Amount: -1, Currency: EUR}, january), ErrMoneyAmountCannotBeNegative)
+		assert.Equal(t, 100.0, asset.Money().Amount)
+	})
+
+	t.Run("ignore a balance older than the last one applied", func(t *testing.T) {
+		asset := newAsset(t, AssetTypeBank)
+
+		require.NoError(t, asset.UpdateBalance(Money{Amount: 300, Currency: EUR}, february))
+		require.NoError(t, asset.UpdateBalance(Money{Amount: 200, Currency: EUR}, january))
+		assert.Equal(t, 300.0, asset.Money().Amount)
+		assert.Equal(t, february, asset.BalanceAsOf())
+		assert.Len(t, asset.AggregateChanges(), 2)
+	})
+
+	t.Run("record a newer balance with the same amount", func(t *testing.T) {
+		asset := newAsset(t, AssetTypeBank)
+
+		require.NoError(t, asset.UpdateBalance(Money{Amount: 100, Currency: EUR}, february))
+		assert.Equal(t, february, asset.BalanceAsOf())
+
+		// the same balance again changes nothing
+		require.NoError(t, asset.UpdateBalance(Money{Amount: 100, Currency: EUR}, february))
+		assert.Len(t, asset.AggregateChanges(), 2)
+
+		// so an older statement imported afterwards is still ignored
+		require.NoError(t, asset.UpdateBalance(Money{Amount: 50, Currency: EUR}, january))
+		assert.Equal(t, 100.0, asset.Money().Amount)
+	})
+
+	t.Run("update the balance in another currency should return error", func(t *testing.T) {
+		require.ErrorIs(t, newAsset(t, AssetTypeBank).UpdateBalance(Money{Amount: 1, Currency: USD}, january), ErrAssetCurrencyMismatch)
+	})
+}
+
+func TestRestoreAsset(t *testing.T) {
+	january := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	asset, err := NewAsset(uuid.New(), "household", "owner", "Account", AssetTypeBank, Money{Amount: 100, Currency: EUR})
+	require.NoError(t, err)
+	require.NoError(t, asset.LinkAccount("ES9121000418450200051332"))
+	require.NoError(t, asset.UpdateBalance(Money{Amount: 250, Currency: EUR}, january))
+
+	changes := asset.AggregateChanges()
+	require.Len(t, changes, 3)
+
+	// the snapshot of the asset after its first two changes
+	snapshotted, err := HydrateAsset(asset.ID(), changes[:2])
+	require.NoError(t, err)
+	snapshot := snapshotted.Snapshot()
+
+	t.Run("restore an asset from its snapshot and the later changes", func(t *testing.T) {
+		restored, err := RestoreAsset(asset.ID(), 2, snapshot, changes[2:])
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, int(restored.AggregateVersion()))
+		assert.Empty(t, restored.AggregateChanges())
+		assert.Equal(t, "household", restored.TenantID())
+		assert.Equal(t, "owner", restored.OwnerID())
+		assert.Equal(t, "ES9121000418450200051332", restored.IBAN())
+		assert.Equal(t, 250.0, restored.Money().Amount)
+		assert.Equal(t, january, restored.BalanceAsOf())
+
+		// the next change follows the restored version
+		restored.MarkAsDeleted()
+		require.Len(t, restored.AggregateChanges(), 1)
+		assert.Equal(t, 4, restored.AggregateChanges()[0].Aggregate().Version)
+	})
+
+	t.Run("restore an asset from its snapshot without later changes", func(t *testing.T) {
+		restored, err := RestoreAsset(asset.ID(), 2, snapshot, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, int(restored.AggregateVersion()))
+		assert.Equal(t, 100.0, restored.Money().Amount)
+		assert.Equal(t, "ES9121000418450200051332", restored.IBAN())
+	})
+
+	t.Run("restore an asset with a gap after its snapshot should return error", func(t *testing.T) {
+		_, err := RestoreAsset(asset.ID(), 1, snapshot, changes[2:])
+		assert.Error(t, err)
+	})
+
+	t.Run("restore an asset from an invalid snapshot should return error", func(t *testing.T) {
+		_, err := RestoreAsset(asset.ID(), 2, Snapshot{}, nil)
+		assert.ErrorIs(t, err, ErrAssetNameIsRequired)
+	})
+}
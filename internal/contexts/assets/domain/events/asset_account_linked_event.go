@@ -0,0 +1,8 @@
+package assetevents
+
+const AssetAccountLinkedEventType = "asset.account_linked"
+
+type AssetAccountLinkedEvent struct {
+	AssetID string `json:"assetId"`
+	IBAN    string `json:"iban"`
+}
@@ -0,0 +1,12 @@
+package assetevents
+
+import "time"
+
+const AssetBalanceUpdatedEventType = "asset.balance_updated"
+
+type AssetBalanceUpdatedEvent struct {
+	AssetID            string    `json:"assetId"`
+	AssetMoneyAmount   float64   `json:"assetMoneyAmount"`
+	AssetMoneyCurrency string    `json:"assetMoneyCurrency"`
+	AsOf               time.Time `json:"asOf"`
+}
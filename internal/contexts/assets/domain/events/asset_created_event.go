@@ -3,9 +3,11 @@ package assetevents
 const AssetCreatedEventType = "asset.created"
 
 type AssetCreatedEvent struct {
-	AssetID            string
-	AssetType          string
-	AssetName          string
-	AssetMoneyAmount   float64
-	AssetMoneyCurrency string
+	AssetID            string  `json:"assetId"`
+	TenantID           string  `json:"tenantId,omitempty"`
+	OwnerID            string  `json:"ownerId,omitempty"`
+	AssetType          string  `json:"assetType"`
+	AssetName          string  `json:"assetName"`
+	AssetMoneyAmount   float64 `json:"assetMoneyAmount"`
+	AssetMoneyCurrency string  `json:"assetMoneyCurrency"`
 }
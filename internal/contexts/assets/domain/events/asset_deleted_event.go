@@ -3,5 +3,5 @@ package assetevents
 const AssetDeletedEventType = "asset.deleted"
 
 type AssetDeletedEvent struct {
-	AssetID string
+	AssetID string `json:"assetId"`
 }
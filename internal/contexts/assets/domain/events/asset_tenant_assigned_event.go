@@ -0,0 +1,8 @@
+package assetevents
+
+const AssetTenantAssignedEventType = "asset.tenant_assigned"
+
+type AssetTenantAssignedEvent struct {
+	AssetID  string `json:"assetId"`
+	TenantID string `json:"tenantId"`
+}
@@ -2,8 +2,10 @@ package assetdomain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
 )
 
 // Repository is the interface that wraps the basic asset repository methods.
@@ -19,4 +21,50 @@ type Repository interface {
 
 	// Exists checks if an asset with the given ID exists
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// History returns the changes of the asset with the given ID ordered by version
+	History(ctx context.Context, id uuid.UUID, query HistoryQuery) ([]aggregate.Change, error)
+
+	// GetByIDAsOf returns the asset by the given ID as it was at the given point in time
+	GetByIDAsOf(ctx context.Context, id uuid.UUID, at PointInTime) (*Asset, error)
+
+	// GetAllAsOf returns all the assets as they were at the given time
+	GetAllAsOf(ctx context.Context, at time.Time) ([]*Asset, error)
+
+	// SaveSnapshot saves the state of the asset at its version,
+	// so the asset is restored from it instead of replaying all its changes
+	SaveSnapshot(ctx context.Context, asset *Asset) error
+
+	// Unassigned returns the assets of any tenant created before households were introduced,
+	// which belong to no tenant until they are assigned one
+	Unassigned(ctx context.Context) ([]*Asset, error)
+}
+
+// PointInTime identifies a past state of an asset, either by time, by version or both.
+// Zero values mean the latest state.
+type PointInTime struct {
+	// Time is the instant at which the state is rebuilt, inclusive.
+	Time time.Time
+
+	// Version is the last version applied to the state, inclusive.
+	Version int
+}
+
+// HistoryQuery filters the changes returned by Repository.History.
+type HistoryQuery struct {
+	// FromVersion is the first version to return, inclusive.
+	// Zero means from the first change.
+	FromVersion int
+
+	// ToVersion is the last version to return, inclusive.
+	// Zero means up to the last change.
+	ToVersion int
+
+	// Until returns only the changes made at or before the given time.
+	// Zero means no time limit.
+	Until time.Time
+
+	// Limit is the maximum number of changes to return.
+	// Zero means no limit.
+	Limit int
 }
@@ -0,0 +1,14 @@
+package assetimmudbmigrations
+
+import (
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// NewAddEventsDateAndParentColumns creates the migration adding the date
+// and the parent aggregate of the payloads of the events.
+func NewAddEventsDateAndParentColumns() ximmudb.Migration {
+	return ximmudb.NewMigration("events/0006_add_events_date_and_parent_columns",
+		`ALTER TABLE events ADD COLUMN event_date TIMESTAMP;`,
+		`ALTER TABLE events ADD COLUMN parent_id VARCHAR[100];`,
+	)
+}
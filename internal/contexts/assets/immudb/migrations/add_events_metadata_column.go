@@ -0,0 +1,12 @@
+package assetimmudbmigrations
+
+import (
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// NewAddEventsMetadataColumn creates the migration adding the metadata of the events.
+func NewAddEventsMetadataColumn() ximmudb.Migration {
+	return ximmudb.NewMigration("events/0003_add_events_metadata_column",
+		`ALTER TABLE events ADD COLUMN metadata VARCHAR[1024];`,
+	)
+}
@@ -0,0 +1,12 @@
+package assetimmudbmigrations
+
+import (
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// NewAddEventsTenantIDColumn creates the migration adding the tenant of the events.
+func NewAddEventsTenantIDColumn() ximmudb.Migration {
+	return ximmudb.NewMigration("events/0005_add_events_tenant_id_column",
+		`ALTER TABLE events ADD COLUMN tenant_id VARCHAR[100];`,
+	)
+}
@@ -0,0 +1,14 @@
+package assetimmudbmigrations
+
+import (
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// NewAddEventsTypeAndVersionColumns creates the migration adding the type
+// and the aggregate version of the events.
+func NewAddEventsTypeAndVersionColumns() ximmudb.Migration {
+	return ximmudb.NewMigration("events/0004_add_events_type_and_version_columns",
+		`ALTER TABLE events ADD COLUMN type VARCHAR[100];`,
+		`ALTER TABLE events ADD COLUMN aggregate_version INTEGER;`,
+	)
+}
@@ -1,25 +1,12 @@
 package assetimmudbmigrations
 
 import (
-	"database/sql"
-
 	"github.com/xfrr/finantrack/internal/shared/ximmudb"
 )
 
-var _ ximmudb.Migration = (*CreateAssetEventsTable)(nil)
-
-// CreateAssetEventsTable represents a immudb SQL migration.
-type CreateAssetEventsTable struct {
-}
-
-// NewCreateAssetEventsTable creates a new migration.
+// NewCreateAssetEventsTable creates the migration creating the events table.
 func NewCreateAssetEventsTable() ximmudb.Migration {
-	return &CreateAssetEventsTable{}
-}
-
-// Up applies the migration.
-func (m *CreateAssetEventsTable) Up(db *sql.DB) error {
-	_, err := db.Exec(`
+	return ximmudb.NewMigration("events/0002_create_events_table", `
 		CREATE TABLE IF NOT EXISTS events (
 			id VARCHAR[100],
 			aggregate_id VARCHAR[100],
@@ -29,9 +16,4 @@ func (m *CreateAssetEventsTable) Up(db *sql.DB) error {
 			PRIMARY KEY id
 		);
 	`)
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
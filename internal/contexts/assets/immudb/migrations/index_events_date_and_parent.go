@@ -0,0 +1,12 @@
+package assetimmudbmigrations
+
+import (
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// NewIndexEventsDateAndParent creates the migration filling the date and the parent
+// aggregate of the events saved before their columns were added.
+func NewIndexEventsDateAndParent(registry xevent.Registry) ximmudb.Migration {
+	return ximmudb.NewEventIndexMigration("events/0007_index_events_date_and_parent", registry)
+}
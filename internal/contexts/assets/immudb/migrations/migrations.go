@@ -0,0 +1,23 @@
+// Package assetimmudbmigrations holds the immudb migrations of the events table,
+// shared by every aggregate of the service.
+package assetimmudbmigrations
+
+import (
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// Migrations returns the migrations of the events table, in the order they are applied.
+// The applied migrations must not be changed, the changes go in new ones appended to the list.
+// The registry decodes the payloads of the events stored, see ximmudb.NewEventIndexMigration.
+func Migrations(registry xevent.Registry) []ximmudb.Migration {
+	return []ximmudb.Migration{
+		NewCreateAssetsDatabase(),
+		NewCreateAssetEventsTable(),
+		NewAddEventsMetadataColumn(),
+		NewAddEventsTypeAndVersionColumns(),
+		NewAddEventsTenantIDColumn(),
+		NewAddEventsDateAndParentColumns(),
+		NewIndexEventsDateAndParent(registry),
+	}
+}
@@ -2,135 +2,284 @@ package assetimmudb
 
 import (
 	"context"
-	"database/sql"
-	"encoding/json"
 	"errors"
-	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+	"github.com/xfrr/finantrack/internal/shared/xsnapshot"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"go.opentelemetry.io/otel/trace"
 
 	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	assetevents "github.com/xfrr/finantrack/internal/contexts/assets/domain/events"
 )
 
-// Asset represents the asset entity.
-type eventDTO struct {
-	ID            string    `db:"id"`
-	AggregateID   string    `db:"aggregate_id"`
-	AggregateName string    `db:"aggregate_name"`
-	Timestamp     time.Time `db:"created_at"`
-	Payload       []byte    `db:"payload"`
-}
+var _ assetdomain.Repository = (*Repository)(nil)
+
+var tracer = xtracing.Tracer("github.com/xfrr/finantrack/internal/contexts/assets/immudb")
 
 // Repository implements the Repository interface using ImmuDB.
 type Repository struct {
-	db *sql.DB
+	eventStore ximmudb.EventStore
+	snapshots  xsnapshot.Store
 }
 
-// NewImmuRepository creates a new ImmuRepository with the given ImmuDB client.
-func NewImmuRepository(db *sql.DB) (*Repository, error) {
+// RepositoryOption configures the Repository.
+type RepositoryOption func(*Repository)
+
+// WithSnapshots restores the assets from their snapshots in the given store, if any,
+// instead of replaying all their changes.
+func WithSnapshots(store xsnapshot.Store) RepositoryOption {
+	return func(r *Repository) {
+		r.snapshots = store
+	}
+}
+
+// NewImmuRepository creates a new ImmuRepository with the given ImmuDB event store.
+func NewImmuRepository(eventStore ximmudb.EventStore, opts ...RepositoryOption) (*Repository, error) {
 	repo := &Repository{
-		db: db,
+		eventStore: eventStore,
+	}
+	for _, opt := range opts {
+		opt(repo)
 	}
 
 	return repo, nil
 }
 
-// Save saves the given asset to ImmuDB.
-func (r *Repository) Save(ctx context.Context, asset *assetdomain.Asset) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+// Save saves the asset changes into the event store.
+func (r *Repository) Save(ctx context.Context, asset *assetdomain.Asset) (err error) {
+	changes := asset.AggregateChanges()
 
-	events := asset.AggregateChanges()
-	if len(events) == 0 {
+	ctx, span := tracer.Start(ctx, "assetimmudb.Repository.Save", trace.WithAttributes(xtracing.ChangesAttributes(changes)...))
+	defer xtracing.End(span, &err)
+
+	if len(changes) == 0 {
 		return nil
 	}
 
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
+	// Assets can only be written on behalf of the tenant they belong to
+	if tenantID, ok := xtenant.FromContext(ctx); ok && tenantID != asset.TenantID() {
+		return assetdomain.ErrAssetTenantMismatch
+	}
+	if asset.TenantID() != "" {
+		ctx = xtenant.WithTenant(ctx, asset.TenantID())
 	}
 
-	var eventDTOs []eventDTO
-	for _, event := range events {
-		var (
-			payload []byte
-			err     error
-		)
-
-		if event.Payload() != nil {
-			payload, err = json.Marshal(event.Payload())
-			if err != nil {
+	// The changes of an asset created before households were introduced are moved to the tenant
+	// it is assigned to before the assignment is saved, so a failed save can be retried
+	for _, change := range changes {
+		if change.Reason() == assetevents.AssetTenantAssignedEventType {
+			if err = r.eventStore.AssignTenant(ctx, asset.ID(), asset.TenantID()); err != nil {
 				return err
 			}
 		}
+	}
 
-		eventID, ok := event.ID().(uuid.UUID)
-		if !ok {
-			return fmt.Errorf("event ID is not a UUID")
-		}
+	return r.eventStore.Save(ctx, changes...)
+}
 
-		aggID, ok := event.ID().(uuid.UUID)
-		if !ok {
-			return fmt.Errorf("agggregate ID is not a UUID")
-		}
+// GetByID retrieves an asset by its ID from ImmuDB.
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (_ *assetdomain.Asset, err error) {
+	ctx, span := tracer.Start(ctx, "assetimmudb.Repository.GetByID", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(id.String()),
+		xtracing.AggregateTypeKey.String(assetdomain.AggregateType),
+	))
+	defer xtracing.End(span, &err)
+
+	snapshot, version, err := r.snapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-		eventDTOs = append(eventDTOs, eventDTO{
-			ID:            eventID.String(),
-			AggregateID:   aggID.String(),
-			AggregateName: event.Aggregate().Name,
-			Timestamp:     event.Time(),
-			Payload:       payload,
-		})
-
-		// Insert the event into the database
-		dbInsertSqlQuery := fmt.Sprintf(`
-			INSERT INTO %s (id, aggregate_id, aggregate_name, created_at, payload)
-			VALUES (?, ?, ?, ?, ?);`,
-			"events",
-		)
-
-		_, err = tx.ExecContext(ctx, dbInsertSqlQuery, eventDTOs[len(eventDTOs)-1].ID, eventDTOs[len(eventDTOs)-1].AggregateID, eventDTOs[len(eventDTOs)-1].AggregateName, eventDTOs[len(eventDTOs)-1].Timestamp, string(eventDTOs[len(eventDTOs)-1].Payload))
-		if err != nil {
-			return err
+	// only the changes after the snapshot are replayed, if any
+	criteria := ximmudb.WithAggregateIDCriteria(id.String())()
+	if version > 0 {
+		criteria = ximmudb.And(criteria, ximmudb.WithAggregateVersionRangeCriteria(version+1, 0)())()
+	}
+
+	events, err := r.eventStore.Get(ctx,
+		scope(ctx, criteria),
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Ascending),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var asset *assetdomain.Asset
+	if version > 0 {
+		asset, err = assetdomain.RestoreAsset(id, version, snapshot, events)
+	} else {
+		if len(events) == 0 {
+			return nil, assetdomain.ErrAssetNotFound
 		}
+		asset, err = assetdomain.HydrateAsset(id, events)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if asset.IsDeleted() {
+		return nil, assetdomain.ErrAssetNotFound
+	}
+
+	return asset, nil
+}
+
+// SaveSnapshot saves the snapshot of the asset at its version, replacing its previous snapshot.
+func (r *Repository) SaveSnapshot(ctx context.Context, asset *assetdomain.Asset) (err error) {
+	ctx, span := tracer.Start(ctx, "assetimmudb.Repository.SaveSnapshot", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(asset.ID().String()),
+		xtracing.AggregateTypeKey.String(assetdomain.AggregateType),
+	))
+	defer xtracing.End(span, &err)
+
+	if r.snapshots == nil {
+		return assetdomain.ErrAssetSnapshotsDisabled
+	}
+
+	if len(asset.AggregateChanges()) > 0 {
+		return assetdomain.ErrAssetHasUncommittedChanges
 	}
 
-	err = tx.Commit()
+	snapshot, err := xsnapshot.New(
+		asset.ID().String(),
+		assetdomain.AggregateType,
+		int(asset.AggregateVersion()),
+		asset.TenantID(),
+		asset.Snapshot(),
+	)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return r.snapshots.Save(ctx, snapshot)
 }
 
-// GetByID retrieves an asset by its ID from ImmuDB.
-func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*assetdomain.Asset, error) {
-	// TODO: Implement GetByID method
-	return nil, errors.New("not implemented")
+// snapshot returns the snapshot of the asset and its version, or a zero version when the asset
+// has no snapshot or it belongs to another tenant than the one in the context, e.g. the asset
+// was assigned a household after its snapshot, so the asset is rebuilt from all its changes.
+func (r *Repository) snapshot(ctx context.Context, id uuid.UUID) (assetdomain.Snapshot, int, error) {
+	if r.snapshots == nil {
+		return assetdomain.Snapshot{}, 0, nil
+	}
+
+	record, err := r.snapshots.Get(ctx, id.String())
+	if errors.Is(err, xsnapshot.ErrSnapshotNotFound) {
+		return assetdomain.Snapshot{}, 0, nil
+	}
+	if err != nil {
+		return assetdomain.Snapshot{}, 0, err
+	}
+
+	if tenantID, ok := xtenant.FromContext(ctx); ok && tenantID != record.TenantID {
+		return assetdomain.Snapshot{}, 0, nil
+	}
+
+	var snapshot assetdomain.Snapshot
+	if err = record.Decode(&snapshot); err != nil {
+		return assetdomain.Snapshot{}, 0, err
+	}
+
+	return snapshot, record.Version, nil
 }
 
 // GetAll retrieves all assets from ImmuDB.
 func (r *Repository) GetAll(ctx context.Context) ([]*assetdomain.Asset, error) {
-	// TODO: Implement GetAll method
-	return nil, errors.New("not implemented")
+	return r.GetAllAsOf(ctx, time.Time{})
 }
 
 // Exists checks whether an asset exists in ImmuDB by its ID.
+// It is not scoped to the tenant, since asset IDs are unique across tenants.
 func (r *Repository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
-	stmt := `SELECT id FROM events WHERE id = ?`
-	args := []any{id.String()}
+	return r.eventStore.ExistsByAggregateID(ctx, id)
+}
 
-	rows, err := r.db.QueryContext(ctx, stmt, args...)
+// History retrieves the changes of the asset with the given ID from the event store.
+func (r *Repository) History(ctx context.Context, id uuid.UUID, query assetdomain.HistoryQuery) ([]aggregate.Change, error) {
+	opts := []ximmudb.QueryOption{
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Ascending),
+	}
+	if query.Limit > 0 {
+		opts = append(opts, ximmudb.WithLimit(int64(query.Limit)))
+	}
+
+	return r.eventStore.Get(ctx,
+		scope(ctx, ximmudb.And(
+			ximmudb.WithAggregateIDCriteria(id.String())(),
+			ximmudb.WithAggregateVersionRangeCriteria(query.FromVersion, query.ToVersion)(),
+			ximmudb.WithTimestampRangeCriteria(time.Time{}, query.Until)(),
+		)()),
+		opts...,
+	)
+}
+
+// GetByIDAsOf retrieves an asset by its ID as it was at the given point in time.
+func (r *Repository) GetByIDAsOf(ctx context.Context, id uuid.UUID, at assetdomain.PointInTime) (*assetdomain.Asset, error) {
+	changes, err := r.History(ctx, id, assetdomain.HistoryQuery{
+		ToVersion: at.Version,
+		Until:     at.Time,
+	})
 	if err != nil {
-		switch {
-		case sql.ErrNoRows == err:
-			return false, nil
-		default:
-			return false, err
-		}
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, assetdomain.ErrAssetNotFound
+	}
+
+	asset, err := assetdomain.HydrateAsset(id, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if asset.IsDeleted() {
+		return nil, assetdomain.ErrAssetNotFound
+	}
+
+	return asset, nil
+}
+
+// GetAllAsOf retrieves all assets as they were at the given time.
+// A zero time retrieves the current state of the assets.
+func (r *Repository) GetAllAsOf(ctx context.Context, at time.Time) ([]*assetdomain.Asset, error) {
+	return assetdomain.HydrateAssets(r.eventStore.Stream(ctx,
+		scope(ctx, ximmudb.And(
+			ximmudb.WithAggregateTypeCriteria(assetdomain.AggregateType)(),
+			ximmudb.WithTimestampRangeCriteria(time.Time{}, at)(),
+		)()),
+		ximmudb.WithSort(ximmudb.SortByAggregateID, ximmudb.Ascending),
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Ascending),
+	))
+}
+
+// Unassigned retrieves the assets created before households were introduced, in any tenant,
+// which belong to no tenant until they are assigned one.
+func (r *Repository) Unassigned(ctx context.Context) ([]*assetdomain.Asset, error) {
+	all, err := assetdomain.HydrateAssets(r.eventStore.Stream(ctx,
+		ximmudb.WithAggregateTypeCriteria(assetdomain.AggregateType)(),
+		ximmudb.WithSort(ximmudb.SortByAggregateID, ximmudb.Ascending),
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Ascending),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(all, func(a *assetdomain.Asset) bool {
+		return a.TenantID() != ""
+	}), nil
+}
+
+// scope restricts the criteria to the tenant of the context, if any.
+func scope(ctx context.Context, criteria ximmudb.Criteria) ximmudb.Criteria {
+	tenantID, ok := xtenant.FromContext(ctx)
+	if !ok {
+		return criteria
 	}
 
-	defer rows.Close()
-	return rows.Next(), nil
+	return ximmudb.And(criteria, ximmudb.WithTenantIDCriteria(tenantID)())()
 }
@@ -0,0 +1,178 @@
+package assetimmudb_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+	"github.com/xfrr/finantrack/internal/shared/xsnapshot"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	. "github.com/xfrr/finantrack/internal/contexts/assets/immudb"
+)
+
+// criteriaEventStore is a ximmudb.EventStore recording the SQL condition of the last query.
+type criteriaEventStore struct {
+	ximmudb.EventStore
+
+	cond string
+	args []any
+}
+
+func (s *criteriaEventStore) Get(_ context.Context, criteria ximmudb.Criteria, _ ...ximmudb.QueryOption) ([]ximmudb.Event, error) {
+	s.cond, s.args = criteria.ToSQL()
+	return nil, nil
+}
+
+func (s *criteriaEventStore) Stream(_ context.Context, criteria ximmudb.Criteria, _ ...ximmudb.QueryOption) iter.Seq2[ximmudb.Event, error] {
+	s.cond, s.args = criteria.ToSQL()
+	return func(func(ximmudb.Event, error) bool) {}
+}
+
+// memorySnapshotStore is a xsnapshot.Store keeping the snapshots in memory.
+type memorySnapshotStore map[string]xsnapshot.Snapshot
+
+func (s memorySnapshotStore) Save(_ context.Context, snapshot xsnapshot.Snapshot) error {
+	s[snapshot.AggregateID] = snapshot
+	return nil
+}
+
+func (s memorySnapshotStore) Get(_ context.Context, aggregateID string) (xsnapshot.Snapshot, error) {
+	snapshot, ok := s[aggregateID]
+	if !ok {
+		return xsnapshot.Snapshot{}, xsnapshot.ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+func TestRepository_AsOf(t *testing.T) {
+	var (
+		id = uuid.New()
+		at = time.Date(2024, 12, 10, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	)
+
+	t.Run("get by id as of a version and an instant, both inclusive", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut, err := NewImmuRepository(store)
+		require.NoError(t, err)
+
+		_, err = sut.GetByIDAsOf(context.Background(), id, assetdomain.PointInTime{Time: at, Version: 3})
+		require.ErrorIs(t, err, assetdomain.ErrAssetNotFound)
+
+		assert.Equal(t, "(aggregate_id = ?) AND (aggregate_version <= ?) AND (created_at <= ?)", store.cond)
+		assert.Equal(t, []any{id.String(), 3, at.UTC()}, store.args)
+	})
+
+	t.Run("get by id as of a version only", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut, err := NewImmuRepository(store)
+		require.NoError(t, err)
+
+		_, err = sut.GetByIDAsOf(context.Background(), id, assetdomain.PointInTime{Version: 1})
+		require.ErrorIs(t, err, assetdomain.ErrAssetNotFound)
+
+		assert.Equal(t, "(aggregate_id = ?) AND (aggregate_version <= ?)", store.cond)
+		assert.Equal(t, []any{id.String(), 1}, store.args)
+	})
+
+	t.Run("get all as of an instant of the tenant", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut, err := NewImmuRepository(store)
+		require.NoError(t, err)
+
+		all, err := sut.GetAllAsOf(xtenant.WithTenant(context.Background(), "household"), at)
+		require.NoError(t, err)
+		assert.Empty(t, all)
+
+		assert.Equal(t, "((aggregate_name = ?) AND (created_at <= ?)) AND (tenant_id = ?)", store.cond)
+		assert.Equal(t, []any{assetdomain.AggregateType, at.UTC(), "household"}, store.args)
+	})
+
+	t.Run("get all current assets", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut, err := NewImmuRepository(store)
+		require.NoError(t, err)
+
+		_, err = sut.GetAll(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "(aggregate_name = ?)", store.cond)
+		assert.Equal(t, []any{assetdomain.AggregateType}, store.args)
+	})
+}
+
+func TestRepository_Snapshots(t *testing.T) {
+	ctx := xtenant.WithTenant(context.Background(), "household")
+
+	newAsset := func(t *testing.T) *assetdomain.Asset {
+		t.Helper()
+		asset, err := assetdomain.NewAsset(uuid.New(), "household", "", "Savings", assetdomain.AssetTypeBank, assetdomain.Money{Amount: 100, Currency: assetdomain.EUR})
+		require.NoError(t, err)
+		require.NoError(t, asset.UpdateBalance(assetdomain.Money{Amount: 250, Currency: assetdomain.EUR}, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)))
+
+		// the asset as read from the event store, with its changes committed
+		saved, err := assetdomain.HydrateAsset(asset.ID(), asset.AggregateChanges())
+		require.NoError(t, err)
+		return saved
+	}
+
+	t.Run("get by id replays only the changes after the snapshot", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		snapshots := memorySnapshotStore{}
+		sut, err := NewImmuRepository(store, WithSnapshots(snapshots))
+		require.NoError(t, err)
+
+		asset := newAsset(t)
+		require.NoError(t, sut.SaveSnapshot(ctx, asset))
+		assert.Equal(t, 2, snapshots[asset.ID().String()].Version)
+
+		got, err := sut.GetByID(ctx, asset.ID())
+		require.NoError(t, err)
+
+		assert.Equal(t, "((aggregate_id = ?) AND (aggregate_version >= ?)) AND (tenant_id = ?)", store.cond)
+		assert.Equal(t, []any{asset.ID().String(), 3, "household"}, store.args)
+		assert.Equal(t, 2, int(got.AggregateVersion()))
+		assert.Equal(t, asset.Money(), got.Money())
+		assert.Equal(t, asset.BalanceAsOf(), got.BalanceAsOf())
+	})
+
+	t.Run("get by id of a snapshot of another tenant replays all the changes", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		snapshots := memorySnapshotStore{}
+		sut, err := NewImmuRepository(store, WithSnapshots(snapshots))
+		require.NoError(t, err)
+
+		asset := newAsset(t)
+		require.NoError(t, sut.SaveSnapshot(ctx, asset))
+
+		_, err = sut.GetByID(xtenant.WithTenant(context.Background(), "other"), asset.ID())
+		require.ErrorIs(t, err, assetdomain.ErrAssetNotFound)
+
+		assert.Equal(t, "(aggregate_id = ?) AND (tenant_id = ?)", store.cond)
+		assert.Equal(t, []any{asset.ID().String(), "other"}, store.args)
+	})
+
+	t.Run("save the snapshot of an asset with uncommitted changes should return error", func(t *testing.T) {
+		sut, err := NewImmuRepository(&criteriaEventStore{}, WithSnapshots(memorySnapshotStore{}))
+		require.NoError(t, err)
+
+		asset := newAsset(t)
+		asset.MarkAsDeleted()
+
+		require.ErrorIs(t, sut.SaveSnapshot(ctx, asset), assetdomain.ErrAssetHasUncommittedChanges)
+	})
+
+	t.Run("save a snapshot without snapshot store should return error", func(t *testing.T) {
+		sut, err := NewImmuRepository(&criteriaEventStore{})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, sut.SaveSnapshot(ctx, newAsset(t)), assetdomain.ErrAssetSnapshotsDisabled)
+	})
+}
@@ -2,51 +2,128 @@ package assetsmongo
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xsnapshot"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"go.opentelemetry.io/otel/trace"
 
 	assetDomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	assetevents "github.com/xfrr/finantrack/internal/contexts/assets/domain/events"
 )
 
 var _ assetDomain.Repository = (*Repository)(nil)
 
+var tracer = xtracing.Tracer("github.com/xfrr/finantrack/internal/contexts/assets/mongodb")
+
 // Repository represents the MongoDB repository for assets.
 type Repository struct {
 	eventStore xmongo.EventStore
+	snapshots  xsnapshot.Store
+}
+
+// RepositoryOption configures the Repository.
+type RepositoryOption func(*Repository)
+
+// WithSnapshots restores the assets from their snapshots in the given store, if any,
+// instead of replaying all their changes.
+func WithSnapshots(store xsnapshot.Store) RepositoryOption {
+	return func(r *Repository) {
+		r.snapshots = store
+	}
 }
 
 // NewRepository creates a new MongoDB repository for assets.
-func NewRepository(eventStore xmongo.EventStore) *Repository {
-	return &Repository{
+func NewRepository(eventStore xmongo.EventStore, opts ...RepositoryOption) *Repository {
+	repo := &Repository{
 		eventStore: eventStore,
 	}
+	for _, opt := range opts {
+		opt(repo)
+	}
+
+	return repo
 }
 
 // Save saves the asset changes into the event store.
-func (r *Repository) Save(ctx context.Context, asset *assetDomain.Asset) error {
+func (r *Repository) Save(ctx context.Context, asset *assetDomain.Asset) (err error) {
 	changes := asset.AggregateChanges()
+
+	ctx, span := tracer.Start(ctx, "assetsmongo.Repository.Save", trace.WithAttributes(xtracing.ChangesAttributes(changes)...))
+	defer xtracing.End(span, &err)
+
 	if len(changes) == 0 {
 		return nil
 	}
 
+	// Assets can only be written on behalf of the tenant they belong to
+	if tenantID, ok := xtenant.FromContext(ctx); ok && tenantID != asset.TenantID() {
+		return assetDomain.ErrAssetTenantMismatch
+	}
+	if asset.TenantID() != "" {
+		ctx = xtenant.WithTenant(ctx, asset.TenantID())
+	}
+
+	// The changes of an asset created before households were introduced are moved to the tenant
+	// it is assigned to before the assignment is saved, so a failed save can be retried
+	for _, change := range changes {
+		if change.Reason() == assetevents.AssetTenantAssignedEventType {
+			if err = r.eventStore.AssignTenant(ctx, asset.ID(), asset.TenantID()); err != nil {
+				return err
+			}
+		}
+	}
+
 	return r.eventStore.Save(ctx, changes...)
 }
 
 // GetAll retrieves all assets from the event store.
 func (r *Repository) GetAll(ctx context.Context) ([]*assetDomain.Asset, error) {
-	// TODO: Implement
-	return nil, nil
+	return r.GetAllAsOf(ctx, time.Time{})
 }
 
 // GetByID retrieves an asset by its ID from the event store.
-func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*assetDomain.Asset, error) {
-	events, err := r.eventStore.Get(ctx, xmongo.WithAggregateIDCriteria(id.String())())
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (_ *assetDomain.Asset, err error) {
+	ctx, span := tracer.Start(ctx, "assetsmongo.Repository.GetByID", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(id.String()),
+		xtracing.AggregateTypeKey.String(assetDomain.AggregateType),
+	))
+	defer xtracing.End(span, &err)
+
+	snapshot, version, err := r.snapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the changes after the snapshot are replayed, if any
+	criteria := xmongo.WithAggregateIDCriteria(id.String())()
+	if version > 0 {
+		criteria = xmongo.And(criteria, xmongo.WithAggregateVersionRangeCriteria(version+1, 0)())()
+	}
+
+	events, err := r.eventStore.Get(ctx,
+		scope(ctx, criteria),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	asset, err := assetDomain.HydrateAsset(id, events)
+	var asset *assetDomain.Asset
+	if version > 0 {
+		asset, err = assetDomain.RestoreAsset(id, version, snapshot, events)
+	} else {
+		if len(events) == 0 {
+			return nil, assetDomain.ErrAssetNotFound
+		}
+		asset, err = assetDomain.HydrateAsset(id, events)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +135,151 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*assetDomain.As
 	return asset, nil
 }
 
+// SaveSnapshot saves the snapshot of the asset at its version, replacing its previous snapshot.
+func (r *Repository) SaveSnapshot(ctx context.Context, asset *assetDomain.Asset) (err error) {
+	ctx, span := tracer.Start(ctx, "assetsmongo.Repository.SaveSnapshot", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(asset.ID().String()),
+		xtracing.AggregateTypeKey.String(assetDomain.AggregateType),
+	))
+	defer xtracing.End(span, &err)
+
+	if r.snapshots == nil {
+		return assetDomain.ErrAssetSnapshotsDisabled
+	}
+
+	if len(asset.AggregateChanges()) > 0 {
+		return assetDomain.ErrAssetHasUncommittedChanges
+	}
+
+	snapshot, err := xsnapshot.New(
+		asset.ID().String(),
+		assetDomain.AggregateType,
+		int(asset.AggregateVersion()),
+		asset.TenantID(),
+		asset.Snapshot(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.snapshots.Save(ctx, snapshot)
+}
+
+// snapshot returns the snapshot of the asset and its version, or a zero version when the asset
+// has no snapshot or it belongs to another tenant than the one in the context, e.g. the asset
+// was assigned a household after its snapshot, so the asset is rebuilt from all its changes.
+func (r *Repository) snapshot(ctx context.Context, id uuid.UUID) (assetDomain.Snapshot, int, error) {
+	if r.snapshots == nil {
+		return assetDomain.Snapshot{}, 0, nil
+	}
+
+	record, err := r.snapshots.Get(ctx, id.String())
+	if errors.Is(err, xsnapshot.ErrSnapshotNotFound) {
+		return assetDomain.Snapshot{}, 0, nil
+	}
+	if err != nil {
+		return assetDomain.Snapshot{}, 0, err
+	}
+
+	if tenantID, ok := xtenant.FromContext(ctx); ok && tenantID != record.TenantID {
+		return assetDomain.Snapshot{}, 0, nil
+	}
+
+	var snapshot assetDomain.Snapshot
+	if err = record.Decode(&snapshot); err != nil {
+		return assetDomain.Snapshot{}, 0, err
+	}
+
+	return snapshot, record.Version, nil
+}
+
 // Exists checks if an asset with the given ID exists in the event store.
+// It is not scoped to the tenant, since asset IDs are unique across tenants.
 func (r *Repository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	return r.eventStore.ExistsByAggregateID(ctx, id)
 }
+
+// History retrieves the changes of the asset with the given ID from the event store.
+func (r *Repository) History(ctx context.Context, id uuid.UUID, query assetDomain.HistoryQuery) ([]aggregate.Change, error) {
+	opts := []xmongo.QueryOption{
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	}
+	if query.Limit > 0 {
+		opts = append(opts, xmongo.WithLimit(int64(query.Limit)))
+	}
+
+	return r.eventStore.Get(ctx,
+		scope(ctx, xmongo.And(
+			xmongo.WithAggregateIDCriteria(id.String())(),
+			xmongo.WithAggregateVersionRangeCriteria(query.FromVersion, query.ToVersion)(),
+			xmongo.WithTimestampRangeCriteria(time.Time{}, query.Until)(),
+		)()),
+		opts...,
+	)
+}
+
+// GetByIDAsOf retrieves an asset by its ID as it was at the given point in time.
+func (r *Repository) GetByIDAsOf(ctx context.Context, id uuid.UUID, at assetDomain.PointInTime) (*assetDomain.Asset, error) {
+	changes, err := r.History(ctx, id, assetDomain.HistoryQuery{
+		ToVersion: at.Version,
+		Until:     at.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, assetDomain.ErrAssetNotFound
+	}
+
+	asset, err := assetDomain.HydrateAsset(id, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if asset.IsDeleted() {
+		return nil, assetDomain.ErrAssetNotFound
+	}
+
+	return asset, nil
+}
+
+// GetAllAsOf retrieves all assets as they were at the given time.
+// A zero time retrieves the current state of the assets.
+func (r *Repository) GetAllAsOf(ctx context.Context, at time.Time) ([]*assetDomain.Asset, error) {
+	return assetDomain.HydrateAssets(r.eventStore.Stream(ctx,
+		scope(ctx, xmongo.And(
+			xmongo.WithAggregateTypeCriteria(assetDomain.AggregateType)(),
+			xmongo.WithTimestampRangeCriteria(time.Time{}, at)(),
+		)()),
+		xmongo.WithSort(xmongo.SortByAggregateID, xmongo.Ascending),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	))
+}
+
+// Unassigned retrieves the assets created before households were introduced, in any tenant,
+// which belong to no tenant until they are assigned one.
+func (r *Repository) Unassigned(ctx context.Context) ([]*assetDomain.Asset, error) {
+	all, err := assetDomain.HydrateAssets(r.eventStore.Stream(ctx,
+		xmongo.WithAggregateTypeCriteria(assetDomain.AggregateType)(),
+		xmongo.WithSort(xmongo.SortByAggregateID, xmongo.Ascending),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(all, func(a *assetDomain.Asset) bool {
+		return a.TenantID() != ""
+	}), nil
+}
+
+// scope restricts the criteria to the tenant of the context, if any.
+func scope(ctx context.Context, criteria xmongo.Criteria) xmongo.Criteria {
+	tenantID, ok := xtenant.FromContext(ctx)
+	if !ok {
+		return criteria
+	}
+
+	return xmongo.And(criteria, xmongo.WithTenantIDCriteria(tenantID)())()
+}
@@ -0,0 +1,121 @@
+package assetsmongo_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	assetDomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xsnapshot"
+
+	. "github.com/xfrr/finantrack/internal/contexts/assets/mongodb"
+)
+
+// criteriaEventStore is a xmongo.EventStore recording the filter of the last query.
+type criteriaEventStore struct {
+	xmongo.EventStore
+
+	filter bson.D
+}
+
+func (s *criteriaEventStore) Get(_ context.Context, criteria xmongo.Criteria, _ ...xmongo.QueryOption) ([]xmongo.Event, error) {
+	s.filter = criteria.ToBSON()
+	return nil, nil
+}
+
+func (s *criteriaEventStore) Stream(_ context.Context, criteria xmongo.Criteria, _ ...xmongo.QueryOption) iter.Seq2[xmongo.Event, error] {
+	s.filter = criteria.ToBSON()
+	return func(func(xmongo.Event, error) bool) {}
+}
+
+// memorySnapshotStore is a xsnapshot.Store keeping the snapshots in memory.
+type memorySnapshotStore map[string]xsnapshot.Snapshot
+
+func (s memorySnapshotStore) Save(_ context.Context, snapshot xsnapshot.Snapshot) error {
+	s[snapshot.AggregateID] = snapshot
+	return nil
+}
+
+func (s memorySnapshotStore) Get(_ context.Context, aggregateID string) (xsnapshot.Snapshot, error) {
+	snapshot, ok := s[aggregateID]
+	if !ok {
+		return xsnapshot.Snapshot{}, xsnapshot.ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+func TestRepository_AsOf(t *testing.T) {
+	var (
+		id = uuid.New()
+		at = time.Date(2024, 12, 10, 12, 30, 0, 0, time.UTC)
+	)
+
+	t.Run("get by id as of a version and an instant, both inclusive", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut := NewRepository(store)
+
+		_, err := sut.GetByIDAsOf(context.Background(), id, assetDomain.PointInTime{Time: at, Version: 3})
+		require.ErrorIs(t, err, assetDomain.ErrAssetNotFound)
+
+		assert.Equal(t, bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "aggregate_id", Value: id.String()}},
+			bson.D{{Key: "aggregate_version", Value: bson.D{{Key: "$lte", Value: 3}}}},
+			bson.D{{Key: "timestamp", Value: bson.D{{Key: "$lte", Value: at}}}},
+		}}}, store.filter)
+	})
+
+	t.Run("get all as of an instant", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut := NewRepository(store)
+
+		all, err := sut.GetAllAsOf(context.Background(), at)
+		require.NoError(t, err)
+		assert.Empty(t, all)
+
+		assert.Equal(t, bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "aggregate_type", Value: assetDomain.AggregateType}},
+			bson.D{{Key: "timestamp", Value: bson.D{{Key: "$lte", Value: at}}}},
+		}}}, store.filter)
+	})
+
+	t.Run("get all current assets leaves the instant open", func(t *testing.T) {
+		store := &criteriaEventStore{}
+		sut := NewRepository(store)
+
+		_, err := sut.GetAll(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "aggregate_type", Value: assetDomain.AggregateType}},
+			bson.D{},
+		}}}, store.filter)
+	})
+}
+
+func TestRepository_GetByIDFromSnapshot(t *testing.T) {
+	created, err := assetDomain.NewAsset(uuid.New(), "", "", "Savings", assetDomain.AssetTypeBank, assetDomain.Money{Amount: 100, Currency: assetDomain.EUR})
+	require.NoError(t, err)
+	asset, err := assetDomain.HydrateAsset(created.ID(), created.AggregateChanges())
+	require.NoError(t, err)
+
+	store := &criteriaEventStore{}
+	sut := NewRepository(store, WithSnapshots(memorySnapshotStore{}))
+	require.NoError(t, sut.SaveSnapshot(context.Background(), asset))
+
+	got, err := sut.GetByID(context.Background(), asset.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "aggregate_id", Value: asset.ID().String()}},
+		bson.D{{Key: "aggregate_version", Value: bson.D{{Key: "$gte", Value: 2}}}},
+	}}}, store.filter)
+	assert.Equal(t, 1, int(got.AggregateVersion()))
+	assert.Equal(t, "Savings", got.Name())
+}
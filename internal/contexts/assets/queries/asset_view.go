@@ -0,0 +1,35 @@
+package assetsqueries
+
+import (
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+)
+
+// AssetView represents the state of an asset returned by the queries.
+type AssetView struct {
+	ID            string
+	TenantID      string
+	OwnerID       string
+	Name          string
+	Type          string
+	MoneyAmount   float64
+	MoneyCurrency string
+	IBAN          string
+	Deleted       bool
+	Version       int
+}
+
+// NewAssetView creates a new AssetView from the given asset.
+func NewAssetView(asset *assets.Asset) AssetView {
+	return AssetView{
+		ID:            asset.ID().String(),
+		TenantID:      asset.TenantID(),
+		OwnerID:       asset.OwnerID(),
+		Name:          asset.Name(),
+		Type:          asset.Type().String(),
+		MoneyAmount:   asset.Money().Amount,
+		MoneyCurrency: asset.Money().Currency.String(),
+		IBAN:          asset.IBAN(),
+		Deleted:       asset.IsDeleted(),
+		Version:       int(asset.AggregateVersion()),
+	}
+}
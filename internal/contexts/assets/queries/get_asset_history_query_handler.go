@@ -0,0 +1,152 @@
+package assetsqueries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+)
+
+// DefaultHistoryLimit is the number of changes returned when no limit is given.
+const DefaultHistoryLimit = 100
+
+type GetAssetHistoryQuery struct {
+	AssetID     string
+	FromVersion int
+	Limit       int
+
+	// AsOf restricts the history to the changes made at or before the given time
+	// and includes the asset state rehydrated up to it.
+	AsOf time.Time
+}
+
+func (q GetAssetHistoryQuery) QueryName() string {
+	return "GetAssetHistoryQuery"
+}
+
+// AssetHistory represents a page of the changes of an asset.
+type AssetHistory struct {
+	AssetID string
+	Changes []AssetChange
+
+	// NextFromVersion is the version to request the next page from.
+	// It is zero when there are no more changes.
+	NextFromVersion int
+
+	// State is the asset state as of the requested time, if any.
+	State *AssetView
+}
+
+// AssetChange represents a single change of an asset.
+type AssetChange struct {
+	ID        string
+	Type      string
+	Version   int
+	Timestamp time.Time
+	Payload   any
+	Metadata  *xevent.Metadata
+}
+
+type GetAssetHistoryQueryHandler struct {
+	assets assets.Repository
+}
+
+func NewGetAssetHistoryQueryHandler(assets assets.Repository) *GetAssetHistoryQueryHandler {
+	return &GetAssetHistoryQueryHandler{
+		assets: assets,
+	}
+}
+
+func (h *GetAssetHistoryQueryHandler) Handle(ctx context.Context, query GetAssetHistoryQuery) (interface{}, error) {
+	assetID, err := uuid.Parse(query.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+
+	// Get one extra change to know if there is a next page
+	changes, err := h.assets.History(ctx, assetID, assets.HistoryQuery{
+		FromVersion: query.FromVersion,
+		Until:       query.AsOf,
+		Limit:       limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 && query.FromVersion <= 1 {
+		return nil, assets.ErrAssetNotFound
+	}
+
+	history := &AssetHistory{
+		AssetID: assetID.String(),
+		Changes: make([]AssetChange, 0, len(changes)),
+	}
+
+	if len(changes) > limit {
+		history.NextFromVersion = changes[limit].Aggregate().Version
+		changes = changes[:limit]
+	}
+
+	for _, change := range changes {
+		history.Changes = append(history.Changes, newAssetChange(change))
+	}
+
+	if query.AsOf.IsZero() {
+		return history, nil
+	}
+
+	// Rehydrate the asset with all its changes up to the given time
+	asset, err := h.rehydrate(ctx, assetID, query.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	state := NewAssetView(asset)
+	history.State = &state
+
+	return history, nil
+}
+
+func (h *GetAssetHistoryQueryHandler) rehydrate(ctx context.Context, assetID uuid.UUID, asOf time.Time) (*assets.Asset, error) {
+	changes, err := h.assets.History(ctx, assetID, assets.HistoryQuery{
+		Until: asOf,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, assets.ErrAssetNotFound
+	}
+
+	return assets.HydrateAsset(assetID, changes)
+}
+
+func newAssetChange(change aggregate.Change) AssetChange {
+	c := AssetChange{
+		ID:        fmt.Sprint(change.ID()),
+		Type:      change.Reason(),
+		Timestamp: change.Time(),
+		Payload:   change.Payload(),
+	}
+
+	if change.Aggregate() != nil {
+		c.Version = change.Aggregate().Version
+	}
+
+	if md, ok := xevent.MetadataOf(change); ok {
+		c.Metadata = &md
+	}
+
+	return c
+}
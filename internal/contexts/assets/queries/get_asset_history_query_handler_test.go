@@ -0,0 +1,107 @@
+package assetsqueries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"github.com/xfrr/go-cqrsify/event"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	assetevents "github.com/xfrr/finantrack/internal/contexts/assets/domain/events"
+	. "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+)
+
+// historyRepository is an assets.Repository that only serves the history of a fixed set of changes.
+type historyRepository struct {
+	assets.Repository
+
+	changes []aggregate.Change
+}
+
+func (r *historyRepository) History(_ context.Context, id uuid.UUID, query assets.HistoryQuery) ([]aggregate.Change, error) {
+	var changes []aggregate.Change
+	for _, c := range r.changes {
+		if c.Aggregate().ID != id ||
+			c.Aggregate().Version < query.FromVersion ||
+			(!query.Until.IsZero() && c.Time().After(query.Until)) {
+			continue
+		}
+
+		if query.Limit > 0 && len(changes) == query.Limit {
+			break
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func TestGetAssetHistoryQueryHandler_Handle(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		assetID = uuid.New()
+		day     = func(d int) time.Time { return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC) }
+	)
+
+	sut := NewGetAssetHistoryQueryHandler(&historyRepository{
+		changes: []aggregate.Change{
+			event.New[any, any](uuid.New(), assetevents.AssetCreatedEventType, &assetevents.AssetCreatedEvent{
+				AssetID:            assetID.String(),
+				AssetName:          "Savings",
+				AssetType:          "bank",
+				AssetMoneyAmount:   1000,
+				AssetMoneyCurrency: "EUR",
+			}, event.WithAggregate(assetID, assets.AggregateType, 1), event.WithTime(day(1))),
+			event.New[any, any](uuid.New(), assetevents.AssetDeletedEventType, &assetevents.AssetDeletedEvent{
+				AssetID: assetID.String(),
+			}, event.WithAggregate(assetID, assets.AggregateType, 2), event.WithTime(day(20))),
+		},
+	})
+
+	t.Run("get the history page by page", func(t *testing.T) {
+		res, err := sut.Handle(ctx, GetAssetHistoryQuery{AssetID: assetID.String(), Limit: 1})
+		require.NoError(t, err)
+
+		history := res.(*AssetHistory)
+		require.Len(t, history.Changes, 1)
+		assert.Equal(t, assetevents.AssetCreatedEventType, history.Changes[0].Type)
+		assert.Equal(t, 1, history.Changes[0].Version)
+		assert.Equal(t, 2, history.NextFromVersion)
+		assert.Nil(t, history.State)
+
+		res, err = sut.Handle(ctx, GetAssetHistoryQuery{AssetID: assetID.String(), FromVersion: 2, Limit: 1})
+		require.NoError(t, err)
+
+		history = res.(*AssetHistory)
+		require.Len(t, history.Changes, 1)
+		assert.Equal(t, assetevents.AssetDeletedEventType, history.Changes[0].Type)
+		assert.Zero(t, history.NextFromVersion)
+	})
+
+	t.Run("get the history as of a point in time", func(t *testing.T) {
+		res, err := sut.Handle(ctx, GetAssetHistoryQuery{AssetID: assetID.String(), AsOf: day(10)})
+		require.NoError(t, err)
+
+		history := res.(*AssetHistory)
+		require.Len(t, history.Changes, 1)
+		require.NotNil(t, history.State)
+		assert.Equal(t, "Savings", history.State.Name)
+		assert.Equal(t, 1000.0, history.State.MoneyAmount)
+		assert.False(t, history.State.Deleted)
+		assert.Equal(t, 1, history.State.Version)
+	})
+
+	t.Run("get the history before the asset existed should return not found", func(t *testing.T) {
+		_, err := sut.Handle(ctx, GetAssetHistoryQuery{AssetID: assetID.String(), AsOf: day(1).Add(-time.Second)})
+		require.ErrorIs(t, err, assets.ErrAssetNotFound)
+	})
+
+	t.Run("get the history of an invalid asset id should return error", func(t *testing.T) {
+		_, err := sut.Handle(ctx, GetAssetHistoryQuery{AssetID: "invalid"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,62 @@
+package assetsqueries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+)
+
+type GetAssetQuery struct {
+	AssetID string
+
+	// AsOf rebuilds the asset as it was at the given time.
+	AsOf time.Time
+
+	// Version rebuilds the asset as it was at the given version.
+	Version int
+}
+
+func (q GetAssetQuery) QueryName() string {
+	return "GetAssetQuery"
+}
+
+type GetAssetQueryHandler struct {
+	assets assets.Repository
+}
+
+func NewGetAssetQueryHandler(assets assets.Repository) *GetAssetQueryHandler {
+	return &GetAssetQueryHandler{
+		assets: assets,
+	}
+}
+
+func (h *GetAssetQueryHandler) Handle(ctx context.Context, query GetAssetQuery) (interface{}, error) {
+	var (
+		err   error
+		asset *assets.Asset
+	)
+
+	assetID, err := uuid.Parse(query.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
+	}
+
+	if query.AsOf.IsZero() && query.Version == 0 {
+		asset, err = h.assets.GetByID(ctx, assetID)
+	} else {
+		asset, err = h.assets.GetByIDAsOf(ctx, assetID, assets.PointInTime{
+			Time:    query.AsOf,
+			Version: query.Version,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	view := NewAssetView(asset)
+	return &view, nil
+}
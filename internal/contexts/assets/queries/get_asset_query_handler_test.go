@@ -0,0 +1,207 @@
+package assetsqueries_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"github.com/xfrr/go-cqrsify/event"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	assetevents "github.com/xfrr/finantrack/internal/contexts/assets/domain/events"
+	. "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+)
+
+// eventsRepository is an assets.Repository rebuilding the assets from a fixed set of changes,
+// sorted by aggregate ID and version, with the inclusive cut-offs of the event stores.
+type eventsRepository struct {
+	assets.Repository
+
+	changes []aggregate.Change
+}
+
+func (r *eventsRepository) GetByID(ctx context.Context, id uuid.UUID) (*assets.Asset, error) {
+	return r.GetByIDAsOf(ctx, id, assets.PointInTime{})
+}
+
+func (r *eventsRepository) GetByIDAsOf(_ context.Context, id uuid.UUID, at assets.PointInTime) (*assets.Asset, error) {
+	var changes []aggregate.Change
+	for _, c := range r.until(at.Time) {
+		if c.Aggregate().ID == id && (at.Version == 0 || c.Aggregate().Version <= at.Version) {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == 0 {
+		return nil, assets.ErrAssetNotFound
+	}
+
+	asset, err := assets.HydrateAsset(id, changes)
+	if err != nil {
+		return nil, err
+	}
+	if asset.IsDeleted() {
+		return nil, assets.ErrAssetNotFound
+	}
+	return asset, nil
+}
+
+func (r *eventsRepository) GetAllAsOf(_ context.Context, at time.Time) ([]*assets.Asset, error) {
+	changes := r.until(at)
+	return assets.HydrateAssets(func(yield func(aggregate.Change, error) bool) {
+		for _, c := range changes {
+			if !yield(c, nil) {
+				return
+			}
+		}
+	})
+}
+
+func (r *eventsRepository) until(at time.Time) []aggregate.Change {
+	if at.IsZero() {
+		return r.changes
+	}
+	return slices.DeleteFunc(slices.Clone(r.changes), func(c aggregate.Change) bool {
+		return c.Time().After(at)
+	})
+}
+
+func assetCreated(id uuid.UUID, name string, amount float64, currency string, at time.Time) aggregate.Change {
+	return event.New[any, any](uuid.New(), assetevents.AssetCreatedEventType, &assetevents.AssetCreatedEvent{
+		AssetID:            id.String(),
+		AssetName:          name,
+		AssetType:          "bank",
+		AssetMoneyAmount:   amount,
+		AssetMoneyCurrency: currency,
+	}, event.WithAggregate(id, assets.AggregateType, 1), event.WithTime(at))
+}
+
+func assetBalanceUpdated(id uuid.UUID, version int, amount float64, currency string, at time.Time) aggregate.Change {
+	return event.New[any, any](uuid.New(), assetevents.AssetBalanceUpdatedEventType, &assetevents.AssetBalanceUpdatedEvent{
+		AssetID:            id.String(),
+		AssetMoneyAmount:   amount,
+		AssetMoneyCurrency: currency,
+		AsOf:               at,
+	}, event.WithAggregate(id, assets.AggregateType, version), event.WithTime(at))
+}
+
+func assetDeleted(id uuid.UUID, version int, at time.Time) aggregate.Change {
+	return event.New[any, any](uuid.New(), assetevents.AssetDeletedEventType, &assetevents.AssetDeletedEvent{
+		AssetID: id.String(),
+	}, event.WithAggregate(id, assets.AggregateType, version), event.WithTime(at))
+}
+
+func TestGetAssetQueryHandler_Handle(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		assetID = uuid.New()
+		day     = func(d int) time.Time { return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC) }
+	)
+
+	sut := NewGetAssetQueryHandler(&eventsRepository{
+		changes: []aggregate.Change{
+			assetCreated(assetID, "Savings", 1000, "EUR", day(1)),
+			assetBalanceUpdated(assetID, 2, 1500, "EUR", day(10)),
+			assetDeleted(assetID, 3, day(20)),
+		},
+	})
+
+	var specs = []struct {
+		name          string
+		query         GetAssetQuery
+		expectedErr   error
+		expectedMoney float64
+		expectedVer   int
+	}{
+		{
+			name:        "current state of a deleted asset",
+			query:       GetAssetQuery{},
+			expectedErr: assets.ErrAssetNotFound,
+		},
+		{
+			name:        "before the asset was created",
+			query:       GetAssetQuery{AsOf: day(1).Add(-time.Nanosecond)},
+			expectedErr: assets.ErrAssetNotFound,
+		},
+		{
+			name:          "at the instant the asset was created",
+			query:         GetAssetQuery{AsOf: day(1)},
+			expectedMoney: 1000,
+			expectedVer:   1,
+		},
+		{
+			name:          "just before the balance was updated",
+			query:         GetAssetQuery{AsOf: day(10).Add(-time.Nanosecond)},
+			expectedMoney: 1000,
+			expectedVer:   1,
+		},
+		{
+			name:          "at the instant the balance was updated",
+			query:         GetAssetQuery{AsOf: day(10)},
+			expectedMoney: 1500,
+			expectedVer:   2,
+		},
+		{
+			name:          "just before the asset was deleted",
+			query:         GetAssetQuery{AsOf: day(20).Add(-time.Nanosecond)},
+			expectedMoney: 1500,
+			expectedVer:   2,
+		},
+		{
+			name:        "at the instant the asset was deleted",
+			query:       GetAssetQuery{AsOf: day(20)},
+			expectedErr: assets.ErrAssetNotFound,
+		},
+		{
+			name:          "at the first version",
+			query:         GetAssetQuery{Version: 1},
+			expectedMoney: 1000,
+			expectedVer:   1,
+		},
+		{
+			name:          "at the last version before the deletion",
+			query:         GetAssetQuery{Version: 2},
+			expectedMoney: 1500,
+			expectedVer:   2,
+		},
+		{
+			name:        "at the version of the deletion",
+			query:       GetAssetQuery{Version: 3},
+			expectedErr: assets.ErrAssetNotFound,
+		},
+		{
+			name:          "at a version and an earlier time, the earliest wins",
+			query:         GetAssetQuery{Version: 2, AsOf: day(5)},
+			expectedMoney: 1000,
+			expectedVer:   1,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			spec.query.AssetID = assetID.String()
+
+			res, err := sut.Handle(ctx, spec.query)
+			if spec.expectedErr != nil {
+				require.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			view := res.(*AssetView)
+			assert.Equal(t, assetID.String(), view.ID)
+			assert.Equal(t, "Savings", view.Name)
+			assert.Equal(t, spec.expectedMoney, view.MoneyAmount)
+			assert.Equal(t, spec.expectedVer, view.Version)
+		})
+	}
+
+	t.Run("get an invalid asset id should return error", func(t *testing.T) {
+		_, err := sut.Handle(ctx, GetAssetQuery{AssetID: "invalid"})
+		require.ErrorIs(t, err, assets.ErrInvalidAssetID)
+	})
+}
@@ -0,0 +1,76 @@
+package assetsqueries
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+)
+
+type GetPortfolioQuery struct {
+	// AsOf rebuilds the portfolio as it was at the given time.
+	AsOf time.Time
+}
+
+func (q GetPortfolioQuery) QueryName() string {
+	return "GetPortfolioQuery"
+}
+
+// PortfolioView represents all the assets owned at a point in time.
+type PortfolioView struct {
+	AsOf   time.Time
+	Assets []AssetView
+
+	// Totals is the sum of the assets money grouped by currency.
+	Totals []MoneyView
+}
+
+// MoneyView represents an amount of money in a currency.
+type MoneyView struct {
+	Amount   float64
+	Currency string
+}
+
+type GetPortfolioQueryHandler struct {
+	assets assets.Repository
+}
+
+func NewGetPortfolioQueryHandler(assets assets.Repository) *GetPortfolioQueryHandler {
+	return &GetPortfolioQueryHandler{
+		assets: assets,
+	}
+}
+
+func (h *GetPortfolioQueryHandler) Handle(ctx context.Context, query GetPortfolioQuery) (interface{}, error) {
+	all, err := h.assets.GetAllAsOf(ctx, query.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio := &PortfolioView{
+		AsOf:   query.AsOf,
+		Assets: make([]AssetView, 0, len(all)),
+	}
+
+	totals := make(map[string]float64)
+	for _, asset := range all {
+		view := NewAssetView(asset)
+		portfolio.Assets = append(portfolio.Assets, view)
+		totals[view.MoneyCurrency] += view.MoneyAmount
+	}
+
+	for currency, amount := range totals {
+		portfolio.Totals = append(portfolio.Totals, MoneyView{
+			Amount:   amount,
+			Currency: currency,
+		})
+	}
+
+	// Sort the totals to get a stable response
+	sort.Slice(portfolio.Totals, func(i, j int) bool {
+		return portfolio.Totals[i].Currency < portfolio.Totals[j].Currency
+	})
+
+	return portfolio, nil
+}
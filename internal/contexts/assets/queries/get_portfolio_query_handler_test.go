@@ -0,0 +1,93 @@
+package assetsqueries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/aggregate"
+
+	. "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+)
+
+func TestGetPortfolioQueryHandler_Handle(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		savings  = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		checking = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+		brokers  = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+		day      = func(d int) time.Time { return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC) }
+	)
+
+	// the changes are sorted by aggregate ID and version, as the event stores stream them
+	sut := NewGetPortfolioQueryHandler(&eventsRepository{
+		changes: []aggregate.Change{
+			assetCreated(savings, "Savings", 1000, "EUR", day(1)),
+			assetBalanceUpdated(savings, 2, 1500, "EUR", day(10)),
+			assetCreated(checking, "Checking", 200, "EUR", day(5)),
+			assetDeleted(checking, 2, day(15)),
+			assetCreated(brokers, "Brokers", 300, "USD", day(10)),
+		},
+	})
+
+	var specs = []struct {
+		name           string
+		asOf           time.Time
+		expectedAssets []string
+		expectedTotals []MoneyView
+	}{
+		{
+			name:           "before any asset was created",
+			asOf:           day(1).Add(-time.Nanosecond),
+			expectedAssets: []string{},
+		},
+		{
+			name:           "at the instant the first asset was created",
+			asOf:           day(1),
+			expectedAssets: []string{"Savings"},
+			expectedTotals: []MoneyView{{Amount: 1000, Currency: "EUR"}},
+		},
+		{
+			name:           "just before the balance was updated",
+			asOf:           day(10).Add(-time.Nanosecond),
+			expectedAssets: []string{"Savings", "Checking"},
+			expectedTotals: []MoneyView{{Amount: 1200, Currency: "EUR"}},
+		},
+		{
+			name:           "at the instant the balance was updated",
+			asOf:           day(10),
+			expectedAssets: []string{"Savings", "Checking", "Brokers"},
+			expectedTotals: []MoneyView{{Amount: 1700, Currency: "EUR"}, {Amount: 300, Currency: "USD"}},
+		},
+		{
+			name:           "at the instant an asset was deleted",
+			asOf:           day(15),
+			expectedAssets: []string{"Savings", "Brokers"},
+			expectedTotals: []MoneyView{{Amount: 1500, Currency: "EUR"}, {Amount: 300, Currency: "USD"}},
+		},
+		{
+			name:           "current state",
+			expectedAssets: []string{"Savings", "Brokers"},
+			expectedTotals: []MoneyView{{Amount: 1500, Currency: "EUR"}, {Amount: 300, Currency: "USD"}},
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			res, err := sut.Handle(ctx, GetPortfolioQuery{AsOf: spec.asOf})
+			require.NoError(t, err)
+
+			portfolio := res.(*PortfolioView)
+			names := make([]string, 0, len(portfolio.Assets))
+			for _, a := range portfolio.Assets {
+				names = append(names, a.Name)
+			}
+			assert.Equal(t, spec.asOf, portfolio.AsOf)
+			assert.Equal(t, spec.expectedAssets, names)
+			assert.Equal(t, spec.expectedTotals, portfolio.Totals)
+		})
+	}
+}
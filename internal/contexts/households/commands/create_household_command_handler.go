@@ -0,0 +1,66 @@
+package householdscommands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+)
+
+type CreateHouseholdCommand struct {
+	HouseholdID   string
+	HouseholdName string
+}
+
+func (c CreateHouseholdCommand) CommandName() string {
+	return "CreateHouseholdCommand"
+}
+
+type CreateHouseholdCommandHandler struct {
+	households households.Repository
+}
+
+func NewCreateHouseholdCommandHandler(households households.Repository) *CreateHouseholdCommandHandler {
+	return &CreateHouseholdCommandHandler{
+		households: households,
+	}
+}
+
+func (h *CreateHouseholdCommandHandler) Handle(ctx context.Context, cmd CreateHouseholdCommand) (interface{}, error) {
+	// The authenticated user becomes the household owner
+	principal, ok := xauth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, xauth.ErrUnauthenticated
+	}
+
+	householdID, err := uuid.Parse(cmd.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", households.ErrInvalidHouseholdID, err)
+	}
+
+	// Check if the household already exists
+	if ok, err = h.households.Exists(ctx, householdID); err != nil {
+		return nil, err
+	} else if ok {
+		return nil, households.ErrHouseholdAlreadyExists
+	}
+
+	household, err := households.NewHousehold(householdID, cmd.HouseholdName, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.households.Save(ctx, household); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("household_id", household.ID().String()).
+		Msg("household created")
+
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package householdscommands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+)
+
+type RemoveHouseholdMemberCommand struct {
+	HouseholdID string
+	UserID      string
+}
+
+func (c RemoveHouseholdMemberCommand) CommandName() string {
+	return "RemoveHouseholdMemberCommand"
+}
+
+type RemoveHouseholdMemberCommandHandler struct {
+	households households.Repository
+}
+
+func NewRemoveHouseholdMemberCommandHandler(households households.Repository) *RemoveHouseholdMemberCommandHandler {
+	return &RemoveHouseholdMemberCommandHandler{
+		households: households,
+	}
+}
+
+func (h *RemoveHouseholdMemberCommandHandler) Handle(ctx context.Context, cmd RemoveHouseholdMemberCommand) (interface{}, error) {
+	principal, ok := xauth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, xauth.ErrUnauthenticated
+	}
+
+	householdID, err := uuid.Parse(cmd.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", households.ErrInvalidHouseholdID, err)
+	}
+
+	household, err := h.households.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Members can leave the household, but only the owners can remove others
+	if cmd.UserID != principal.Subject {
+		if err = household.Authorize(principal.Subject, households.PermissionManage); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = household.RemoveMember(cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	if err = h.households.Save(ctx, household); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("household_id", household.ID().String()).
+		Str("user_id", cmd.UserID).
+		Msg("household member removed")
+
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package householdscommands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+)
+
+type SetHouseholdMemberCommand struct {
+	HouseholdID string
+	UserID      string
+	Role        string
+}
+
+func (c SetHouseholdMemberCommand) CommandName() string {
+	return "SetHouseholdMemberCommand"
+}
+
+type SetHouseholdMemberCommandHandler struct {
+	households households.Repository
+}
+
+func NewSetHouseholdMemberCommandHandler(households households.Repository) *SetHouseholdMemberCommandHandler {
+	return &SetHouseholdMemberCommandHandler{
+		households: households,
+	}
+}
+
+func (h *SetHouseholdMemberCommandHandler) Handle(ctx context.Context, cmd SetHouseholdMemberCommand) (interface{}, error) {
+	principal, ok := xauth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, xauth.ErrUnauthenticated
+	}
+
+	householdID, err := uuid.Parse(cmd.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", households.ErrInvalidHouseholdID, err)
+	}
+
+	household, err := h.households.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the owners can manage the members
+	if err = household.Authorize(principal.Subject, households.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if err = household.SetMember(cmd.UserID, households.Role(cmd.Role)); err != nil {
+		return nil, err
+	}
+
+	if err = h.households.Save(ctx, household); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("household_id", household.ID().String()).
+		Str("user_id", cmd.UserID).
+		Str("role", cmd.Role).
+		Msg("household member set")
+
+	return nil, nil
+}
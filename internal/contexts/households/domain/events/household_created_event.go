@@ -0,0 +1,9 @@
+package householdevents
+
+const HouseholdCreatedEventType = "household.created"
+
+type HouseholdCreatedEvent struct {
+	HouseholdID   string `json:"householdId"`
+	HouseholdName string `json:"householdName"`
+	OwnerID       string `json:"ownerId"`
+}
@@ -0,0 +1,9 @@
+package householdevents
+
+const HouseholdMemberAddedEventType = "household.member.added"
+
+type HouseholdMemberAddedEvent struct {
+	HouseholdID string `json:"householdId"`
+	UserID      string `json:"userId"`
+	Role        string `json:"role"`
+}
@@ -0,0 +1,8 @@
+package householdevents
+
+const HouseholdMemberRemovedEventType = "household.member.removed"
+
+type HouseholdMemberRemovedEvent struct {
+	HouseholdID string `json:"householdId"`
+	UserID      string `json:"userId"`
+}
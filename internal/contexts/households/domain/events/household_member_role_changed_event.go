@@ -0,0 +1,9 @@
+package householdevents
+
+const HouseholdMemberRoleChangedEventType = "household.member.role_changed"
+
+type HouseholdMemberRoleChangedEvent struct {
+	HouseholdID string `json:"householdId"`
+	UserID      string `json:"userId"`
+	Role        string `json:"role"`
+}
@@ -0,0 +1,326 @@
+package householddomain
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
+
+	householdevents "github.com/xfrr/finantrack/internal/contexts/households/domain/events"
+)
+
+// AggregateType represents the household aggregate type.
+const AggregateType = "household"
+
+var (
+	// ErrInvalidHouseholdID represents the error when the household ID is not a valid UUID.
+	ErrInvalidHouseholdID = errors.New("invalid household identifier")
+
+	// ErrHouseholdNameIsRequired represents the error when the household name is required.
+	ErrHouseholdNameIsRequired = errors.New("household name is required")
+
+	// ErrHouseholdOwnerIsRequired represents the error when the household owner is required.
+	ErrHouseholdOwnerIsRequired = errors.New("household owner is required")
+
+	// ErrHouseholdNotFound represents the error when the household is not found
+	// or the user is not one of its members.
+	ErrHouseholdNotFound = errors.New("household not found")
+
+	// ErrHouseholdAlreadyExists represents the error when the household already exists.
+	ErrHouseholdAlreadyExists = errors.New("household already exists with given identifier")
+
+	// ErrMemberNotFound represents the error when the user is not a member of the household.
+	ErrMemberNotFound = errors.New("household member not found")
+
+	// ErrLastOwner represents the error when the last owner of a household would be removed or demoted.
+	ErrLastOwner = errors.New("household must keep at least one owner")
+
+	// ErrForbidden represents the error when the member role does not grant the requested permission.
+	ErrForbidden = errors.New("household role does not grant the requested permission")
+)
+
+// Member represents a user that shares a household.
+type Member struct {
+	UserID string
+	Role   Role
+}
+
+// Household represents a group of users sharing their finances.
+// It is the tenant every other aggregate belongs to.
+type Household struct {
+	*aggregate.Base[uuid.UUID]
+
+	name    string
+	members map[string]Role
+}
+
+// NewHousehold creates a new Household owned by the given user.
+func NewHousehold(id uuid.UUID, name, ownerID string) (*Household, error) {
+	if name == "" {
+		return nil, ErrHouseholdNameIsRequired
+	}
+
+	if ownerID == "" {
+		return nil, ErrHouseholdOwnerIsRequired
+	}
+
+	household := newHousehold(id)
+
+	aggregate.NextChange(
+		household,
+		uuid.New(),
+		householdevents.HouseholdCreatedEventType,
+		&householdevents.HouseholdCreatedEvent{
+			HouseholdID:   id.String(),
+			HouseholdName: name,
+			OwnerID:       ownerID,
+		},
+	)
+
+	return household, nil
+}
+
+// ID returns the household ID.
+func (h *Household) ID() uuid.UUID {
+	return h.Base.AggregateID()
+}
+
+// Name returns the household name.
+func (h *Household) Name() string {
+	return h.name
+}
+
+// Members returns the household members sorted by user ID.
+func (h *Household) Members() []Member {
+	members := make([]Member, 0, len(h.members))
+	for userID, role := range h.members {
+		members = append(members, Member{UserID: userID, Role: role})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserID < members[j].UserID
+	})
+
+	return members
+}
+
+// RoleOf returns the role of the given user in the household.
+func (h *Household) RoleOf(userID string) (Role, bool) {
+	role, ok := h.members[userID]
+	return role, ok
+}
+
+// Authorize checks if the given user is granted the permission in the household.
+// Users that are not members get ErrHouseholdNotFound, so the household is not disclosed.
+func (h *Household) Authorize(userID string, p Permission) error {
+	role, ok := h.RoleOf(userID)
+	if !ok {
+		return ErrHouseholdNotFound
+	}
+
+	if !role.Can(p) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// SetMember adds the user to the household with the given role,
+// or changes its role if the user is already a member.
+func (h *Household) SetMember(userID string, role Role) error {
+	if err := role.Validate(); err != nil {
+		return err
+	}
+
+	current, ok := h.members[userID]
+	switch {
+	case !ok:
+		aggregate.NextChange(
+			h,
+			uuid.New(),
+			householdevents.HouseholdMemberAddedEventType,
+			&householdevents.HouseholdMemberAddedEvent{
+				HouseholdID: h.ID().String(),
+				UserID:      userID,
+				Role:        role.String(),
+			},
+		)
+	case current != role:
+		if current == RoleOwner && h.owners() == 1 {
+			return ErrLastOwner
+		}
+
+		aggregate.NextChange(
+			h,
+			uuid.New(),
+			householdevents.HouseholdMemberRoleChangedEventType,
+			&householdevents.HouseholdMemberRoleChangedEvent{
+				HouseholdID: h.ID().String(),
+				UserID:      userID,
+				Role:        role.String(),
+			},
+		)
+	}
+
+	return nil
+}
+
+// RemoveMember removes the user from the household.
+func (h *Household) RemoveMember(userID string) error {
+	role, ok := h.members[userID]
+	if !ok {
+		return ErrMemberNotFound
+	}
+
+	if role == RoleOwner && h.owners() == 1 {
+		return ErrLastOwner
+	}
+
+	aggregate.NextChange(
+		h,
+		uuid.New(),
+		householdevents.HouseholdMemberRemovedEventType,
+		&householdevents.HouseholdMemberRemovedEvent{
+			HouseholdID: h.ID().String(),
+			UserID:      userID,
+		},
+	)
+
+	return nil
+}
+
+// owners returns the number of owners of the household.
+func (h *Household) owners() int {
+	var n int
+	for _, role := range h.members {
+		if role == RoleOwner {
+			n++
+		}
+	}
+	return n
+}
+
+// HydrateHousehold rebuilds the household with the given ID from its changes.
+func HydrateHousehold(id uuid.UUID, changes []aggregate.Change) (*Household, error) {
+	household := newHousehold(id)
+
+	if err := aggregate.Hydrate(household, changes); err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+// HydrateHouseholds rebuilds the households from a sequence of changes
+// sorted by aggregate ID and version.
+func HydrateHouseholds(changes iter.Seq2[aggregate.Change, error]) ([]*Household, error) {
+	var (
+		households []*Household
+		id         uuid.UUID
+		pending    []aggregate.Change
+	)
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		household, err := HydrateHousehold(id, pending)
+		if err != nil {
+			return fmt.Errorf("failed to hydrate household %s: %w", id, err)
+		}
+
+		households = append(households, household)
+		pending = nil
+		return nil
+	}
+
+	for change, err := range changes {
+		if err != nil {
+			return nil, err
+		}
+
+		if change.Aggregate() == nil {
+			return nil, aggregate.ErrInvalidEventAggregateReference
+		}
+
+		changeAggregateID, ok := change.Aggregate().ID.(uuid.UUID)
+		if !ok {
+			return nil, aggregate.ErrInvalidAggregateID
+		}
+
+		if changeAggregateID != id {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+			id = changeAggregateID
+		}
+
+		pending = append(pending, change)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return households, nil
+}
+
+// newHousehold creates an empty household with its event handlers registered.
+func newHousehold(id uuid.UUID) *Household {
+	household := &Household{
+		Base:    aggregate.New(id, AggregateType),
+		members: make(map[string]Role),
+	}
+
+	household.When(householdevents.HouseholdCreatedEventType, household.householdCreatedEventHandler)
+	household.When(householdevents.HouseholdMemberAddedEventType, household.memberAddedEventHandler)
+	household.When(householdevents.HouseholdMemberRoleChangedEventType, household.memberRoleChangedEventHandler)
+	household.When(householdevents.HouseholdMemberRemovedEventType, household.memberRemovedEventHandler)
+
+	return household
+}
+
+// householdCreatedEventHandler is the event handler for the household created event.
+func (h *Household) householdCreatedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*householdevents.HouseholdCreatedEvent)
+	if !ok {
+		return
+	}
+
+	h.name = evt.HouseholdName
+	h.members[evt.OwnerID] = RoleOwner
+}
+
+// memberAddedEventHandler is the event handler for the household member added event.
+func (h *Household) memberAddedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*householdevents.HouseholdMemberAddedEvent)
+	if !ok {
+		return
+	}
+
+	h.members[evt.UserID] = Role(evt.Role)
+}
+
+// memberRoleChangedEventHandler is the event handler for the household member role changed event.
+func (h *Household) memberRoleChangedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*householdevents.HouseholdMemberRoleChangedEvent)
+	if !ok {
+		return
+	}
+
+	h.members[evt.UserID] = Role(evt.Role)
+}
+
+// memberRemovedEventHandler is the event handler for the household member removed event.
+func (h *Household) memberRemovedEventHandler(event aggregate.Change) {
+	evt, ok := event.Payload().(*householdevents.HouseholdMemberRemovedEvent)
+	if !ok {
+		return
+	}
+
+	delete(h.members, evt.UserID)
+}
@@ -0,0 +1,60 @@
+package householddomain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/contexts/households/domain"
+)
+
+func TestHousehold_Authorize(t *testing.T) {
+	household, err := NewHousehold(uuid.New(), "Home", "alice")
+	require.NoError(t, err)
+	require.NoError(t, household.SetMember("bob", RoleEditor))
+	require.NoError(t, household.SetMember("carol", RoleViewer))
+
+	var specs = []struct {
+		user       string
+		permission Permission
+		expected   error
+	}{
+		{user: "alice", permission: PermissionManage},
+		{user: "bob", permission: PermissionWrite},
+		{user: "bob", permission: PermissionManage, expected: ErrForbidden},
+		{user: "carol", permission: PermissionRead},
+		{user: "carol", permission: PermissionWrite, expected: ErrForbidden},
+		{user: "mallory", permission: PermissionRead, expected: ErrHouseholdNotFound},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.user+" "+string(spec.permission), func(t *testing.T) {
+			assert.ErrorIs(t, household.Authorize(spec.user, spec.permission), spec.expected)
+		})
+	}
+}
+
+func TestHousehold_Members(t *testing.T) {
+	id := uuid.New()
+
+	household, err := NewHousehold(id, "Home", "alice")
+	require.NoError(t, err)
+
+	require.NoError(t, household.SetMember("bob", RoleViewer))
+	require.NoError(t, household.SetMember("bob", RoleOwner))
+	assert.ErrorIs(t, household.SetMember("bob", "admin"), ErrInvalidRole)
+
+	// the last owner cannot leave or be demoted
+	require.NoError(t, household.RemoveMember("alice"))
+	assert.ErrorIs(t, household.RemoveMember("bob"), ErrLastOwner)
+	assert.ErrorIs(t, household.SetMember("bob", RoleEditor), ErrLastOwner)
+	assert.ErrorIs(t, household.RemoveMember("alice"), ErrMemberNotFound)
+
+	// the state is rebuilt from the recorded changes
+	hydrated, err := HydrateHousehold(id, household.AggregateChanges())
+	require.NoError(t, err)
+	assert.Equal(t, "Home", hydrated.Name())
+	assert.Equal(t, []Member{{UserID: "bob", Role: RoleOwner}}, hydrated.Members())
+}
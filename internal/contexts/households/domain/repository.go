@@ -0,0 +1,22 @@
+package householddomain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the interface that wraps the basic household repository methods.
+type Repository interface {
+	// Save saves all the household uncommited events to the event store
+	Save(ctx context.Context, household *Household) error
+
+	// GetByID returns the household by the given ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Household, error)
+
+	// GetByMember returns the households the given user is a member of
+	GetByMember(ctx context.Context, userID string) ([]*Household, error)
+
+	// Exists checks if a household with the given ID exists
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+}
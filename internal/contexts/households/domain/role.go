@@ -0,0 +1,65 @@
+package householddomain
+
+import "errors"
+
+const (
+	// RoleOwner manages the household and its members, and reads and writes its data.
+	RoleOwner Role = "owner"
+
+	// RoleEditor reads and writes the household data.
+	RoleEditor Role = "editor"
+
+	// RoleViewer only reads the household data.
+	RoleViewer Role = "viewer"
+)
+
+const (
+	// PermissionRead allows to read the household data.
+	PermissionRead Permission = "read"
+
+	// PermissionWrite allows to create, modify and delete the household data.
+	PermissionWrite Permission = "write"
+
+	// PermissionManage allows to manage the household members.
+	PermissionManage Permission = "manage"
+)
+
+var (
+	// ErrInvalidRole represents the error when the member role is invalid.
+	ErrInvalidRole = errors.New("invalid role, please use owner, editor or viewer")
+)
+
+// Role represents the role of a member in a household.
+type Role string
+
+// Permission represents an action a member can perform in a household.
+type Permission string
+
+// String returns the string representation of the role.
+func (r Role) String() string {
+	return string(r)
+}
+
+// Validate validates the role.
+func (r Role) Validate() error {
+	switch r {
+	case RoleOwner, RoleEditor, RoleViewer:
+		return nil
+	}
+
+	return ErrInvalidRole
+}
+
+// Can checks if the role grants the given permission.
+func (r Role) Can(p Permission) bool {
+	switch r {
+	case RoleOwner:
+		return true
+	case RoleEditor:
+		return p == PermissionRead || p == PermissionWrite
+	case RoleViewer:
+		return p == PermissionRead
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package householdsmongo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+)
+
+var _ householddomain.Repository = (*Repository)(nil)
+
+var tracer = xtracing.Tracer("github.com/xfrr/finantrack/internal/contexts/households/mongodb")
+
+// Repository implements the Repository interface using MongoDB.
+type Repository struct {
+	eventStore xmongo.EventStore
+}
+
+// NewRepository creates a new Repository with the given MongoDB event store.
+func NewRepository(eventStore xmongo.EventStore) *Repository {
+	return &Repository{
+		eventStore: eventStore,
+	}
+}
+
+// Save saves the household changes into the event store.
+// The household is the tenant of its own events.
+func (r *Repository) Save(ctx context.Context, household *householddomain.Household) (err error) {
+	changes := household.AggregateChanges()
+
+	ctx, span := tracer.Start(ctx, "householdsmongo.Repository.Save", trace.WithAttributes(xtracing.ChangesAttributes(changes)...))
+	defer xtracing.End(span, &err)
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return r.eventStore.Save(xtenant.WithTenant(ctx, household.ID().String()), changes...)
+}
+
+// GetByID retrieves a household by its ID from the event store.
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (_ *householddomain.Household, err error) {
+	ctx, span := tracer.Start(ctx, "householdsmongo.Repository.GetByID", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(id.String()),
+		xtracing.AggregateTypeKey.String(householddomain.AggregateType),
+	))
+	defer xtracing.End(span, &err)
+
+	changes, err := r.eventStore.Get(ctx,
+		xmongo.And(
+			xmongo.WithAggregateIDCriteria(id.String())(),
+			xmongo.WithAggregateTypeCriteria(householddomain.AggregateType)(),
+		)(),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, householddomain.ErrHouseholdNotFound
+	}
+
+	return householddomain.HydrateHousehold(id, changes)
+}
+
+// GetByMember retrieves the households the given user is a member of.
+func (r *Repository) GetByMember(ctx context.Context, userID string) ([]*householddomain.Household, error) {
+	households, err := householddomain.HydrateHouseholds(r.eventStore.Stream(ctx,
+		xmongo.WithAggregateTypeCriteria(householddomain.AggregateType)(),
+		xmongo.WithSort(xmongo.SortByAggregateID, xmongo.Ascending),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*householddomain.Household
+	for _, h := range households {
+		if _, ok := h.RoleOf(userID); ok {
+			result = append(result, h)
+		}
+	}
+
+	return result, nil
+}
+
+// Exists checks if a household with the given ID exists in the event store.
+func (r *Repository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.eventStore.ExistsByAggregateID(ctx, id)
+}
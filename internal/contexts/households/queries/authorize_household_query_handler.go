@@ -0,0 +1,56 @@
+package householdsqueries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+)
+
+// AuthorizeHouseholdQuery checks the authenticated user is granted
+// the permission in the household. It returns the role of the user.
+type AuthorizeHouseholdQuery struct {
+	HouseholdID string
+	Permission  households.Permission
+}
+
+func (q AuthorizeHouseholdQuery) QueryName() string {
+	return "AuthorizeHouseholdQuery"
+}
+
+type AuthorizeHouseholdQueryHandler struct {
+	households households.Repository
+}
+
+func NewAuthorizeHouseholdQueryHandler(households households.Repository) *AuthorizeHouseholdQueryHandler {
+	return &AuthorizeHouseholdQueryHandler{
+		households: households,
+	}
+}
+
+func (h *AuthorizeHouseholdQueryHandler) Handle(ctx context.Context, query AuthorizeHouseholdQuery) (interface{}, error) {
+	principal, ok := xauth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, xauth.ErrUnauthenticated
+	}
+
+	householdID, err := uuid.Parse(query.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", households.ErrInvalidHouseholdID, err)
+	}
+
+	household, err := h.households.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = household.Authorize(principal.Subject, query.Permission); err != nil {
+		return nil, err
+	}
+
+	role, _ := household.RoleOf(principal.Subject)
+	return role, nil
+}
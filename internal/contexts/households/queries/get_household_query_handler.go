@@ -0,0 +1,53 @@
+package householdsqueries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+)
+
+type GetHouseholdQuery struct {
+	HouseholdID string
+}
+
+func (q GetHouseholdQuery) QueryName() string {
+	return "GetHouseholdQuery"
+}
+
+type GetHouseholdQueryHandler struct {
+	households households.Repository
+}
+
+func NewGetHouseholdQueryHandler(households households.Repository) *GetHouseholdQueryHandler {
+	return &GetHouseholdQueryHandler{
+		households: households,
+	}
+}
+
+func (h *GetHouseholdQueryHandler) Handle(ctx context.Context, query GetHouseholdQuery) (interface{}, error) {
+	principal, ok := xauth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, xauth.ErrUnauthenticated
+	}
+
+	householdID, err := uuid.Parse(query.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", households.ErrInvalidHouseholdID, err)
+	}
+
+	household, err := h.households.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = household.Authorize(principal.Subject, households.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	view := NewHouseholdView(household)
+	return &view, nil
+}
@@ -0,0 +1,44 @@
+package householdsqueries
+
+import (
+	"context"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+)
+
+// GetHouseholdsQuery returns the households of the authenticated user.
+type GetHouseholdsQuery struct{}
+
+func (q GetHouseholdsQuery) QueryName() string {
+	return "GetHouseholdsQuery"
+}
+
+type GetHouseholdsQueryHandler struct {
+	households households.Repository
+}
+
+func NewGetHouseholdsQueryHandler(households households.Repository) *GetHouseholdsQueryHandler {
+	return &GetHouseholdsQueryHandler{
+		households: households,
+	}
+}
+
+func (h *GetHouseholdsQueryHandler) Handle(ctx context.Context, _ GetHouseholdsQuery) (interface{}, error) {
+	principal, ok := xauth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, xauth.ErrUnauthenticated
+	}
+
+	list, err := h.households.GetByMember(ctx, principal.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]HouseholdView, 0, len(list))
+	for _, household := range list {
+		views = append(views, NewHouseholdView(household))
+	}
+
+	return views, nil
+}
@@ -0,0 +1,37 @@
+package householdsqueries
+
+import (
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+)
+
+// HouseholdView represents the state of a household returned by the queries.
+type HouseholdView struct {
+	ID      string
+	Name    string
+	Members []MemberView
+	Version int
+}
+
+// MemberView represents a member of a household.
+type MemberView struct {
+	UserID string
+	Role   string
+}
+
+// NewHouseholdView creates a new HouseholdView from the given household.
+func NewHouseholdView(household *households.Household) HouseholdView {
+	view := HouseholdView{
+		ID:      household.ID().String(),
+		Name:    household.Name(),
+		Version: int(household.AggregateVersion()),
+	}
+
+	for _, m := range household.Members() {
+		view.Members = append(view.Members, MemberView{
+			UserID: m.UserID,
+			Role:   m.Role.String(),
+		})
+	}
+
+	return view
+}
@@ -0,0 +1,129 @@
+package reportdomain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// GranularityMonthly groups the report by calendar month.
+	GranularityMonthly Granularity = "monthly"
+
+	// GranularityQuarterly groups the report by calendar quarter.
+	GranularityQuarterly Granularity = "quarterly"
+
+	// GranularityYearly groups the report by calendar year.
+	GranularityYearly Granularity = "yearly"
+)
+
+// MaxPeriods is the maximum number of periods of a report.
+const MaxPeriods = 600
+
+var (
+	// ErrInvalidGranularity represents the error when the report granularity is not valid.
+	ErrInvalidGranularity = errors.New("invalid report granularity")
+
+	// ErrInvalidPeriod represents the error when the report range is empty or too long.
+	ErrInvalidPeriod = errors.New("invalid report period")
+)
+
+// Granularity represents the length of the periods of a report.
+type Granularity string
+
+// String returns the string representation of the granularity.
+func (g Granularity) String() string {
+	return string(g)
+}
+
+// Validate validates the granularity.
+func (g Granularity) Validate() error {
+	switch g {
+	case GranularityMonthly, GranularityQuarterly, GranularityYearly:
+		return nil
+	}
+
+	return ErrInvalidGranularity
+}
+
+// Period returns the period of the granularity the given time belongs to.
+func (g Granularity) Period(t time.Time) Period {
+	t = t.UTC()
+	switch g {
+	case GranularityQuarterly:
+		start := time.Date(t.Year(), t.Month()-(t.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		return Period{Granularity: g, Start: start, End: start.AddDate(0, 3, 0)}
+	case GranularityYearly:
+		start := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return Period{Granularity: g, Start: start, End: start.AddDate(1, 0, 0)}
+	default:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return Period{Granularity: g, Start: start, End: start.AddDate(0, 1, 0)}
+	}
+}
+
+// DefaultFrom returns the start of the default range of a report ending at the given
+// time: the last twelve months, the last four quarters or the last five years.
+func (g Granularity) DefaultFrom(to time.Time) time.Time {
+	start := g.Period(to).Start
+	switch g {
+	case GranularityQuarterly:
+		return start.AddDate(0, -9, 0)
+	case GranularityYearly:
+		return start.AddDate(-4, 0, 0)
+	default:
+		return start.AddDate(0, -11, 0)
+	}
+}
+
+// Periods returns the consecutive periods covering the range between from and to, both inclusive.
+func (g Granularity) Periods(from, to time.Time) ([]Period, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: %s is before %s", ErrInvalidPeriod, to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+
+	var periods []Period
+	for p := g.Period(from); !p.Start.After(to); p = g.Period(p.End) {
+		if len(periods) == MaxPeriods {
+			return nil, fmt.Errorf("%w: more than %d %s periods", ErrInvalidPeriod, MaxPeriods, g)
+		}
+		periods = append(periods, p)
+	}
+
+	return periods, nil
+}
+
+// Period represents a calendar month, quarter or year.
+type Period struct {
+	Granularity Granularity
+
+	// Start is the first instant of the period and End the first instant after it.
+	Start time.Time
+	End   time.Time
+}
+
+// Label returns the name of the period, e.g. 2024-01, 2024-Q1 or 2024.
+func (p Period) Label() string {
+	switch p.Granularity {
+	case GranularityQuarterly:
+		return fmt.Sprintf("%d-Q%d", p.Start.Year(), (int(p.Start.Month())-1)/3+1)
+	case GranularityYearly:
+		return fmt.Sprintf("%d", p.Start.Year())
+	default:
+		return p.Start.Format("2006-01")
+	}
+}
+
+// LastDay returns the last day of the period.
+func (p Period) LastDay() time.Time {
+	return p.End.AddDate(0, 0, -1)
+}
+
+// Contains checks if the given time belongs to the period.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
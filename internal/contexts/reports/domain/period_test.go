@@ -0,0 +1,95 @@
+package reportdomain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+)
+
+func TestGranularity_Periods(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	var specs = []struct {
+		name           string
+		granularity    Granularity
+		from           time.Time
+		to             time.Time
+		expectedLabels []string
+		expectedErr    error
+	}{
+		{
+			name:           "months of a quarter",
+			granularity:    GranularityMonthly,
+			from:           date(2024, 1, 15),
+			to:             date(2024, 3, 1),
+			expectedLabels: []string{"2024-01", "2024-02", "2024-03"},
+		},
+		{
+			name:           "quarters across years",
+			granularity:    GranularityQuarterly,
+			from:           date(2023, 11, 30),
+			to:             date(2024, 4, 1),
+			expectedLabels: []string{"2023-Q4", "2024-Q1", "2024-Q2"},
+		},
+		{
+			name:           "single year",
+			granularity:    GranularityYearly,
+			from:           date(2024, 6, 1),
+			to:             date(2024, 6, 1),
+			expectedLabels: []string{"2024"},
+		},
+		{
+			name:        "invalid granularity",
+			granularity: "weekly",
+			from:        date(2024, 1, 1),
+			to:          date(2024, 2, 1),
+			expectedErr: ErrInvalidGranularity,
+		},
+		{
+			name:        "reversed range",
+			granularity: GranularityMonthly,
+			from:        date(2024, 2, 1),
+			to:          date(2024, 1, 1),
+			expectedErr: ErrInvalidPeriod,
+		},
+		{
+			name:        "too many periods",
+			granularity: GranularityMonthly,
+			from:        date(1900, 1, 1),
+			to:          date(2024, 1, 1),
+			expectedErr: ErrInvalidPeriod,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			periods, err := spec.granularity.Periods(spec.from, spec.to)
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			labels := make([]string, 0, len(periods))
+			for _, p := range periods {
+				labels = append(labels, p.Label())
+				assert.True(t, p.Contains(p.Start))
+				assert.True(t, p.Contains(p.LastDay()))
+				assert.False(t, p.Contains(p.End))
+			}
+			assert.Equal(t, spec.expectedLabels, labels)
+		})
+	}
+}
+
+func TestGranularity_DefaultFrom(t *testing.T) {
+	to := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), GranularityMonthly.DefaultFrom(to))
+	assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), GranularityQuarterly.DefaultFrom(to))
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), GranularityYearly.DefaultFrom(to))
+}
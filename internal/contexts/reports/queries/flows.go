@@ -0,0 +1,170 @@
+package reportsqueries
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// Uncategorized is the category of the transactions without category.
+const Uncategorized = "Uncategorized"
+
+// flow is an amount of money earned or spent in a category.
+// Income and expenses are both positive, refunds are negative.
+type flow struct {
+	date     time.Time
+	currency string
+	category string
+	kind     transactions.CategoryKind
+	amount   float64
+}
+
+// flowReader reads the income and expenses of the tenant from the read models.
+type flowReader struct {
+	transactions transactions.Repository
+	categories   transactions.CategoryRepository
+	assets       assets.Repository
+}
+
+// read returns the flows booked between from and to, the latter excluded.
+// Transfers move money between accounts, so they are neither income nor expenses
+// and are left out, along with the transactions of deleted assets.
+func (r flowReader) read(ctx context.Context, from, to time.Time) ([]flow, error) {
+	all, err := r.assets.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make(map[uuid.UUID]bool, len(all))
+	for _, asset := range all {
+		visible[asset.ID()] = true
+	}
+
+	categories, err := r.categories.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// category names are case insensitive, the spelling of the category, or else the first one, is kept
+	var (
+		names = make(map[string]string, len(categories))
+		kinds = make(map[string]transactions.CategoryKind, len(categories))
+	)
+	for _, category := range categories {
+		names[strings.ToLower(category.Name())] = category.Name()
+		kinds[strings.ToLower(category.Name())] = category.Kind()
+	}
+
+	// the store selects the transactions booked in the range, its end is excluded below
+	records, err := r.transactions.GetByBookingDate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var flows []flow
+	add := func(details transactions.Details, category, transfer string, amount float64) {
+		if transfer != "" || amount == 0 {
+			return
+		}
+
+		category = transactions.NormalizeCategoryName(category)
+		if category == "" {
+			category = Uncategorized
+		}
+
+		key := strings.ToLower(category)
+		if name, ok := names[key]; ok {
+			category = name
+		} else {
+			names[key] = category
+		}
+
+		// the kind of the flows of unknown categories, e.g. the transactions imported
+		// without category, is guessed from the sign of each amount
+		kind, ok := kinds[key]
+		if !ok {
+			kind = transactions.CategoryKindExpense
+			if amount > 0 {
+				kind = transactions.CategoryKindIncome
+			}
+		}
+
+		if kind == transactions.CategoryKindExpense {
+			amount = -amount
+		}
+
+		flows = append(flows, flow{
+			date:     details.BookingDate,
+			currency: details.Currency,
+			category: category,
+			kind:     kind,
+			amount:   amount,
+		})
+	}
+
+	for _, transaction := range records {
+		details := transaction.Details()
+		if !visible[transaction.AssetID()] || details.BookingDate.Before(from) || !details.BookingDate.Before(to) {
+			continue
+		}
+
+		if len(details.Splits) == 0 {
+			add(details, details.Category, details.Transfer, details.Amount)
+			continue
+		}
+
+		for _, split := range details.Splits {
+			add(details, split.Category, split.Transfer, split.Amount)
+		}
+	}
+
+	return flows, nil
+}
+
+// reportPeriods returns the periods of a report, defaulting the range to the
+// last periods up to now.
+func reportPeriods(granularity reports.Granularity, from, to time.Time) ([]reports.Period, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = granularity.DefaultFrom(to)
+	}
+
+	return granularity.Periods(from, to)
+}
+
+// currenciesOf returns the sorted currencies of the flows.
+func currenciesOf(flows []flow) []string {
+	seen := make(map[string]bool)
+	var currencies []string
+	for _, f := range flows {
+		if !seen[f.currency] {
+			seen[f.currency] = true
+			currencies = append(currencies, f.currency)
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// roundMoney rounds an amount of money to cents.
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ratio returns the part over the total rounded to four decimals, or zero when there is no total.
+func ratio(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(part/total*1e4) / 1e4
+}
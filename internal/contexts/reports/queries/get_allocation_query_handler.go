@@ -0,0 +1,120 @@
+package reportsqueries
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+)
+
+// GetAllocationQuery returns how the money of the tenant is distributed among the asset types.
+type GetAllocationQuery struct {
+	// AsOf rebuilds the allocation as it was at the given time.
+	AsOf time.Time
+}
+
+func (q GetAllocationQuery) QueryName() string {
+	return "GetAllocationQuery"
+}
+
+// AllocationView represents the asset allocation at a point in time.
+type AllocationView struct {
+	AsOf time.Time
+
+	// Allocations are sorted by currency and then from the largest to the smallest amount.
+	Allocations []AllocationItemView
+}
+
+// AllocationItemView represents the money held in the assets of a type and currency.
+type AllocationItemView struct {
+	Currency  string
+	AssetType string
+	Assets    int
+	Amount    float64
+
+	// Share is the part of the money in the currency held in the assets of the type.
+	Share float64
+}
+
+type GetAllocationQueryHandler struct {
+	assets assets.Repository
+}
+
+func NewGetAllocationQueryHandler(assets assets.Repository) *GetAllocationQueryHandler {
+	return &GetAllocationQueryHandler{
+		assets: assets,
+	}
+}
+
+func (h *GetAllocationQueryHandler) Handle(ctx context.Context, query GetAllocationQuery) (interface{}, error) {
+	var (
+		all []*assets.Asset
+		err error
+	)
+
+	if query.AsOf.IsZero() {
+		all, err = h.assets.GetAll(ctx)
+	} else {
+		all, err = h.assets.GetAllAsOf(ctx, query.AsOf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		currency  string
+		assetType assets.AssetType
+	}
+
+	var (
+		keys    []key
+		counts  = make(map[key]int)
+		amounts = make(map[key]float64)
+		totals  = make(map[string]float64)
+	)
+
+	for _, asset := range all {
+		// credit cards hold debts, not money
+		if asset.IsDeleted() || asset.Type() == assets.AssetTypeCreditCard {
+			continue
+		}
+
+		k := key{currency: asset.Money().Currency.String(), assetType: asset.Type()}
+		if _, ok := counts[k]; !ok {
+			keys = append(keys, k)
+		}
+		counts[k]++
+		amounts[k] += asset.Money().Amount
+		totals[k.currency] += asset.Money().Amount
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.currency != b.currency:
+			return a.currency < b.currency
+		case amounts[a] != amounts[b]:
+			return amounts[a] > amounts[b]
+		default:
+			return a.assetType < b.assetType
+		}
+	})
+
+	view := AllocationView{
+		AsOf:        query.AsOf,
+		Allocations: make([]AllocationItemView, 0, len(keys)),
+	}
+
+	for _, k := range keys {
+		view.Allocations = append(view.Allocations, AllocationItemView{
+			Currency:  k.currency,
+			AssetType: k.assetType.String(),
+			Assets:    counts[k],
+			Amount:    roundMoney(amounts[k]),
+			Share:     ratio(amounts[k], totals[k.currency]),
+		})
+	}
+
+	return view, nil
+}
@@ -0,0 +1,150 @@
+package reportsqueries
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// GetCategoryBreakdownQuery returns the income and expenses of the tenant per period and category.
+// Without range, it covers the last twelve months, four quarters or five years.
+type GetCategoryBreakdownQuery struct {
+	Granularity reports.Granularity
+	From        time.Time
+	To          time.Time
+}
+
+func (q GetCategoryBreakdownQuery) QueryName() string {
+	return "GetCategoryBreakdownQuery"
+}
+
+// CategoryBreakdownView represents the category breakdown of a range of periods.
+type CategoryBreakdownView struct {
+	Granularity string
+
+	// From is the first day of the first period and To the last day of the last one.
+	From time.Time
+	To   time.Time
+
+	// Categories are sorted by period, currency and kind, income first,
+	// and then from the largest to the smallest amount.
+	Categories []PeriodCategoryView
+}
+
+// PeriodCategoryView represents the money earned or spent in a category during a period.
+type PeriodCategoryView struct {
+	Period   string
+	Start    time.Time
+	End      time.Time
+	Currency string
+	Category string
+	Kind     string
+	Amount   float64
+
+	// Share is the part of the income or expenses of the period in the category.
+	Share float64
+}
+
+type GetCategoryBreakdownQueryHandler struct {
+	flows flowReader
+}
+
+func NewGetCategoryBreakdownQueryHandler(
+	transactions transactions.Repository,
+	categories transactions.CategoryRepository,
+	assets assets.Repository,
+) *GetCategoryBreakdownQueryHandler {
+	return &GetCategoryBreakdownQueryHandler{
+		flows: flowReader{
+			transactions: transactions,
+			categories:   categories,
+			assets:       assets,
+		},
+	}
+}
+
+func (h *GetCategoryBreakdownQueryHandler) Handle(ctx context.Context, query GetCategoryBreakdownQuery) (interface{}, error) {
+	periods, err := reportPeriods(query.Granularity, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := periods[0], periods[len(periods)-1]
+	flows, err := h.flows.read(ctx, first.Start, last.End)
+	if err != nil {
+		return nil, err
+	}
+
+	type total struct {
+		period   int
+		currency string
+		kind     transactions.CategoryKind
+	}
+
+	type key struct {
+		total
+		category string
+	}
+
+	var (
+		keys    []key
+		amounts = make(map[key]float64)
+		totals  = make(map[total]float64)
+	)
+
+	for _, f := range flows {
+		k := key{
+			total:    total{period: indexOf(periods, f.date), currency: f.currency, kind: f.kind},
+			category: f.category,
+		}
+
+		if _, ok := amounts[k]; !ok {
+			keys = append(keys, k)
+		}
+		amounts[k] += f.amount
+		totals[k.total] += f.amount
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.period != b.period:
+			return a.period < b.period
+		case a.currency != b.currency:
+			return a.currency < b.currency
+		case a.kind != b.kind:
+			return a.kind == transactions.CategoryKindIncome
+		case amounts[a] != amounts[b]:
+			return amounts[a] > amounts[b]
+		default:
+			return a.category < b.category
+		}
+	})
+
+	view := CategoryBreakdownView{
+		Granularity: query.Granularity.String(),
+		From:        first.Start,
+		To:          last.LastDay(),
+		Categories:  make([]PeriodCategoryView, 0, len(keys)),
+	}
+
+	for _, k := range keys {
+		period := periods[k.period]
+		view.Categories = append(view.Categories, PeriodCategoryView{
+			Period:   period.Label(),
+			Start:    period.Start,
+			End:      period.LastDay(),
+			Currency: k.currency,
+			Category: k.category,
+			Kind:     string(k.kind),
+			Amount:   roundMoney(amounts[k]),
+			Share:    ratio(amounts[k], totals[k.total]),
+		})
+	}
+
+	return view, nil
+}
@@ -0,0 +1,132 @@
+package reportsqueries
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// GetIncomeStatementQuery returns the income, expenses and savings of the tenant per period.
+// Without range, it covers the last twelve months, four quarters or five years.
+type GetIncomeStatementQuery struct {
+	Granularity reports.Granularity
+	From        time.Time
+	To          time.Time
+}
+
+func (q GetIncomeStatementQuery) QueryName() string {
+	return "GetIncomeStatementQuery"
+}
+
+// IncomeStatementView represents the income statement of a range of periods.
+type IncomeStatementView struct {
+	Granularity string
+
+	// From is the first day of the first period and To the last day of the last one.
+	From time.Time
+	To   time.Time
+
+	// Periods are sorted by period and currency, with one row per currency used in the range.
+	Periods []PeriodSummaryView
+}
+
+// PeriodSummaryView represents the income and expenses of a period in a currency.
+type PeriodSummaryView struct {
+	Period   string
+	Start    time.Time
+	End      time.Time
+	Currency string
+	Income   float64
+	Expenses float64
+
+	// Net is the money saved, the income minus the expenses.
+	Net float64
+
+	// SavingsRate is the part of the income that was saved, zero without income.
+	SavingsRate float64
+}
+
+type GetIncomeStatementQueryHandler struct {
+	flows flowReader
+}
+
+func NewGetIncomeStatementQueryHandler(
+	transactions transactions.Repository,
+	categories transactions.CategoryRepository,
+	assets assets.Repository,
+) *GetIncomeStatementQueryHandler {
+	return &GetIncomeStatementQueryHandler{
+		flows: flowReader{
+			transactions: transactions,
+			categories:   categories,
+			assets:       assets,
+		},
+	}
+}
+
+func (h *GetIncomeStatementQueryHandler) Handle(ctx context.Context, query GetIncomeStatementQuery) (interface{}, error) {
+	periods, err := reportPeriods(query.Granularity, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := periods[0], periods[len(periods)-1]
+	flows, err := h.flows.read(ctx, first.Start, last.End)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		period   int
+		currency string
+	}
+
+	income := make(map[key]float64)
+	expenses := make(map[key]float64)
+	for _, f := range flows {
+		k := key{period: indexOf(periods, f.date), currency: f.currency}
+		if f.kind == transactions.CategoryKindIncome {
+			income[k] += f.amount
+		} else {
+			expenses[k] += f.amount
+		}
+	}
+
+	view := IncomeStatementView{
+		Granularity: query.Granularity.String(),
+		From:        first.Start,
+		To:          last.LastDay(),
+	}
+
+	currencies := currenciesOf(flows)
+	for i, period := range periods {
+		for _, currency := range currencies {
+			k := key{period: i, currency: currency}
+			net := income[k] - expenses[k]
+
+			view.Periods = append(view.Periods, PeriodSummaryView{
+				Period:      period.Label(),
+				Start:       period.Start,
+				End:         period.LastDay(),
+				Currency:    currency,
+				Income:      roundMoney(income[k]),
+				Expenses:    roundMoney(expenses[k]),
+				Net:         roundMoney(net),
+				SavingsRate: ratio(net, income[k]),
+			})
+		}
+	}
+
+	return view, nil
+}
+
+// indexOf returns the index of the period the time belongs to.
+func indexOf(periods []reports.Period, t time.Time) int {
+	return sort.Search(len(periods), func(i int) bool {
+		return t.Before(periods[i].End)
+	})
+}
@@ -0,0 +1,208 @@
+package reportsqueries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+
+	. "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+)
+
+// assetRepository is an assets.Repository that only serves a fixed set of assets.
+type assetRepository struct {
+	assets.Repository
+
+	assets []*assets.Asset
+}
+
+func (r *assetRepository) GetAll(_ context.Context) ([]*assets.Asset, error) {
+	return r.assets, nil
+}
+
+// transactionRepository is a transactions.Repository that only serves a fixed set of transactions.
+type transactionRepository struct {
+	transactions.Repository
+
+	transactions []*transactions.Transaction
+}
+
+func (r *transactionRepository) GetAll(_ context.Context) ([]*transactions.Transaction, error) {
+	return r.transactions, nil
+}
+
+func (r *transactionRepository) GetByBookingDate(_ context.Context, from, to time.Time) ([]*transactions.Transaction, error) {
+	var booked []*transactions.Transaction
+	for _, transaction := range r.transactions {
+		date := transaction.Details().BookingDate
+		if !date.Before(from) && (to.IsZero() || !date.After(to)) {
+			booked = append(booked, transaction)
+		}
+	}
+	return booked, nil
+}
+
+// categoryRepository is a transactions.CategoryRepository that only serves a fixed set of categories.
+type categoryRepository struct {
+	transactions.CategoryRepository
+
+	categories []*transactions.Category
+}
+
+func (r *categoryRepository) GetAll(_ context.Context) ([]*transactions.Category, error) {
+	return r.categories, nil
+}
+
+func newFixtures(t *testing.T) (*transactionRepository, *categoryRepository, *assetRepository) {
+	const tenantID = "household"
+
+	newAsset := func(name string, assetType assets.AssetType, amount float64) *assets.Asset {
+		money, err := assets.NewMoney(amount, "EUR")
+		require.NoError(t, err)
+		asset, err := assets.NewAsset(uuid.New(), tenantID, "", name, assetType, money)
+		require.NoError(t, err)
+		return asset
+	}
+
+	checking := newAsset("Checking", assets.AssetTypeBank, 3000)
+	savings := newAsset("Savings", assets.AssetTypeBank, 5000)
+	brokerage := newAsset("Brokerage", assets.AssetTypeInvestment, 2000)
+	visa := newAsset("Visa", assets.AssetTypeCreditCard, 300)
+
+	newTransaction := func(day time.Time, amount float64, category, transfer string, splits ...transactions.Split) *transactions.Transaction {
+		transaction, err := transactions.NewTransaction(uuid.New(), tenantID, checking.ID(), transactions.Details{
+			BookingDate: day,
+			Amount:      amount,
+			Currency:    "EUR",
+			Category:    category,
+			Transfer:    transfer,
+			Splits:      splits,
+		}, uuid.NewString())
+		require.NoError(t, err)
+		return transaction
+	}
+
+	salary, err := transactions.NewCategory(tenantID, "Salary", transactions.CategoryKindIncome, "")
+	require.NoError(t, err)
+	groceries, err := transactions.NewCategory(tenantID, "Food:Groceries", transactions.CategoryKindExpense, "")
+	require.NoError(t, err)
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+
+	return &transactionRepository{
+			transactions: []*transactions.Transaction{
+				newTransaction(day(1, 1), 2000, "Salary", ""),
+				newTransaction(day(1, 5), -300, "Food:Groceries", ""),
+				newTransaction(day(1, 10), -500, "", "Savings"),
+				newTransaction(day(1, 20), -200, "", "",
+					transactions.Split{Category: "Food:Groceries", Amount: -150},
+					transactions.Split{Category: "Rent", Amount: -50},
+				),
+				// a refund lowers the expenses of the category
+				newTransaction(day(2, 3), 100, "Food:Groceries", ""),
+				newTransaction(day(2, 28), 2000, "salary", ""),
+				// the transactions without category are income or expenses by their own sign
+				newTransaction(day(4, 1), 50, "", ""),
+				newTransaction(day(5, 2), -20, "", ""),
+				newTransaction(day(5, 9), -60, "", ""),
+			},
+		},
+		&categoryRepository{categories: []*transactions.Category{salary, groceries}},
+		&assetRepository{assets: []*assets.Asset{checking, savings, brokerage, visa}}
+}
+
+func TestGetIncomeStatementQueryHandler_Handle(t *testing.T) {
+	sut := NewGetIncomeStatementQueryHandler(newFixtures(t))
+
+	res, err := sut.Handle(context.Background(), GetIncomeStatementQuery{
+		Granularity: reports.GranularityMonthly,
+		From:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	view := res.(IncomeStatementView)
+	assert.Equal(t, "monthly", view.Granularity)
+	assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), view.To)
+	assert.Equal(t, []PeriodSummaryView{
+		{
+			Period:      "2024-01",
+			Start:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:         time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			Currency:    "EUR",
+			Income:      2000,
+			Expenses:    500,
+			Net:         1500,
+			SavingsRate: 0.75,
+		},
+		{
+			Period:      "2024-02",
+			Start:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			End:         time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			Currency:    "EUR",
+			Income:      2000,
+			Expenses:    -100,
+			Net:         2100,
+			SavingsRate: 1.05,
+		},
+		{
+			Period:   "2024-03",
+			Start:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			Currency: "EUR",
+		},
+	}, view.Periods)
+
+	_, err = sut.Handle(context.Background(), GetIncomeStatementQuery{Granularity: "weekly"})
+	assert.ErrorIs(t, err, reports.ErrInvalidGranularity)
+}
+
+func TestGetCategoryBreakdownQueryHandler_Handle(t *testing.T) {
+	sut := NewGetCategoryBreakdownQueryHandler(newFixtures(t))
+
+	res, err := sut.Handle(context.Background(), GetCategoryBreakdownQuery{
+		Granularity: reports.GranularityQuarterly,
+		From:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	view := res.(CategoryBreakdownView)
+	type row struct {
+		Period, Category, Kind string
+		Amount, Share          float64
+	}
+
+	rows := make([]row, 0, len(view.Categories))
+	for _, c := range view.Categories {
+		rows = append(rows, row{c.Period, c.Category, c.Kind, c.Amount, c.Share})
+	}
+
+	assert.Equal(t, []row{
+		{"2024-Q1", "Salary", "income", 4000, 1},
+		{"2024-Q1", "Food:Groceries", "expense", 350, 0.875},
+		{"2024-Q1", "Rent", "expense", 50, 0.125},
+		{"2024-Q2", Uncategorized, "income", 50, 1},
+		{"2024-Q2", Uncategorized, "expense", 80, 1},
+	}, rows)
+}
+
+func TestGetAllocationQueryHandler_Handle(t *testing.T) {
+	_, _, assetsRepository := newFixtures(t)
+	sut := NewGetAllocationQueryHandler(assetsRepository)
+
+	res, err := sut.Handle(context.Background(), GetAllocationQuery{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []AllocationItemView{
+		{Currency: "EUR", AssetType: "bank", Assets: 2, Amount: 8000, Share: 0.8},
+		{Currency: "EUR", AssetType: "investment", Assets: 1, Amount: 2000, Share: 0.2},
+	}, res.(AllocationView).Allocations)
+}
@@ -0,0 +1,249 @@
+package render
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// chartWidth is the width of the charts, in points, the width of an A4 page without margins.
+const chartWidth = 515
+
+type chartKind int
+
+const (
+	// columnChart draws a group of vertical bars per label, one per series.
+	columnChart chartKind = iota
+
+	// barChart draws a horizontal bar per label along with its value and share of the total.
+	barChart
+)
+
+// chart is a chart of a report, laid out as shapes the renderers draw.
+type chart struct {
+	Title  string
+	Kind   chartKind
+	Labels []string
+	Series []series
+}
+
+// series is a named set of values of a chart, one per label.
+type series struct {
+	Name   string
+	Color  string
+	Values []float64
+}
+
+type textAnchor int
+
+const (
+	anchorStart textAnchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// rect is a filled rectangle.
+type rect struct {
+	x, y, w, h float64
+	fill       string
+}
+
+// line is a hairline between two points.
+type line struct {
+	x1, y1, x2, y2 float64
+	stroke         string
+}
+
+// label is a line of text, y is its baseline.
+type label struct {
+	x, y   float64
+	text   string
+	size   float64
+	fill   string
+	anchor textAnchor
+}
+
+const (
+	colorAxis = "#9e9e9e"
+	colorGrid = "#e0e0e0"
+	colorText = "#424242"
+)
+
+// layout returns the height of the chart and the shapes it is drawn with,
+// in points from the top left corner.
+func (c chart) layout() (float64, []any) {
+	if c.Kind == barChart {
+		return c.layoutBars()
+	}
+	return c.layoutColumns()
+}
+
+func (c chart) layoutColumns() (float64, []any) {
+	const (
+		height       = 220.0
+		left         = 64.0
+		right        = 8.0
+		top          = 24.0
+		bottom       = 22.0
+		minLabelSize = 44.0
+	)
+
+	var shapes []any
+
+	// legend
+	x := left
+	for _, s := range c.Series {
+		shapes = append(shapes,
+			rect{x: x, y: 4, w: 10, h: 10, fill: s.Color},
+			label{x: x + 14, y: 13, text: s.Name, size: 9, fill: colorText},
+		)
+		x += 24 + textWidth(s.Name, 9)
+	}
+
+	lo, hi := 0.0, 0.0
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	step := niceStep(hi - lo)
+	lo, hi = math.Floor(lo/step)*step, math.Ceil(hi/step)*step
+	if hi == lo {
+		hi = lo + step
+	}
+
+	plotW, plotH := chartWidth-left-right, height-top-bottom
+	y := func(v float64) float64 { return top + (hi-v)/(hi-lo)*plotH }
+
+	for v := lo; v <= hi+step/2; v += step {
+		stroke := colorGrid
+		if math.Abs(v) < step/2 {
+			stroke = colorAxis
+		}
+		shapes = append(shapes,
+			line{x1: left, y1: y(v), x2: chartWidth - right, y2: y(v), stroke: stroke},
+			label{x: left - 6, y: y(v) + 3, text: formatTick(v, step), size: 8, fill: colorText, anchor: anchorEnd},
+		)
+	}
+
+	if len(c.Labels) == 0 || len(c.Series) == 0 {
+		return height, shapes
+	}
+
+	groupW := plotW / float64(len(c.Labels))
+	barW := groupW * 0.8 / float64(len(c.Series))
+	every := int(math.Ceil(minLabelSize / groupW))
+
+	for i, name := range c.Labels {
+		groupX := left + float64(i)*groupW + groupW*0.1
+		for j, s := range c.Series {
+			if i >= len(s.Values) {
+				continue
+			}
+			y0, y1 := y(math.Max(s.Values[i], 0)), y(math.Min(s.Values[i], 0))
+			shapes = append(shapes, rect{x: groupX + float64(j)*barW, y: y0, w: barW, h: y1 - y0, fill: s.Color})
+		}
+
+		if i%every == 0 {
+			shapes = append(shapes, label{
+				x: left + (float64(i)+0.5)*groupW, y: height - 8,
+				text: name, size: 8, fill: colorText, anchor: anchorMiddle,
+			})
+		}
+	}
+
+	return height, shapes
+}
+
+func (c chart) layoutBars() (float64, []any) {
+	const (
+		rowH   = 18.0
+		labelW = 150.0
+		valueW = 120.0
+	)
+
+	if len(c.Series) == 0 {
+		return 0, nil
+	}
+	s := c.Series[0]
+
+	var max, total float64
+	for _, v := range s.Values {
+		max = math.Max(max, v)
+		total += math.Max(v, 0)
+	}
+
+	barArea := chartWidth - labelW - valueW
+	shapes := make([]any, 0, 3*len(c.Labels))
+	for i, name := range c.Labels {
+		if i >= len(s.Values) {
+			break
+		}
+
+		v := s.Values[i]
+		top := float64(i) * rowH
+		shapes = append(shapes, label{
+			x: 0, y: top + 13, text: truncate(name, 9, labelW-8), size: 9, fill: colorText,
+		})
+
+		if max > 0 && v > 0 {
+			shapes = append(shapes, rect{x: labelW, y: top + 3, w: v / max * barArea, h: rowH - 6, fill: s.Color})
+		}
+
+		value := formatAmount(v)
+		if total > 0 {
+			value += "  " + formatPercent(v/total)
+		}
+		shapes = append(shapes, label{
+			x: chartWidth, y: top + 13, text: value, size: 9, fill: colorText, anchor: anchorEnd,
+		})
+	}
+
+	return float64(len(c.Labels)) * rowH, shapes
+}
+
+// niceStep returns a round step dividing the span in about four intervals.
+func niceStep(span float64) float64 {
+	if span <= 0 {
+		return 1
+	}
+
+	raw := span / 4
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*magnitude >= raw {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// formatTick formats the value of a gridline, with decimals only when the step needs them.
+func formatTick(v, step float64) string {
+	s := formatAmount(v)
+	if step == math.Trunc(step) {
+		return s[:len(s)-3]
+	}
+	return s
+}
+
+// textWidth estimates the width of a text in Helvetica, which the renderers use.
+func textWidth(s string, size float64) float64 {
+	return float64(utf8.RuneCountInString(s)) * size * 0.55
+}
+
+// truncate shortens the text with an ellipsis to fit in the given width.
+func truncate(s string, size, width float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	n := int(width/(size*0.55)) - 1
+	if n < 1 {
+		n = 1
+	}
+	if n > len(runes) {
+		n = len(runes)
+	}
+	return string(runes[:n]) + "…"
+}
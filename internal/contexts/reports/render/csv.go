@@ -0,0 +1,94 @@
+// Package render renders the reports in the formats they are downloaded as.
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+)
+
+// WriteIncomeStatementCSV writes the income statement as CSV, one row per period and currency.
+func WriteIncomeStatementCSV(w io.Writer, view reportsqueries.IncomeStatementView) error {
+	rows := [][]string{
+		{"period", "start", "end", "currency", "income", "expenses", "net", "savings_rate"},
+	}
+
+	for _, p := range view.Periods {
+		rows = append(rows, []string{
+			p.Period,
+			formatDate(p.Start),
+			formatDate(p.End),
+			p.Currency,
+			formatMoney(p.Income),
+			formatMoney(p.Expenses),
+			formatMoney(p.Net),
+			formatRatio(p.SavingsRate),
+		})
+	}
+
+	return writeCSV(w, rows)
+}
+
+// WriteCategoryBreakdownCSV writes the category breakdown as CSV, one row per period, currency and category.
+func WriteCategoryBreakdownCSV(w io.Writer, view reportsqueries.CategoryBreakdownView) error {
+	rows := [][]string{
+		{"period", "start", "end", "currency", "kind", "category", "amount", "share"},
+	}
+
+	for _, c := range view.Categories {
+		rows = append(rows, []string{
+			c.Period,
+			formatDate(c.Start),
+			formatDate(c.End),
+			c.Currency,
+			c.Kind,
+			c.Category,
+			formatMoney(c.Amount),
+			formatRatio(c.Share),
+		})
+	}
+
+	return writeCSV(w, rows)
+}
+
+// WriteAllocationCSV writes the asset allocation as CSV, one row per currency and asset type.
+func WriteAllocationCSV(w io.Writer, view reportsqueries.AllocationView) error {
+	rows := [][]string{
+		{"currency", "asset_type", "assets", "amount", "share"},
+	}
+
+	for _, a := range view.Allocations {
+		rows = append(rows, []string{
+			a.Currency,
+			a.AssetType,
+			strconv.Itoa(a.Assets),
+			formatMoney(a.Amount),
+			formatRatio(a.Share),
+		})
+	}
+
+	return writeCSV(w, rows)
+}
+
+func writeCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatRatio(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
@@ -0,0 +1,335 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+)
+
+// Colors of the charts.
+const (
+	colorIncome   = "#2e7d32"
+	colorExpenses = "#c62828"
+	colorShare    = "#1565c0"
+)
+
+// document is a report laid out as titled sections of charts and tables,
+// shared by the HTML and PDF renderers. The fields are exported for the HTML template.
+type document struct {
+	Title       string
+	Subtitle    string
+	GeneratedAt time.Time
+
+	// Empty is the message shown instead of the sections when there are none.
+	Empty    string
+	Sections []section
+}
+
+// section groups the charts and the table of a currency.
+type section struct {
+	Title  string
+	Charts []chart
+	Table  table
+}
+
+// table is a grid of formatted cells with an optional footer of totals.
+type table struct {
+	Header []cell
+	Rows   [][]cell
+	Footer []cell
+}
+
+// cell is a formatted value of a table, numbers are right aligned.
+type cell struct {
+	Text    string
+	Numeric bool
+}
+
+func text(s string) cell {
+	return cell{Text: s}
+}
+
+func number(s string) cell {
+	return cell{Text: s, Numeric: true}
+}
+
+// incomeStatementDocument lays out the income statement, one section per currency.
+func incomeStatementDocument(view reportsqueries.IncomeStatementView) document {
+	doc := document{
+		Title:       "Income statement",
+		Subtitle:    rangeSubtitle(view.Granularity, view.From, view.To),
+		GeneratedAt: time.Now().UTC(),
+		Empty:       "There are no income or expenses in the period.",
+	}
+
+	byCurrency := make(map[string][]reportsqueries.PeriodSummaryView)
+	for _, p := range view.Periods {
+		byCurrency[p.Currency] = append(byCurrency[p.Currency], p)
+	}
+
+	for _, currency := range sortedKeys(byCurrency) {
+		periods := byCurrency[currency]
+
+		income := series{Name: "Income", Color: colorIncome}
+		expenses := series{Name: "Expenses", Color: colorExpenses}
+		labels := make([]string, 0, len(periods))
+
+		t := table{Header: []cell{
+			text("Period"), number("Income"), number("Expenses"), number("Net"), number("Savings rate"),
+		}}
+
+		var totalIncome, totalExpenses float64
+		for _, p := range periods {
+			labels = append(labels, p.Period)
+			income.Values = append(income.Values, p.Income)
+			expenses.Values = append(expenses.Values, p.Expenses)
+			totalIncome += p.Income
+			totalExpenses += p.Expenses
+
+			t.Rows = append(t.Rows, []cell{
+				text(p.Period),
+				number(formatAmount(p.Income)),
+				number(formatAmount(p.Expenses)),
+				number(formatAmount(p.Net)),
+				number(formatPercent(p.SavingsRate)),
+			})
+		}
+
+		net := totalIncome - totalExpenses
+		rate := 0.0
+		if totalIncome > 0 {
+			rate = net / totalIncome
+		}
+		t.Footer = []cell{
+			text("Total"),
+			number(formatAmount(totalIncome)),
+			number(formatAmount(totalExpenses)),
+			number(formatAmount(net)),
+			number(formatPercent(rate)),
+		}
+
+		doc.Sections = append(doc.Sections, section{
+			Title: currency,
+			Charts: []chart{{
+				Title:  "Income and expenses",
+				Kind:   columnChart,
+				Labels: labels,
+				Series: []series{income, expenses},
+			}},
+			Table: t,
+		})
+	}
+
+	return doc
+}
+
+// categoryBreakdownDocument lays out the category breakdown, one section per currency
+// with the income and expenses of each category over the whole range.
+func categoryBreakdownDocument(view reportsqueries.CategoryBreakdownView) document {
+	doc := document{
+		Title:       "Category breakdown",
+		Subtitle:    rangeSubtitle(view.Granularity, view.From, view.To),
+		GeneratedAt: time.Now().UTC(),
+		Empty:       "There are no income or expenses in the period.",
+	}
+
+	byCurrency := make(map[string][]reportsqueries.PeriodCategoryView)
+	for _, c := range view.Categories {
+		byCurrency[c.Currency] = append(byCurrency[c.Currency], c)
+	}
+
+	for _, currency := range sortedKeys(byCurrency) {
+		categories := byCurrency[currency]
+
+		t := table{Header: []cell{
+			text("Period"), text("Kind"), text("Category"), number("Amount"), number("Share"),
+		}}
+
+		totals := map[string]map[string]float64{}
+		for _, c := range categories {
+			if totals[c.Kind] == nil {
+				totals[c.Kind] = make(map[string]float64)
+			}
+			totals[c.Kind][c.Category] += c.Amount
+
+			t.Rows = append(t.Rows, []cell{
+				text(c.Period),
+				text(c.Kind),
+				text(c.Category),
+				number(formatAmount(c.Amount)),
+				number(formatPercent(c.Share)),
+			})
+		}
+
+		s := section{Title: currency, Table: t}
+		for _, kind := range []struct{ name, title, color string }{
+			{"income", "Income by category", colorIncome},
+			{"expense", "Expenses by category", colorExpenses},
+		} {
+			if len(totals[kind.name]) == 0 {
+				continue
+			}
+			s.Charts = append(s.Charts, shareChart(kind.title, kind.color, totals[kind.name]))
+		}
+
+		doc.Sections = append(doc.Sections, s)
+	}
+
+	return doc
+}
+
+// allocationDocument lays out the asset allocation, one section per currency.
+func allocationDocument(view reportsqueries.AllocationView) document {
+	subtitle := "Current balances"
+	if !view.AsOf.IsZero() {
+		subtitle = "Balances as of " + view.AsOf.UTC().Format("2006-01-02 15:04 MST")
+	}
+
+	doc := document{
+		Title:       "Asset allocation",
+		Subtitle:    subtitle,
+		GeneratedAt: time.Now().UTC(),
+		Empty:       "There are no assets.",
+	}
+
+	byCurrency := make(map[string][]reportsqueries.AllocationItemView)
+	for _, a := range view.Allocations {
+		byCurrency[a.Currency] = append(byCurrency[a.Currency], a)
+	}
+
+	for _, currency := range sortedKeys(byCurrency) {
+		allocations := byCurrency[currency]
+
+		c := chart{Title: "Allocation by asset type", Kind: barChart}
+		shares := series{Color: colorShare}
+		t := table{Header: []cell{
+			text("Asset type"), number("Assets"), number("Amount"), number("Share"),
+		}}
+
+		var (
+			assets int
+			total  float64
+		)
+		for _, a := range allocations {
+			c.Labels = append(c.Labels, assetTypeLabel(a.AssetType))
+			shares.Values = append(shares.Values, a.Amount)
+			assets += a.Assets
+			total += a.Amount
+
+			t.Rows = append(t.Rows, []cell{
+				text(assetTypeLabel(a.AssetType)),
+				number(strconv.Itoa(a.Assets)),
+				number(formatAmount(a.Amount)),
+				number(formatPercent(a.Share)),
+			})
+		}
+		c.Series = []series{shares}
+
+		share := 0.0
+		if total > 0 {
+			share = 1
+		}
+		t.Footer = []cell{
+			text("Total"),
+			number(strconv.Itoa(assets)),
+			number(formatAmount(total)),
+			number(formatPercent(share)),
+		}
+
+		doc.Sections = append(doc.Sections, section{
+			Title:  currency,
+			Charts: []chart{c},
+			Table:  t,
+		})
+	}
+
+	return doc
+}
+
+// maxBars is the number of bars of the share charts, the smallest amounts are summed up as others.
+const maxBars = 12
+
+// shareChart returns a bar chart of the given amounts, from the largest to the smallest.
+func shareChart(title, color string, amounts map[string]float64) chart {
+	labels := sortedKeys(amounts)
+	sort.SliceStable(labels, func(i, j int) bool {
+		return amounts[labels[i]] > amounts[labels[j]]
+	})
+
+	values := make([]float64, 0, len(labels))
+	for _, label := range labels {
+		values = append(values, amounts[label])
+	}
+
+	if len(labels) > maxBars {
+		var others float64
+		for _, v := range values[maxBars-1:] {
+			others += v
+		}
+		labels = append(labels[:maxBars-1], "Others")
+		values = append(values[:maxBars-1], others)
+	}
+
+	return chart{
+		Title:  title,
+		Kind:   barChart,
+		Labels: labels,
+		Series: []series{{Color: color, Values: values}},
+	}
+}
+
+// rangeSubtitle describes the periods of a report, e.g. "Monthly, from 2024-01-01 to 2024-12-31".
+func rangeSubtitle(granularity string, from, to time.Time) string {
+	if granularity != "" {
+		granularity = strings.ToUpper(granularity[:1]) + granularity[1:]
+	}
+	return fmt.Sprintf("%s, from %s to %s", granularity, formatDate(from), formatDate(to))
+}
+
+// assetTypeLabel returns the human readable name of an asset type, e.g. "Credit card".
+func assetTypeLabel(assetType string) string {
+	label := strings.ReplaceAll(assetType, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatAmount formats an amount of money with two decimals and thousands separators.
+func formatAmount(v float64) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', 2, 64)
+	integer, decimals := s[:len(s)-3], s[len(s)-3:]
+
+	var b strings.Builder
+	if v < 0 && s != "0.00" {
+		b.WriteByte('-')
+	}
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString(decimals)
+
+	return b.String()
+}
+
+// formatPercent formats a ratio as a percentage with two decimals.
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v*100, 'f', 2, 64) + "%"
+}
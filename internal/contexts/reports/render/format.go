@@ -0,0 +1,92 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+)
+
+// ErrUnsupportedFormat represents the error when the report format is not supported.
+var ErrUnsupportedFormat = errors.New("unsupported report format")
+
+// Format represents a format the reports are downloaded as.
+type Format string
+
+const (
+	// FormatCSV renders the rows of the report as comma separated values.
+	FormatCSV Format = "csv"
+
+	// FormatHTML renders the report as a self-contained HTML page with SVG charts.
+	FormatHTML Format = "html"
+
+	// FormatPDF renders the report as a printable A4 PDF document.
+	FormatPDF Format = "pdf"
+)
+
+// Formats are the supported report formats.
+var Formats = []Format{FormatCSV, FormatHTML, FormatPDF}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+}
+
+// Extension returns the file extension of the format.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// WriteIncomeStatement renders the income statement in the given format.
+func WriteIncomeStatement(w io.Writer, format Format, view reportsqueries.IncomeStatementView) error {
+	if format == FormatCSV {
+		return WriteIncomeStatementCSV(w, view)
+	}
+	return writeDocument(w, format, incomeStatementDocument(view))
+}
+
+// WriteCategoryBreakdown renders the category breakdown in the given format.
+func WriteCategoryBreakdown(w io.Writer, format Format, view reportsqueries.CategoryBreakdownView) error {
+	if format == FormatCSV {
+		return WriteCategoryBreakdownCSV(w, view)
+	}
+	return writeDocument(w, format, categoryBreakdownDocument(view))
+}
+
+// WriteAllocation renders the asset allocation in the given format.
+func WriteAllocation(w io.Writer, format Format, view reportsqueries.AllocationView) error {
+	if format == FormatCSV {
+		return WriteAllocationCSV(w, view)
+	}
+	return writeDocument(w, format, allocationDocument(view))
+}
+
+func writeDocument(w io.Writer, format Format, doc document) error {
+	switch format {
+	case FormatHTML:
+		return writeHTML(w, doc)
+	case FormatPDF:
+		return writePDF(w, doc)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
@@ -0,0 +1,19 @@
+package render
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed templates/report.html
+var reportTemplate string
+
+// htmlTemplate renders a document as a self-contained page: the styles are inlined
+// and the charts embedded as SVG, so it can be opened offline or sent by email.
+var htmlTemplate = template.Must(template.New("report").Parse(reportTemplate))
+
+// writeHTML renders the document as an HTML page.
+func writeHTML(w io.Writer, doc document) error {
+	return htmlTemplate.Execute(w, doc)
+}
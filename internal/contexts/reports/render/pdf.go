@@ -0,0 +1,229 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Layout of the PDF documents, in points.
+const (
+	pdfMargin  = 40.0
+	pdfRowH    = 16.0
+	pdfNumberW = 80.0
+	pdfFont    = "Helvetica"
+)
+
+// writePDF renders the document as an A4 PDF. The core Helvetica font is used,
+// so the texts are limited to the Windows-1252 characters.
+func writePDF(w io.Writer, doc document) error {
+	pdf := fpdf.New("P", "pt", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetTitle(doc.Title, true)
+	pdf.SetCreator("FinanTrack", true)
+	pdf.SetCreationDate(doc.GeneratedAt)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.AliasNbPages("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 8)
+		pdf.SetFont(pdfFont, "", 8)
+		setColor(pdf.SetTextColor, colorAxis)
+		pdf.CellFormat(0, 10, "Generated by FinanTrack on "+doc.GeneratedAt.Format("2006-01-02 15:04 MST"), "", 0, "L", false, 0, "")
+		pdf.SetX(pdfMargin)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont(pdfFont, "B", 20)
+	setColor(pdf.SetTextColor, "#212121")
+	pdf.CellFormat(0, 26, tr(doc.Title), "", 1, "L", false, 0, "")
+	pdf.SetFont(pdfFont, "", 11)
+	setColor(pdf.SetTextColor, "#616161")
+	pdf.CellFormat(0, 16, tr(doc.Subtitle), "", 1, "L", false, 0, "")
+	setColor(pdf.SetDrawColor, "#212121")
+	pdf.SetLineWidth(1.5)
+	pdf.Line(pdfMargin, pdf.GetY()+4, pdfMargin+chartWidth, pdf.GetY()+4)
+	pdf.Ln(16)
+
+	if len(doc.Sections) == 0 {
+		pdf.SetFont(pdfFont, "", 11)
+		pdf.CellFormat(0, 16, tr(doc.Empty), "", 1, "L", false, 0, "")
+	}
+
+	for _, s := range doc.Sections {
+		ensureSpace(pdf, 60)
+		pdf.SetFont(pdfFont, "B", 14)
+		setColor(pdf.SetTextColor, "#212121")
+		pdf.CellFormat(0, 24, tr(s.Title), "", 1, "L", false, 0, "")
+
+		for _, c := range s.Charts {
+			drawPDFChart(pdf, tr, c)
+		}
+		drawPDFTable(pdf, tr, s.Table)
+		pdf.Ln(12)
+	}
+
+	return pdf.Output(w)
+}
+
+// drawPDFChart draws the chart along with its title, on a new page when it does not fit.
+func drawPDFChart(pdf *fpdf.Fpdf, tr func(string) string, c chart) {
+	height, shapes := c.layout()
+	ensureSpace(pdf, height+24)
+
+	pdf.SetFont(pdfFont, "B", 10)
+	setColor(pdf.SetTextColor, colorText)
+	pdf.CellFormat(0, 18, tr(c.Title), "", 1, "L", false, 0, "")
+
+	x0, y0 := pdfMargin, pdf.GetY()
+	pdf.SetLineWidth(0.5)
+	for _, shape := range shapes {
+		switch s := shape.(type) {
+		case rect:
+			if s.w <= 0 || s.h <= 0 {
+				continue
+			}
+			setColor(pdf.SetFillColor, s.fill)
+			pdf.Rect(x0+s.x, y0+s.y, s.w, s.h, "F")
+		case line:
+			setColor(pdf.SetDrawColor, s.stroke)
+			pdf.Line(x0+s.x1, y0+s.y1, x0+s.x2, y0+s.y2)
+		case label:
+			text := tr(s.text)
+			pdf.SetFont(pdfFont, "", s.size)
+			setColor(pdf.SetTextColor, s.fill)
+
+			x := x0 + s.x
+			switch s.anchor {
+			case anchorMiddle:
+				x -= pdf.GetStringWidth(text) / 2
+			case anchorEnd:
+				x -= pdf.GetStringWidth(text)
+			}
+			pdf.Text(x, y0+s.y, text)
+		}
+	}
+
+	pdf.SetY(y0 + height + 12)
+}
+
+// drawPDFTable draws the table, repeating its header on every page it spans.
+func drawPDFTable(pdf *fpdf.Fpdf, tr func(string) string, t table) {
+	widths := columnWidths(t.Header)
+
+	header := func() {
+		pdf.SetFont(pdfFont, "B", 9)
+		setColor(pdf.SetTextColor, "#212121")
+		setColor(pdf.SetFillColor, "#f5f5f5")
+		setColor(pdf.SetDrawColor, colorAxis)
+		drawPDFRow(pdf, tr, widths, t.Header, true)
+	}
+
+	ensureSpace(pdf, 2*pdfRowH)
+	header()
+
+	pdf.SetFont(pdfFont, "", 9)
+	setColor(pdf.SetDrawColor, colorGrid)
+	for _, row := range t.Rows {
+		if !fits(pdf, pdfRowH) {
+			pdf.AddPage()
+			header()
+			pdf.SetFont(pdfFont, "", 9)
+			setColor(pdf.SetDrawColor, colorGrid)
+		}
+		drawPDFRow(pdf, tr, widths, row, false)
+	}
+
+	if len(t.Footer) > 0 {
+		ensureSpace(pdf, pdfRowH)
+		pdf.SetFont(pdfFont, "B", 9)
+		setColor(pdf.SetDrawColor, colorAxis)
+		drawPDFRow(pdf, tr, widths, t.Footer, false)
+	}
+}
+
+func drawPDFRow(pdf *fpdf.Fpdf, tr func(string) string, widths []float64, cells []cell, fill bool) {
+	for i, c := range cells {
+		if i >= len(widths) {
+			break
+		}
+
+		align := "L"
+		if c.Numeric {
+			align = "R"
+		}
+
+		pdf.CellFormat(widths[i], pdfRowH, fitText(pdf, tr, c.Text, widths[i]-6), "B", 0, align, fill, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// fitText translates the text, shortened with an ellipsis to fit in the given width.
+func fitText(pdf *fpdf.Fpdf, tr func(string) string, s string, width float64) string {
+	if pdf.GetStringWidth(tr(s)) <= width {
+		return tr(s)
+	}
+
+	runes := []rune(s)
+	for len(runes) > 1 {
+		runes = runes[:len(runes)-1]
+		if text := tr(string(runes) + "…"); pdf.GetStringWidth(text) <= width {
+			return text
+		}
+	}
+	return tr(string(runes))
+}
+
+// columnWidths gives the numeric columns a fixed width and shares the rest of the page
+// among the text columns.
+func columnWidths(header []cell) []float64 {
+	var texts int
+	for _, c := range header {
+		if !c.Numeric {
+			texts++
+		}
+	}
+
+	textW := 0.0
+	if texts > 0 {
+		textW = (chartWidth - pdfNumberW*float64(len(header)-texts)) / float64(texts)
+	}
+
+	widths := make([]float64, len(header))
+	for i, c := range header {
+		widths[i] = textW
+		if c.Numeric {
+			widths[i] = pdfNumberW
+		}
+	}
+	return widths
+}
+
+// fits reports whether there is room for the given height on the current page.
+func fits(pdf *fpdf.Fpdf, height float64) bool {
+	_, pageH := pdf.GetPageSize()
+	return pdf.GetY()+height <= pageH-pdfMargin
+}
+
+// ensureSpace starts a new page when there is no room for the given height on the current one.
+func ensureSpace(pdf *fpdf.Fpdf, height float64) {
+	if !fits(pdf, height) {
+		pdf.AddPage()
+	}
+}
+
+// setColor calls the color setter with the components of a #rrggbb color.
+func setColor(set func(r, g, b int), hex string) {
+	v, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil || len(hex) != 7 {
+		set(0, 0, 0)
+		return
+	}
+	set(int(v>>16&0xff), int(v>>8&0xff), int(v&0xff))
+}
@@ -0,0 +1,157 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+
+	. "github.com/xfrr/finantrack/internal/contexts/reports/render"
+)
+
+func month(m time.Month) (time.Time, time.Time) {
+	start := time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, -1)
+}
+
+var incomeStatement = func() reportsqueries.IncomeStatementView {
+	jan, janEnd := month(time.January)
+	feb, febEnd := month(time.February)
+
+	return reportsqueries.IncomeStatementView{
+		Granularity: "monthly",
+		From:        jan,
+		To:          febEnd,
+		Periods: []reportsqueries.PeriodSummaryView{
+			{Period: "2024-01", Start: jan, End: janEnd, Currency: "EUR", Income: 2000, Expenses: 500, Net: 1500, SavingsRate: 0.75},
+			{Period: "2024-01", Start: jan, End: janEnd, Currency: "USD", Income: 100, Net: 100, SavingsRate: 1},
+			{Period: "2024-02", Start: feb, End: febEnd, Currency: "EUR", Income: 1234567.891, Expenses: -100, Net: 1234667.89, SavingsRate: 1.0001},
+			{Period: "2024-02", Start: feb, End: febEnd, Currency: "USD"},
+		},
+	}
+}()
+
+var categoryBreakdown = func() reportsqueries.CategoryBreakdownView {
+	jan, janEnd := month(time.January)
+
+	return reportsqueries.CategoryBreakdownView{
+		Granularity: "monthly",
+		From:        jan,
+		To:          janEnd,
+		Categories: []reportsqueries.PeriodCategoryView{
+			{Period: "2024-01", Start: jan, End: janEnd, Currency: "EUR", Category: "Salary", Kind: "income", Amount: 2000, Share: 1},
+			{Period: "2024-01", Start: jan, End: janEnd, Currency: "EUR", Category: `<script>alert("Café")</script>`, Kind: "expense", Amount: 300, Share: 0.6},
+			{Period: "2024-01", Start: jan, End: janEnd, Currency: "EUR", Category: "Rent", Kind: "expense", Amount: 200, Share: 0.4},
+		},
+	}
+}()
+
+var allocation = reportsqueries.AllocationView{
+	AsOf: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+	Allocations: []reportsqueries.AllocationItemView{
+		{Currency: "EUR", AssetType: "bank", Assets: 2, Amount: 8000, Share: 0.8},
+		{Currency: "EUR", AssetType: "investment", Assets: 1, Amount: 2000, Share: 0.2},
+	},
+}
+
+func TestWriteIncomeStatement_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteIncomeStatement(&buf, FormatHTML, incomeStatement))
+
+	html := buf.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<title>Income statement</title>")
+	assert.Contains(t, html, "Monthly, from 2024-01-01 to 2024-02-29")
+	assert.Equal(t, 2, strings.Count(html, "<section>"), "one section per currency")
+	assert.Equal(t, 2, strings.Count(html, "<svg "), "one chart per currency")
+	assert.Contains(t, html, `<td class="num">1,234,567.89</td>`)
+	assert.Contains(t, html, `<td class="num">-100.00</td>`)
+	assert.Contains(t, html, `<td class="num">100.01%</td>`)
+	assert.Contains(t, html, `<td class="num">1,236,567.89</td>`, "total income")
+
+	// self-contained: no external resources are loaded
+	assert.NotContains(t, html, "<link")
+	assert.NotContains(t, html, "src=")
+}
+
+func TestWriteCategoryBreakdown_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCategoryBreakdown(&buf, FormatHTML, categoryBreakdown))
+
+	html := buf.String()
+	assert.Contains(t, html, "Income by category")
+	assert.Contains(t, html, "Expenses by category")
+	assert.NotContains(t, html, "<script>")
+	assert.Contains(t, html, "&lt;script&gt;")
+}
+
+func TestWriteAllocation_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteAllocation(&buf, FormatHTML, reportsqueries.AllocationView{}))
+	assert.Contains(t, buf.String(), "There are no assets.")
+	assert.NotContains(t, buf.String(), "<svg ")
+
+	buf.Reset()
+	require.NoError(t, WriteAllocation(&buf, FormatHTML, allocation))
+	assert.Contains(t, buf.String(), "Balances as of 2024-12-31 23:59 UTC")
+	assert.Contains(t, buf.String(), "Investment")
+}
+
+func TestWrite_PDF(t *testing.T) {
+	var specs = []struct {
+		name  string
+		write func(*bytes.Buffer) error
+	}{
+		{
+			name:  "income statement",
+			write: func(buf *bytes.Buffer) error { return WriteIncomeStatement(buf, FormatPDF, incomeStatement) },
+		},
+		{
+			name:  "category breakdown",
+			write: func(buf *bytes.Buffer) error { return WriteCategoryBreakdown(buf, FormatPDF, categoryBreakdown) },
+		},
+		{
+			name:  "allocation",
+			write: func(buf *bytes.Buffer) error { return WriteAllocation(buf, FormatPDF, allocation) },
+		},
+		{
+			name: "empty allocation",
+			write: func(buf *bytes.Buffer) error {
+				return WriteAllocation(buf, FormatPDF, reportsqueries.AllocationView{})
+			},
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, spec.write(&buf))
+
+			pdf := buf.String()
+			assert.True(t, strings.HasPrefix(pdf, "%PDF-"))
+			assert.True(t, strings.HasSuffix(strings.TrimSpace(pdf), "%%EOF"))
+		})
+	}
+}
+
+func TestWriteIncomeStatement_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteIncomeStatement(&buf, FormatCSV, incomeStatement))
+	assert.True(t, strings.HasPrefix(buf.String(), "period,start,end,currency,income,expenses,net,savings_rate\n"))
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("PDF")
+	require.NoError(t, err)
+	assert.Equal(t, FormatPDF, format)
+	assert.Equal(t, ".pdf", format.Extension())
+	assert.Equal(t, "application/pdf", format.ContentType())
+
+	_, err = ParseFormat("xlsx")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
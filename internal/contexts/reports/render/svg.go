@@ -0,0 +1,52 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVG returns the chart as an inline SVG image scaled to the width of its container.
+func (c chart) SVG() template.HTML {
+	height, shapes := c.layout()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %s %s" width="100%%" role="img" aria-label="%s" font-family="Helvetica, Arial, sans-serif">`,
+		formatCoord(chartWidth), formatCoord(height), template.HTMLEscapeString(c.Title))
+
+	for _, shape := range shapes {
+		switch s := shape.(type) {
+		case rect:
+			fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`,
+				formatCoord(s.x), formatCoord(s.y), formatCoord(s.w), formatCoord(s.h), s.fill)
+		case line:
+			fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="0.5"/>`,
+				formatCoord(s.x1), formatCoord(s.y1), formatCoord(s.x2), formatCoord(s.y2), s.stroke)
+		case label:
+			fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="%s" fill="%s"%s>%s</text>`,
+				formatCoord(s.x), formatCoord(s.y), formatCoord(s.size), s.fill, svgAnchor(s.anchor), template.HTMLEscapeString(s.text))
+		}
+	}
+	b.WriteString("</svg>")
+
+	// the shapes are built from escaped texts and numbers only
+	return template.HTML(b.String())
+}
+
+func svgAnchor(anchor textAnchor) string {
+	switch anchor {
+	case anchorMiddle:
+		return ` text-anchor="middle"`
+	case anchorEnd:
+		return ` text-anchor="end"`
+	default:
+		return ""
+	}
+}
+
+// formatCoord formats a coordinate rounded to hundredths of a point.
+func formatCoord(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
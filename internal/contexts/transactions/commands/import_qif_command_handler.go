@@ -0,0 +1,307 @@
+package transactionscommands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+)
+
+// QIFSource is the source of the transactions imported from QIF files.
+const QIFSource = "qif"
+
+type ImportQIFCommand struct {
+	File statements.QIFFile
+
+	// Currency is the currency of the assets created for the unknown accounts.
+	Currency string
+
+	// DryRun previews the import without creating the assets, categories and transactions.
+	DryRun bool
+}
+
+func (c ImportQIFCommand) CommandName() string {
+	return "ImportQIFCommand"
+}
+
+// ImportQIFResult represents the outcome of a QIF import.
+type ImportQIFResult struct {
+	DryRun     bool
+	Accounts   []ImportedAccount
+	Categories []ImportedCategory
+}
+
+// ImportedAccount represents a QIF account and the asset its transactions were imported into.
+type ImportedAccount struct {
+	ImportResult
+
+	Name      string
+	AssetType string
+
+	// Created is set when no asset with the account name existed.
+	Created bool
+}
+
+// ImportedCategory represents a QIF category and whether it was created.
+type ImportedCategory struct {
+	CategoryID string
+	Name       string
+	Kind       string
+	Created    bool
+}
+
+type ImportQIFCommandHandler struct {
+	transactions transactions.Repository
+	categories   transactions.CategoryRepository
+	assets       assets.Repository
+}
+
+func NewImportQIFCommandHandler(
+	transactions transactions.Repository,
+	categories transactions.CategoryRepository,
+	assets assets.Repository,
+) *ImportQIFCommandHandler {
+	return &ImportQIFCommandHandler{
+		transactions: transactions,
+		categories:   categories,
+		assets:       assets,
+	}
+}
+
+func (h *ImportQIFCommandHandler) Handle(ctx context.Context, cmd ImportQIFCommand) (interface{}, error) {
+	// Assets and categories always belong to the household of the request
+	tenantID, ok := xtenant.FromContext(ctx)
+	if !ok {
+		return nil, xtenant.ErrTenantRequired
+	}
+
+	existing, err := h.assets.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, pendingCategories, err := h.prepareCategories(ctx, tenantID, cmd.File)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportQIFResult{
+		DryRun:     cmd.DryRun,
+		Accounts:   make([]ImportedAccount, 0, len(cmd.File.Accounts)),
+		Categories: categories,
+	}
+
+	var (
+		pendingAssets       []*assets.Asset
+		pendingTransactions []*transactions.Transaction
+	)
+
+	for _, account := range cmd.File.Accounts {
+		asset, created, err := h.asset(ctx, tenantID, existing, account, cmd.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", account.Name, err)
+		}
+
+		pending, imported, err := prepare(ctx, h.transactions, asset, QIFSource, account.Statement)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", account.Name, err)
+		}
+		imported.DryRun = cmd.DryRun
+
+		if created {
+			pendingAssets = append(pendingAssets, asset)
+		}
+		pendingTransactions = append(pendingTransactions, pending...)
+
+		result.Accounts = append(result.Accounts, ImportedAccount{
+			ImportResult: *imported,
+			Name:         account.Name,
+			AssetType:    asset.Type().String(),
+			Created:      created,
+		})
+	}
+
+	if cmd.DryRun {
+		return result, nil
+	}
+
+	for _, category := range pendingCategories {
+		if err = h.categories.Save(ctx, category); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, asset := range pendingAssets {
+		if err = h.assets.Save(ctx, asset); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, transaction := range pendingTransactions {
+		if err = h.transactions.Save(ctx, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	xlog.FromContext(ctx).Info().
+		Int("accounts", len(result.Accounts)).
+		Int("assets_created", len(pendingAssets)).
+		Int("categories_created", len(pendingCategories)).
+		Int("transactions_imported", len(pendingTransactions)).
+		Msg("qif file imported")
+
+	return result, nil
+}
+
+// asset returns the asset named after the account, creating it when missing.
+// Created assets hold the sum of the account transactions, which is rejected when
+// negative unless the asset type allows it, e.g. for overdrawn bank accounts.
+func (h *ImportQIFCommandHandler) asset(
+	ctx context.Context,
+	tenantID string,
+	existing []*assets.Asset,
+	account statements.QIFAccount,
+	currency string,
+) (*assets.Asset, bool, error) {
+	for _, asset := range existing {
+		if strings.EqualFold(asset.Name(), account.Name) {
+			return asset, false, nil
+		}
+	}
+
+	var balance float64
+	for _, entry := range account.Statement.Entries {
+		balance += entry.Amount
+	}
+
+	money := assets.Money{
+		Amount:   balance,
+		Currency: assets.Currency(strings.ToUpper(currency)),
+	}
+
+	// The owner is the authenticated user, if any
+	principal, _ := xauth.PrincipalFromContext(ctx)
+
+	asset, err := assets.NewAsset(uuid.New(), tenantID, principal.Subject, account.Name, qifAssetType(account.Type), money)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return asset, true, nil
+}
+
+// prepareCategories builds the categories declared or used by the QIF file that do not exist yet.
+// The kind of the undeclared categories is guessed from the sign of their first amount.
+func (h *ImportQIFCommandHandler) prepareCategories(
+	ctx context.Context,
+	tenantID string,
+	file statements.QIFFile,
+) ([]ImportedCategory, []*transactions.Category, error) {
+	var (
+		names []string
+		kinds = make(map[string]transactions.CategoryKind)
+	)
+
+	add := func(name string, kind transactions.CategoryKind) {
+		name = transactions.NormalizeCategoryName(name)
+		if name == "" {
+			return
+		}
+
+		key := strings.ToLower(name)
+		if _, ok := kinds[key]; ok {
+			return
+		}
+
+		names = append(names, name)
+		kinds[key] = kind
+	}
+
+	kindOf := func(amount float64) transactions.CategoryKind {
+		if amount > 0 {
+			return transactions.CategoryKindIncome
+		}
+		return transactions.CategoryKindExpense
+	}
+
+	for _, category := range file.Categories {
+		kind := transactions.CategoryKindExpense
+		if category.Income {
+			kind = transactions.CategoryKindIncome
+		}
+		add(category.Name, kind)
+	}
+
+	for _, account := range file.Accounts {
+		for _, entry := range account.Statement.Entries {
+			add(entry.Category, kindOf(entry.Amount))
+			for _, split := range entry.Splits {
+				add(split.Category, kindOf(split.Amount))
+			}
+		}
+	}
+
+	var (
+		imported = make([]ImportedCategory, 0, len(names))
+		pending  []*transactions.Category
+	)
+
+	for _, name := range names {
+		kind := kinds[strings.ToLower(name)]
+		id := transactions.NewCategoryID(tenantID, name)
+
+		exists, err := h.categories.Exists(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !exists {
+			var description string
+			for _, category := range file.Categories {
+				if strings.EqualFold(transactions.NormalizeCategoryName(category.Name), name) {
+					description = category.Description
+				}
+			}
+
+			category, err := transactions.NewCategory(tenantID, name, kind, description)
+			if err != nil {
+				return nil, nil, fmt.Errorf("category %s: %w", name, err)
+			}
+			pending = append(pending, category)
+		}
+
+		imported = append(imported, ImportedCategory{
+			CategoryID: id.String(),
+			Name:       name,
+			Kind:       string(kind),
+			Created:    !exists,
+		})
+	}
+
+	return imported, pending, nil
+}
+
+// qifAssetType returns the asset type of a QIF account type.
+func qifAssetType(accountType statements.QIFAccountType) assets.AssetType {
+	switch accountType {
+	case statements.QIFBank:
+		return assets.AssetTypeBank
+	case statements.QIFCash:
+		return assets.AssetTypeCash
+	case statements.QIFCreditCard:
+		return assets.AssetTypeCreditCard
+	case statements.QIFInvestment:
+		return assets.AssetTypeInvestment
+	default:
+		return assets.AssetTypeOther
+	}
+}
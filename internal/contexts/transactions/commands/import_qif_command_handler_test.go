@@ -0,0 +1,102 @@
+package transactionscommands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/commands"
+)
+
+// categoriesRepository is a transactions.CategoryRepository without categories.
+type categoriesRepository struct {
+	transactions.CategoryRepository
+}
+
+func (r *categoriesRepository) GetAll(_ context.Context) ([]*transactions.Category, error) {
+	return nil, nil
+}
+
+func TestImportQIFCommandHandler_OpeningBalance(t *testing.T) {
+	var (
+		ctx    = xtenant.WithTenant(context.Background(), "household")
+		booked = time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+	)
+
+	newAccount := func(accountType statements.QIFAccountType, amounts ...float64) statements.QIFAccount {
+		account := statements.QIFAccount{Name: "Imported " + string(accountType), Type: accountType}
+		for _, amount := range amounts {
+			account.Statement.Entries = append(account.Statement.Entries, statements.Entry{
+				BookingDate: booked,
+				Amount:      amount,
+				Description: "Payment",
+			})
+		}
+		return account
+	}
+
+	var specs = []struct {
+		name            string
+		account         statements.QIFAccount
+		expectedBalance float64
+		expectedErr     error
+	}{
+		{
+			name:            "bank account with a positive balance",
+			account:         newAccount(statements.QIFBank, 100, -40),
+			expectedBalance: 60,
+		},
+		{
+			name:            "overdrawn bank account",
+			account:         newAccount(statements.QIFBank, 100, -140.5),
+			expectedBalance: -40.5,
+		},
+		{
+			name:            "credit card with a debt",
+			account:         newAccount(statements.QIFCreditCard, -25, -75),
+			expectedBalance: -100,
+		},
+		{
+			name:        "cash with a negative balance",
+			account:     newAccount(statements.QIFCash, -10),
+			expectedErr: assets.ErrMoneyAmountCannotBeNegative,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			existing, err := assets.NewAsset(uuid.New(), "household", "", "Checking", assets.AssetTypeBank, assets.Money{Currency: assets.EUR})
+			require.NoError(t, err)
+
+			assetsRepo := &assetsRepository{asset: existing}
+			sut := NewImportQIFCommandHandler(newTransactionsRepository(), &categoriesRepository{}, assetsRepo)
+
+			res, err := sut.Handle(ctx, ImportQIFCommand{
+				File:     statements.QIFFile{Accounts: []statements.QIFAccount{spec.account}},
+				Currency: "eur",
+			})
+			if spec.expectedErr != nil {
+				require.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			result := res.(*ImportQIFResult)
+			require.Len(t, result.Accounts, 1)
+			assert.True(t, result.Accounts[0].Created)
+			assert.Equal(t, len(spec.account.Statement.Entries), result.Accounts[0].Imported)
+
+			require.Len(t, assetsRepo.saved, 1)
+			assert.Equal(t, spec.expectedBalance, assetsRepo.saved[0].Money().Amount)
+		})
+	}
+}
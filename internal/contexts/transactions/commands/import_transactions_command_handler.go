@@ -0,0 +1,294 @@
+package transactionscommands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+)
+
+type ImportTransactionsCommand struct {
+	// AssetID is the asset the transactions are imported into. When empty,
+	// the asset linked to the IBAN of the statement account is used.
+	AssetID   string
+	Source    string
+	Statement statements.Statement
+
+	// DryRun previews the import without recording the transactions.
+	DryRun bool
+}
+
+func (c ImportTransactionsCommand) CommandName() string {
+	return "ImportTransactionsCommand"
+}
+
+// ImportResult represents the outcome of a statement import.
+type ImportResult struct {
+	AssetID      string
+	DryRun       bool
+	Imported     int
+	Duplicates   int
+	Transactions []ImportedTransaction
+
+	// Balance is the closing balance of the statement applied to the asset, if any.
+	Balance *ImportedBalance
+}
+
+// ImportedBalance represents the statement balance applied to the asset.
+type ImportedBalance struct {
+	Amount   float64
+	Currency string
+	AsOf     time.Time
+
+	// Updated is set when the balance differs from the asset money.
+	Updated bool
+
+	// Outdated is set when the balance is older than the last one applied to the asset,
+	// e.g. of a statement imported late, so it is ignored.
+	Outdated bool
+}
+
+// ImportedTransaction represents a statement entry and whether it was already imported.
+type ImportedTransaction struct {
+	TransactionID string
+	Fingerprint   string
+	BookingDate   time.Time
+	ValueDate     time.Time
+	Amount        float64
+	Currency      string
+	Description   string
+	Payee         string
+	Reference     string
+	Duplicate     bool
+}
+
+type ImportTransactionsCommandHandler struct {
+	transactions transactions.Repository
+	assets       assets.Repository
+}
+
+func NewImportTransactionsCommandHandler(
+	transactions transactions.Repository,
+	assets assets.Repository,
+) *ImportTransactionsCommandHandler {
+	return &ImportTransactionsCommandHandler{
+		transactions: transactions,
+		assets:       assets,
+	}
+}
+
+func (h *ImportTransactionsCommandHandler) Handle(ctx context.Context, cmd ImportTransactionsCommand) (interface{}, error) {
+	asset, err := h.asset(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, result, err := prepare(ctx, h.transactions, asset, cmd.Source, cmd.Statement)
+	if err != nil {
+		return nil, err
+	}
+
+	result.DryRun = cmd.DryRun
+	result.Balance, err = applyBalance(asset, cmd.Statement.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.DryRun {
+		return result, nil
+	}
+
+	for _, transaction := range pending {
+		if err = h.transactions.Save(ctx, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	// the balance is saved last, so it is only updated once all the transactions are saved
+	if err = h.assets.Save(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	xlog.FromContext(ctx).Info().
+		Str("asset_id", asset.ID().String()).
+		Str("source", cmd.Source).
+		Int("imported", result.Imported).
+		Int("duplicates", result.Duplicates).
+		Msg("transactions imported")
+
+	return result, nil
+}
+
+// asset returns the asset the statement is imported into, retrieved within the tenant of the request.
+func (h *ImportTransactionsCommandHandler) asset(ctx context.Context, cmd ImportTransactionsCommand) (*assets.Asset, error) {
+	account := assets.NormalizeIBAN(cmd.Statement.AccountID)
+	isIBAN := assets.ValidateIBAN(account) == nil
+
+	if cmd.AssetID == "" {
+		if !isIBAN {
+			return nil, transactions.ErrStatementAccountNotLinked
+		}
+
+		all, err := h.assets.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, asset := range all {
+			if asset.IBAN() == account {
+				return asset, nil
+			}
+		}
+
+		return nil, transactions.ErrStatementAccountNotLinked
+	}
+
+	assetID, err := uuid.Parse(cmd.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
+	}
+
+	asset, err := h.assets.GetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// statements of other accounts are rejected when the accounts are known
+	if isIBAN && asset.IBAN() != "" && asset.IBAN() != account {
+		return nil, transactions.ErrStatementAccountMismatch
+	}
+
+	return asset, nil
+}
+
+// applyBalance updates the asset money with the closing balance of the statement,
+// unless a more recent balance was already applied.
+func applyBalance(asset *assets.Asset, balance *statements.Balance) (*ImportedBalance, error) {
+	if balance == nil {
+		return nil, nil
+	}
+
+	currency := strings.ToUpper(balance.Currency)
+	if currency == "" {
+		currency = asset.Money().Currency.String()
+	}
+
+	previous := asset.Money()
+	outdated := balance.Date.Before(asset.BalanceAsOf())
+	err := asset.UpdateBalance(assets.Money{
+		Amount:   balance.Amount,
+		Currency: assets.Currency(currency),
+	}, balance.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the asset balance: %w", err)
+	}
+
+	return &ImportedBalance{
+		Amount:   balance.Amount,
+		Currency: currency,
+		AsOf:     balance.Date,
+		Updated:  asset.Money() != previous,
+		Outdated: outdated,
+	}, nil
+}
+
+// prepare builds the transactions of the statement entries that were not imported yet.
+func prepare(
+	ctx context.Context,
+	repository transactions.Repository,
+	asset *assets.Asset,
+	source string,
+	statement statements.Statement,
+) ([]*transactions.Transaction, *ImportResult, error) {
+	var (
+		pending     []*transactions.Transaction
+		occurrences = make(map[string]int)
+		seen        = make(map[uuid.UUID]bool)
+		result      = &ImportResult{
+			AssetID:      asset.ID().String(),
+			Transactions: make([]ImportedTransaction, 0, len(statement.Entries)),
+		}
+	)
+
+	for i, entry := range statement.Entries {
+		details := transactions.Details{
+			BookingDate: entry.BookingDate,
+			ValueDate:   entry.ValueDate,
+			Amount:      entry.Amount,
+			Currency:    strings.ToUpper(entry.Currency),
+			Description: entry.Description,
+			Payee:       entry.Payee,
+			Reference:   entry.Reference,
+			Category:    entry.Category,
+			Transfer:    entry.Transfer,
+			Source:      source,
+			ExternalID:  entry.ExternalID,
+		}
+		for _, split := range entry.Splits {
+			details.Splits = append(details.Splits, transactions.Split(split))
+		}
+		if details.Currency == "" {
+			details.Currency = strings.ToUpper(statement.Currency)
+		}
+		if details.Currency == "" {
+			details.Currency = asset.Money().Currency.String()
+		}
+
+		if details.Currency != asset.Money().Currency.String() {
+			return nil, nil, fmt.Errorf("%w: entry %d is in %s", transactions.ErrTransactionCurrencyMismatch, i+1, details.Currency)
+		}
+
+		// identical entries of the same statement are told apart by their occurrence
+		fingerprint := transactions.Fingerprint(asset.ID(), details, 0)
+		occurrence := occurrences[fingerprint]
+		occurrences[fingerprint]++
+		if occurrence > 0 {
+			fingerprint = transactions.Fingerprint(asset.ID(), details, occurrence)
+		}
+
+		id := transactions.NewTransactionID(asset.ID(), fingerprint)
+		duplicate, err := repository.Exists(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// entries repeated in the statement, e.g. with the same external ID
+		duplicate = duplicate || seen[id]
+		seen[id] = true
+
+		result.Transactions = append(result.Transactions, ImportedTransaction{
+			TransactionID: id.String(),
+			Fingerprint:   fingerprint,
+			BookingDate:   details.BookingDate,
+			ValueDate:     details.ValueDate,
+			Amount:        details.Amount,
+			Currency:      details.Currency,
+			Description:   details.Description,
+			Payee:         details.Payee,
+			Reference:     details.Reference,
+			Duplicate:     duplicate,
+		})
+
+		if duplicate {
+			result.Duplicates++
+			continue
+		}
+
+		transaction, err := transactions.NewTransaction(id, asset.TenantID(), asset.ID(), details, fingerprint)
+		if err != nil {
+			return nil, nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+
+		pending = append(pending, transaction)
+		result.Imported++
+	}
+
+	return pending, result, nil
+}
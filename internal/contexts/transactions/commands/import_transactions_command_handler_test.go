@@ -0,0 +1,208 @@
+package transactionscommands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/commands"
+)
+
+// transactionsRepository is a transactions.Repository keeping the saved transactions in memory.
+type transactionsRepository struct {
+	transactions.Repository
+
+	saved map[uuid.UUID]*transactions.Transaction
+}
+
+func newTransactionsRepository() *transactionsRepository {
+	return &transactionsRepository{saved: make(map[uuid.UUID]*transactions.Transaction)}
+}
+
+func (r *transactionsRepository) Save(_ context.Context, transaction *transactions.Transaction) error {
+	r.saved[transaction.ID()] = transaction
+	return nil
+}
+
+func (r *transactionsRepository) Exists(_ context.Context, id uuid.UUID) (bool, error) {
+	_, ok := r.saved[id]
+	return ok, nil
+}
+
+// assetsRepository is an assets.Repository holding a single asset.
+type assetsRepository struct {
+	assets.Repository
+
+	asset *assets.Asset
+	saved []*assets.Asset
+}
+
+func (r *assetsRepository) GetByID(_ context.Context, id uuid.UUID) (*assets.Asset, error) {
+	if r.asset.ID() != id {
+		return nil, assets.ErrAssetNotFound
+	}
+	return r.asset, nil
+}
+
+func (r *assetsRepository) GetAll(_ context.Context) ([]*assets.Asset, error) {
+	return []*assets.Asset{r.asset}, nil
+}
+
+func (r *assetsRepository) Save(_ context.Context, asset *assets.Asset) error {
+	r.saved = append(r.saved, asset)
+	return nil
+}
+
+func TestImportTransactionsCommandHandler(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		booked = time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+		coffee = statements.Entry{BookingDate: booked, Amount: -2.5, Description: "Coffee"}
+		rent   = statements.Entry{BookingDate: booked, Amount: -900, Description: "Rent", ExternalID: "TX-1"}
+	)
+
+	setup := func(t *testing.T) (*ImportTransactionsCommandHandler, *transactionsRepository, *assetsRepository) {
+		t.Helper()
+		asset, err := assets.NewAsset(uuid.New(), "household", "", "Checking", assets.AssetTypeBank, assets.Money{Amount: 1000, Currency: assets.EUR})
+		require.NoError(t, err)
+
+		transactionsRepo := newTransactionsRepository()
+		assetsRepo := &assetsRepository{asset: asset}
+		return NewImportTransactionsCommandHandler(transactionsRepo, assetsRepo), transactionsRepo, assetsRepo
+	}
+
+	handle := func(t *testing.T, sut *ImportTransactionsCommandHandler, cmd ImportTransactionsCommand) *ImportResult {
+		t.Helper()
+		res, err := sut.Handle(ctx, cmd)
+		require.NoError(t, err)
+		return res.(*ImportResult)
+	}
+
+	t.Run("import identical entries of a statement by their occurrence", func(t *testing.T) {
+		sut, transactionsRepo, assetsRepo := setup(t)
+		cmd := ImportTransactionsCommand{
+			AssetID:   assetsRepo.asset.ID().String(),
+			Source:    "csv",
+			Statement: statements.Statement{Entries: []statements.Entry{coffee, coffee}},
+		}
+
+		result := handle(t, sut, cmd)
+		assert.Equal(t, 2, result.Imported)
+		assert.Zero(t, result.Duplicates)
+		assert.NotEqual(t, result.Transactions[0].TransactionID, result.Transactions[1].TransactionID)
+		assert.Len(t, transactionsRepo.saved, 2)
+
+		// a later statement with one more identical entry only imports the new one
+		cmd.Statement.Entries = append(cmd.Statement.Entries, coffee)
+		result = handle(t, sut, cmd)
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 2, result.Duplicates)
+		assert.False(t, result.Transactions[2].Duplicate)
+		assert.Len(t, transactionsRepo.saved, 3)
+	})
+
+	t.Run("import entries repeated with the same external ID once", func(t *testing.T) {
+		sut, transactionsRepo, assetsRepo := setup(t)
+
+		result := handle(t, sut, ImportTransactionsCommand{
+			AssetID:   assetsRepo.asset.ID().String(),
+			Source:    "camt053",
+			Statement: statements.Statement{Entries: []statements.Entry{rent, rent}},
+		})
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.True(t, result.Transactions[1].Duplicate)
+		assert.Len(t, transactionsRepo.saved, 1)
+	})
+
+	t.Run("re-import a statement records no transactions", func(t *testing.T) {
+		sut, transactionsRepo, assetsRepo := setup(t)
+		cmd := ImportTransactionsCommand{
+			AssetID:   assetsRepo.asset.ID().String(),
+			Source:    "ofx",
+			Statement: statements.Statement{Entries: []statements.Entry{coffee, rent}},
+		}
+
+		first := handle(t, sut, cmd)
+		assert.Equal(t, 2, first.Imported)
+
+		second := handle(t, sut, cmd)
+		assert.Zero(t, second.Imported)
+		assert.Equal(t, 2, second.Duplicates)
+		for i, transaction := range second.Transactions {
+			assert.True(t, transaction.Duplicate)
+			assert.Equal(t, first.Transactions[i].TransactionID, transaction.TransactionID)
+		}
+		assert.Len(t, transactionsRepo.saved, 2)
+	})
+
+	t.Run("dry run previews the import without recording it", func(t *testing.T) {
+		sut, transactionsRepo, assetsRepo := setup(t)
+		cmd := ImportTransactionsCommand{
+			AssetID: assetsRepo.asset.ID().String(),
+			Source:  "ofx",
+			Statement: statements.Statement{
+				Entries: []statements.Entry{coffee, rent},
+				Balance: &statements.Balance{Amount: 97.5, Date: booked},
+			},
+			DryRun: true,
+		}
+
+		result := handle(t, sut, cmd)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 2, result.Imported)
+		require.NotNil(t, result.Balance)
+		assert.True(t, result.Balance.Updated)
+		assert.Empty(t, transactionsRepo.saved)
+		assert.Empty(t, assetsRepo.saved)
+
+		// the dry run does not turn the entries into duplicates
+		cmd.DryRun = false
+		result = handle(t, sut, cmd)
+		assert.Equal(t, 2, result.Imported)
+		assert.Len(t, transactionsRepo.saved, 2)
+		assert.Len(t, assetsRepo.saved, 1)
+	})
+
+	t.Run("apply a negative balance and ignore the outdated ones", func(t *testing.T) {
+		sut, _, assetsRepo := setup(t)
+		cmd := ImportTransactionsCommand{
+			AssetID: assetsRepo.asset.ID().String(),
+			Source:  "ofx",
+			Statement: statements.Statement{
+				Balance: &statements.Balance{Amount: -42.5, Date: booked},
+			},
+		}
+
+		result := handle(t, sut, cmd)
+		require.NotNil(t, result.Balance)
+		assert.True(t, result.Balance.Updated)
+		assert.Equal(t, -42.5, assetsRepo.asset.Money().Amount)
+
+		cmd.Statement.Balance = &statements.Balance{Amount: 500, Date: booked.AddDate(0, -1, 0)}
+		result = handle(t, sut, cmd)
+		assert.False(t, result.Balance.Updated)
+		assert.True(t, result.Balance.Outdated)
+		assert.Equal(t, -42.5, assetsRepo.asset.Money().Amount)
+	})
+
+	t.Run("import entries in another currency should return error", func(t *testing.T) {
+		sut, transactionsRepo, assetsRepo := setup(t)
+
+		_, err := sut.Handle(ctx, ImportTransactionsCommand{
+			AssetID:   assetsRepo.asset.ID().String(),
+			Source:    "csv",
+			Statement: statements.Statement{Currency: "usd", Entries: []statements.Entry{coffee}},
+		})
+		require.ErrorIs(t, err, transactions.ErrTransactionCurrencyMismatch)
+		assert.Empty(t, transactionsRepo.saved)
+	})
+}
@@ -0,0 +1,228 @@
+package transactiondomain
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
+
+	transactionevents "github.com/xfrr/finantrack/internal/contexts/transactions/domain/events"
+)
+
+// CategoryAggregateType represents the category aggregate type.
+const CategoryAggregateType = "category"
+
+// categoryNamespace is the UUID namespace of the category IDs derived from names.
+var categoryNamespace = uuid.MustParse("b3a1f2de-3c0e-4d5f-8f7a-5e2b9c6d1a47")
+
+// CategorySeparator separates the levels of a category name, e.g. "Food:Groceries".
+const CategorySeparator = ":"
+
+var (
+	// ErrCategoryNameIsRequired represents the error when the category name is required.
+	ErrCategoryNameIsRequired = errors.New("category name is required")
+
+	// ErrInvalidCategoryKind represents the error when the category kind is not valid.
+	ErrInvalidCategoryKind = errors.New("invalid category kind")
+
+	// ErrCategoryNotFound represents the error when the category is not found.
+	ErrCategoryNotFound = errors.New("category not found")
+
+	// ErrCategoryTenantMismatch represents the error when a category is saved on behalf of another tenant.
+	ErrCategoryTenantMismatch = errors.New("category belongs to another tenant")
+)
+
+// CategoryKind represents whether a category classifies income or expenses.
+type CategoryKind string
+
+const (
+	// CategoryKindIncome represents the income category kind.
+	CategoryKindIncome CategoryKind = "income"
+
+	// CategoryKindExpense represents the expense category kind.
+	CategoryKindExpense CategoryKind = "expense"
+)
+
+// Validate validates the category kind.
+func (k CategoryKind) Validate() error {
+	switch k {
+	case CategoryKindIncome, CategoryKindExpense:
+		return nil
+	default:
+		return ErrInvalidCategoryKind
+	}
+}
+
+// Category represents a classification of transactions, e.g. "Food:Groceries".
+type Category struct {
+	*aggregate.Base[uuid.UUID]
+
+	tenantID    string
+	name        string
+	kind        CategoryKind
+	description string
+}
+
+// NewCategoryID returns the ID of the category with the given name in the given tenant.
+// Deriving the ID from the name makes categories unique by name.
+func NewCategoryID(tenantID, name string) uuid.UUID {
+	return uuid.NewSHA1(categoryNamespace, []byte(tenantID+":"+strings.ToLower(NormalizeCategoryName(name))))
+}
+
+// NormalizeCategoryName removes the whitespace around the levels of a category name.
+func NormalizeCategoryName(name string) string {
+	levels := strings.Split(name, CategorySeparator)
+	for i, level := range levels {
+		levels[i] = strings.Join(strings.Fields(level), " ")
+	}
+	return strings.Join(levels, CategorySeparator)
+}
+
+// NewCategory creates a new category of the given tenant.
+func NewCategory(tenantID, name string, kind CategoryKind, description string) (*Category, error) {
+	name = NormalizeCategoryName(name)
+	if strings.Trim(name, CategorySeparator) == "" {
+		return nil, ErrCategoryNameIsRequired
+	}
+
+	if err := kind.Validate(); err != nil {
+		return nil, err
+	}
+
+	id := NewCategoryID(tenantID, name)
+	category := newCategory(id)
+
+	aggregate.NextChange(
+		category,
+		uuid.New(),
+		transactionevents.CategoryCreatedEventType,
+		&transactionevents.CategoryCreatedEvent{
+			CategoryID:  id.String(),
+			TenantID:    tenantID,
+			Name:        name,
+			Kind:        string(kind),
+			Description: description,
+		},
+	)
+
+	return category, nil
+}
+
+func newCategory(id uuid.UUID) *Category {
+	category := &Category{
+		Base: aggregate.New(id, CategoryAggregateType),
+	}
+
+	// Register the event handlers
+	category.When(transactionevents.CategoryCreatedEventType, category.categoryCreatedEventHandler)
+
+	return category
+}
+
+// ID returns the category ID.
+func (c *Category) ID() uuid.UUID {
+	return c.Base.AggregateID()
+}
+
+// TenantID returns the ID of the household the category belongs to.
+func (c *Category) TenantID() string {
+	return c.tenantID
+}
+
+// Name returns the category name.
+func (c *Category) Name() string {
+	return c.name
+}
+
+// Kind returns the category kind.
+func (c *Category) Kind() CategoryKind {
+	return c.kind
+}
+
+// Description returns the category description.
+func (c *Category) Description() string {
+	return c.description
+}
+
+// HydrateCategory rebuilds the category with the given ID from its changes.
+func HydrateCategory(id uuid.UUID, changes []aggregate.Change) (*Category, error) {
+	category := newCategory(id)
+
+	err := aggregate.Hydrate(category, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// HydrateCategories rebuilds the categories from a sequence of changes
+// sorted by aggregate ID and version.
+func HydrateCategories(changes iter.Seq2[aggregate.Change, error]) ([]*Category, error) {
+	var (
+		categories []*Category
+		id         uuid.UUID
+		pending    []aggregate.Change
+	)
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		category, err := HydrateCategory(id, pending)
+		if err != nil {
+			return fmt.Errorf("failed to hydrate category %s: %w", id, err)
+		}
+
+		categories = append(categories, category)
+		pending = nil
+		return nil
+	}
+
+	for change, err := range changes {
+		if err != nil {
+			return nil, err
+		}
+
+		if change.Aggregate() == nil {
+			return nil, aggregate.ErrInvalidEventAggregateReference
+		}
+
+		changeAggregateID, ok := change.Aggregate().ID.(uuid.UUID)
+		if !ok {
+			return nil, aggregate.ErrInvalidAggregateID
+		}
+
+		if changeAggregateID != id {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+			id = changeAggregateID
+		}
+
+		pending = append(pending, change)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// categoryCreatedEventHandler is the event handler for the category created event.
+func (c *Category) categoryCreatedEventHandler(change aggregate.Change) {
+	evt, ok := change.Payload().(*transactionevents.CategoryCreatedEvent)
+	if !ok {
+		return
+	}
+
+	c.tenantID = evt.TenantID
+	c.name = evt.Name
+	c.kind = CategoryKind(evt.Kind)
+	c.description = evt.Description
+}
@@ -0,0 +1,11 @@
+package transactionevents
+
+const CategoryCreatedEventType = "category.created"
+
+type CategoryCreatedEvent struct {
+	CategoryID  string `json:"categoryId"`
+	TenantID    string `json:"tenantId,omitempty"`
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Description string `json:"description,omitempty"`
+}
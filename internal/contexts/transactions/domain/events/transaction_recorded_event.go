@@ -0,0 +1,42 @@
+package transactionevents
+
+import "time"
+
+const TransactionRecordedEventType = "transaction.recorded"
+
+type TransactionRecordedEvent struct {
+	TransactionID string    `json:"transactionId"`
+	TenantID      string    `json:"tenantId,omitempty"`
+	AssetID       string    `json:"assetId"`
+	BookingDate   time.Time `json:"bookingDate"`
+	ValueDate     time.Time `json:"valueDate"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Description   string    `json:"description,omitempty"`
+	Payee         string    `json:"payee,omitempty"`
+	Reference     string    `json:"reference,omitempty"`
+	Category      string    `json:"category,omitempty"`
+	Transfer      string    `json:"transfer,omitempty"`
+	Source        string    `json:"source,omitempty"`
+	ExternalID    string    `json:"externalId,omitempty"`
+	Fingerprint   string    `json:"fingerprint"`
+
+	Splits []TransactionSplit `json:"splits,omitempty"`
+}
+
+type TransactionSplit struct {
+	Category string  `json:"category,omitempty"`
+	Transfer string  `json:"transfer,omitempty"`
+	Memo     string  `json:"memo,omitempty"`
+	Amount   float64 `json:"amount"`
+}
+
+// EventDate returns the booking date, so the transactions are queried by it.
+func (e TransactionRecordedEvent) EventDate() time.Time {
+	return e.BookingDate
+}
+
+// ParentID returns the asset of the transaction, so the transactions are queried by it.
+func (e TransactionRecordedEvent) ParentID() string {
+	return e.AssetID
+}
@@ -0,0 +1,42 @@
+package transactiondomain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the interface that wraps the basic transaction repository methods.
+type Repository interface {
+	// Save saves all the transaction uncommited events to the event store
+	Save(ctx context.Context, transaction *Transaction) error
+
+	// GetByID returns the transaction by the given ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
+
+	// GetAll returns all the transactions sorted by booking date
+	GetAll(ctx context.Context) ([]*Transaction, error)
+
+	// GetByAsset returns the transactions of the given asset sorted by booking date
+	GetByAsset(ctx context.Context, assetID uuid.UUID) ([]*Transaction, error)
+
+	// GetByBookingDate returns the transactions booked between from and to, both inclusive,
+	// sorted by booking date. A zero time on any of the bounds leaves that side of the range open.
+	GetByBookingDate(ctx context.Context, from, to time.Time) ([]*Transaction, error)
+
+	// Exists checks if a transaction with the given ID exists
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// CategoryRepository is the interface that wraps the basic category repository methods.
+type CategoryRepository interface {
+	// Save saves all the category uncommited events to the event store
+	Save(ctx context.Context, category *Category) error
+
+	// GetAll returns all the categories sorted by name
+	GetAll(ctx context.Context) ([]*Category, error)
+
+	// Exists checks if a category with the given ID exists
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+}
@@ -0,0 +1,346 @@
+package transactiondomain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
+
+	transactionevents "github.com/xfrr/finantrack/internal/contexts/transactions/domain/events"
+)
+
+// AggregateType represents the transaction aggregate type.
+const AggregateType = "transaction"
+
+// namespace is the UUID namespace of the transaction IDs derived from fingerprints.
+var namespace = uuid.MustParse("6f0d8f3e-6b1c-4c55-9a53-2f2d7f1f6c1e")
+
+var (
+	// ErrInvalidTransactionID represents the error when the transaction ID is not a valid UUID.
+	ErrInvalidTransactionID = errors.New("invalid transaction identifier")
+
+	// ErrTransactionAssetIsRequired represents the error when the transaction asset is required.
+	ErrTransactionAssetIsRequired = errors.New("transaction asset is required")
+
+	// ErrTransactionDateIsRequired represents the error when the transaction booking date is required.
+	ErrTransactionDateIsRequired = errors.New("transaction booking date is required")
+
+	// ErrTransactionCurrencyIsRequired represents the error when the transaction currency is required.
+	ErrTransactionCurrencyIsRequired = errors.New("transaction currency is required")
+
+	// ErrTransactionSplitsMismatch represents the error when the splits do not add up to the transaction amount.
+	ErrTransactionSplitsMismatch = errors.New("transaction splits do not add up to the transaction amount")
+
+	// ErrTransactionCurrencyMismatch represents the error when the transaction currency
+	// differs from the currency of its asset.
+	ErrTransactionCurrencyMismatch = errors.New("transaction currency does not match the asset currency")
+
+	// ErrStatementAccountNotLinked represents the error when no asset is linked to the statement account.
+	ErrStatementAccountNotLinked = errors.New("no asset is linked to the statement account")
+
+	// ErrStatementAccountMismatch represents the error when the statement account
+	// differs from the account linked to the asset.
+	ErrStatementAccountMismatch = errors.New("statement account does not match the asset account")
+
+	// ErrTransactionNotFound represents the error when the transaction is not found.
+	ErrTransactionNotFound = errors.New("transaction not found")
+
+	// ErrTransactionTenantMismatch represents the error when a transaction is saved on behalf of another tenant.
+	ErrTransactionTenantMismatch = errors.New("transaction belongs to another tenant")
+)
+
+// Details represents the data of a money movement of an asset.
+// Positive amounts are inflows and negative amounts outflows.
+type Details struct {
+	BookingDate time.Time
+	ValueDate   time.Time
+	Amount      float64
+	Currency    string
+	Description string
+	Payee       string
+	Reference   string
+	Category    string
+
+	// Transfer is the name of the account the money was moved to or from, if any.
+	Transfer string
+
+	// Splits are the parts of the transaction assigned to different categories, if any.
+	Splits []Split
+
+	// Source is the origin of the transaction, e.g. the statement format it was imported from.
+	Source string
+
+	// ExternalID is the identifier given to the transaction by the bank, if any.
+	ExternalID string
+}
+
+// Split represents a part of a transaction.
+type Split struct {
+	Category string
+	Transfer string
+	Memo     string
+	Amount   float64
+}
+
+// splitsTolerance is the rounding difference allowed between the splits and the transaction amount.
+const splitsTolerance = 0.005
+
+// Transaction represents a money movement of an asset.
+type Transaction struct {
+	*aggregate.Base[uuid.UUID]
+
+	tenantID    string
+	assetID     uuid.UUID
+	details     Details
+	fingerprint string
+}
+
+// NewTransaction records a new transaction of the given asset.
+// The tenant is the household the asset belongs to.
+func NewTransaction(id uuid.UUID, tenantID string, assetID uuid.UUID, details Details, fingerprint string) (*Transaction, error) {
+	if err := details.Validate(); err != nil {
+		return nil, err
+	}
+
+	if assetID == uuid.Nil {
+		return nil, ErrTransactionAssetIsRequired
+	}
+
+	transaction := newTransaction(id)
+
+	aggregate.NextChange(
+		transaction,
+		uuid.New(),
+		transactionevents.TransactionRecordedEventType,
+		&transactionevents.TransactionRecordedEvent{
+			TransactionID: id.String(),
+			TenantID:      tenantID,
+			AssetID:       assetID.String(),
+			BookingDate:   details.BookingDate,
+			ValueDate:     details.ValueDate,
+			Amount:        details.Amount,
+			Currency:      details.Currency,
+			Description:   details.Description,
+			Payee:         details.Payee,
+			Reference:     details.Reference,
+			Category:      details.Category,
+			Transfer:      details.Transfer,
+			Source:        details.Source,
+			ExternalID:    details.ExternalID,
+			Fingerprint:   fingerprint,
+			Splits:        newSplitEvents(details.Splits),
+		},
+	)
+
+	return transaction, nil
+}
+
+func newTransaction(id uuid.UUID) *Transaction {
+	transaction := &Transaction{
+		Base: aggregate.New(id, AggregateType),
+	}
+
+	// Register the event handlers
+	transaction.When(transactionevents.TransactionRecordedEventType, transaction.transactionRecordedEventHandler)
+
+	return transaction
+}
+
+// ID returns the transaction ID.
+func (t *Transaction) ID() uuid.UUID {
+	return t.Base.AggregateID()
+}
+
+// TenantID returns the ID of the household the transaction belongs to.
+func (t *Transaction) TenantID() string {
+	return t.tenantID
+}
+
+// AssetID returns the ID of the asset the transaction belongs to.
+func (t *Transaction) AssetID() uuid.UUID {
+	return t.assetID
+}
+
+// Details returns the transaction data.
+func (t *Transaction) Details() Details {
+	return t.details
+}
+
+// Fingerprint returns the fingerprint the transaction is deduplicated by.
+func (t *Transaction) Fingerprint() string {
+	return t.fingerprint
+}
+
+// Validate validates the transaction data.
+func (d Details) Validate() error {
+	if d.BookingDate.IsZero() {
+		return ErrTransactionDateIsRequired
+	}
+
+	if d.Currency == "" {
+		return ErrTransactionCurrencyIsRequired
+	}
+
+	if len(d.Splits) > 0 {
+		var total float64
+		for _, split := range d.Splits {
+			total += split.Amount
+		}
+
+		if math.Abs(total-d.Amount) > splitsTolerance {
+			return ErrTransactionSplitsMismatch
+		}
+	}
+
+	return nil
+}
+
+// Fingerprint identifies the transaction of an asset, so the same transaction
+// imported twice can be detected. Transactions identified by the bank are
+// fingerprinted by their external ID, and the rest by their content.
+// Occurrence distinguishes identical transactions without external ID
+// in the same statement, e.g. two equal payments on the same day.
+func Fingerprint(assetID uuid.UUID, details Details, occurrence int) string {
+	fields := []string{assetID.String(), "external", details.ExternalID}
+	if details.ExternalID == "" {
+		fields = []string{
+			assetID.String(),
+			details.BookingDate.Format(time.DateOnly),
+			strconv.FormatFloat(details.Amount, 'f', 2, 64),
+			strings.ToUpper(details.Currency),
+			normalize(details.Description),
+			normalize(details.Reference),
+			strconv.Itoa(occurrence),
+		}
+	}
+
+	h := sha256.New()
+	for _, field := range fields {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NewTransactionID returns the ID of the transaction with the given fingerprint.
+// Deriving the ID from the fingerprint makes imports idempotent.
+func NewTransactionID(assetID uuid.UUID, fingerprint string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte(assetID.String()+":"+fingerprint))
+}
+
+// normalize removes the case and whitespace differences of a text.
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// HydrateTransaction rebuilds the transaction with the given ID from its changes.
+func HydrateTransaction(id uuid.UUID, changes []aggregate.Change) (*Transaction, error) {
+	transaction := newTransaction(id)
+
+	err := aggregate.Hydrate(transaction, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// HydrateTransactions rebuilds the transactions from a sequence of changes
+// sorted by aggregate ID and version.
+func HydrateTransactions(changes iter.Seq2[aggregate.Change, error]) ([]*Transaction, error) {
+	var (
+		transactions []*Transaction
+		id           uuid.UUID
+		pending      []aggregate.Change
+	)
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		transaction, err := HydrateTransaction(id, pending)
+		if err != nil {
+			return fmt.Errorf("failed to hydrate transaction %s: %w", id, err)
+		}
+
+		transactions = append(transactions, transaction)
+		pending = nil
+		return nil
+	}
+
+	for change, err := range changes {
+		if err != nil {
+			return nil, err
+		}
+
+		if change.Aggregate() == nil {
+			return nil, aggregate.ErrInvalidEventAggregateReference
+		}
+
+		changeAggregateID, ok := change.Aggregate().ID.(uuid.UUID)
+		if !ok {
+			return nil, aggregate.ErrInvalidAggregateID
+		}
+
+		if changeAggregateID != id {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+			id = changeAggregateID
+		}
+
+		pending = append(pending, change)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// transactionRecordedEventHandler is the event handler for the transaction recorded event.
+func (t *Transaction) transactionRecordedEventHandler(change aggregate.Change) {
+	evt, ok := change.Payload().(*transactionevents.TransactionRecordedEvent)
+	if !ok {
+		return
+	}
+
+	t.tenantID = evt.TenantID
+	t.assetID, _ = uuid.Parse(evt.AssetID)
+	t.fingerprint = evt.Fingerprint
+	t.details = Details{
+		BookingDate: evt.BookingDate,
+		ValueDate:   evt.ValueDate,
+		Amount:      evt.Amount,
+		Currency:    evt.Currency,
+		Description: evt.Description,
+		Payee:       evt.Payee,
+		Reference:   evt.Reference,
+		Category:    evt.Category,
+		Transfer:    evt.Transfer,
+		Source:      evt.Source,
+		ExternalID:  evt.ExternalID,
+	}
+
+	for _, split := range evt.Splits {
+		t.details.Splits = append(t.details.Splits, Split(split))
+	}
+}
+
+func newSplitEvents(splits []Split) []transactionevents.TransactionSplit {
+	var events []transactionevents.TransactionSplit
+	for _, split := range splits {
+		events = append(events, transactionevents.TransactionSplit(split))
+	}
+	return events
+}
@@ -0,0 +1,103 @@
+package exports
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// beancountAccount builds a beancount account name. The levels must start with
+// a capital letter or a digit and contain only ASCII letters, digits and dashes.
+func beancountAccount(levels ...string) string {
+	names := make([]string, 0, len(levels))
+	for _, level := range levels {
+		var words []string
+		for _, word := range strings.FieldsFunc(norm.NFD.String(level), func(r rune) bool {
+			return !isASCIIAlphanumeric(r) && !unicode.Is(unicode.Mn, r)
+		}) {
+			// drop the accents decomposed from the letters
+			word = strings.Map(func(r rune) rune {
+				if unicode.Is(unicode.Mn, r) {
+					return -1
+				}
+				return r
+			}, word)
+			if word != "" {
+				words = append(words, word)
+			}
+		}
+
+		name := strings.Join(words, "-")
+		if name == "" {
+			name = uncategorized
+		}
+		names = append(names, strings.ToUpper(name[:1])+name[1:])
+	}
+	return strings.Join(names, ":")
+}
+
+func isASCIIAlphanumeric(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// writeBeancount renders the book as a beancount file.
+func writeBeancount(w io.Writer, b book) error {
+	bw := bufio.NewWriter(w)
+	start := b.start.Format("2006-01-02")
+
+	bw.WriteString("; Exported from FinanTrack\n")
+	bw.WriteString("option \"title\" \"FinanTrack\"\n")
+	for _, commodity := range b.commodities {
+		bw.WriteString("option \"operating_currency\" \"" + commodity + "\"\n")
+	}
+
+	if len(b.commodities) > 0 {
+		bw.WriteString("\n")
+	}
+	for _, commodity := range b.commodities {
+		bw.WriteString(start + " commodity " + commodity + "\n")
+	}
+
+	if len(b.accounts) > 0 {
+		bw.WriteString("\n")
+	}
+	for _, account := range b.accounts {
+		bw.WriteString(start + " open " + account + "\n")
+	}
+
+	if len(b.prices) > 0 {
+		bw.WriteString("\n")
+	}
+	for _, p := range b.prices {
+		bw.WriteString(p.date.Format("2006-01-02") + " price " + p.commodity + " " + formatPrice(p.amount) + " " + p.currency + "\n")
+	}
+
+	for _, e := range b.entries {
+		bw.WriteString("\n" + e.date.Format("2006-01-02") + " *")
+		if e.payee != "" {
+			bw.WriteString(" " + beancountString(e.payee))
+		}
+		bw.WriteString(" " + beancountString(e.narration) + "\n")
+		bw.WriteString("  id: " + beancountString(strings.Join(e.ids, ",")) + "\n")
+
+		width := e.accountWidth()
+		for i, amount := range e.amounts() {
+			bw.WriteString("  " + pad(e.postings[i].account, width) + amount + "\n")
+			if memo := e.postings[i].memo; memo != "" {
+				bw.WriteString("    memo: " + beancountString(memo) + "\n")
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// beancountString quotes a text, removing its line breaks.
+func beancountString(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+	return `"` + s + `"`
+}
@@ -0,0 +1,404 @@
+// Package exports renders the books of a household in the plain-text accounting
+// formats of ledger, hledger and beancount.
+package exports
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+)
+
+// ErrUnsupportedFormat represents the error when the export format is not supported.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Format represents a plain-text accounting format.
+type Format string
+
+const (
+	// FormatLedger is the journal format of ledger.
+	FormatLedger Format = "ledger"
+
+	// FormatHLedger is the journal format of hledger, which reads the ledger journals.
+	FormatHLedger Format = "hledger"
+
+	// FormatBeancount is the format of beancount.
+	FormatBeancount Format = "beancount"
+)
+
+// Formats are the supported export formats.
+var Formats = []Format{FormatLedger, FormatHLedger, FormatBeancount}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+}
+
+// Extension returns the file extension of the format.
+func (f Format) Extension() string {
+	switch f {
+	case FormatBeancount:
+		return ".beancount"
+	default:
+		return ".journal"
+	}
+}
+
+// Write renders the journal in the given format.
+func Write(w io.Writer, format Format, journal transactionsqueries.JournalView) error {
+	switch format {
+	case FormatLedger, FormatHLedger:
+		return writeLedger(w, newBook(journal, ledgerAccount))
+	case FormatBeancount:
+		return writeBeancount(w, newBook(journal, beancountAccount))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// Account roots of the plain-text accounting formats.
+const (
+	rootAssets      = "Assets"
+	rootLiabilities = "Liabilities"
+	rootIncome      = "Income"
+	rootExpenses    = "Expenses"
+	rootEquity      = "Equity"
+)
+
+// uncategorized is the category of the transactions without category.
+const uncategorized = "Uncategorized"
+
+// priceScale is the precision of the exchange rates, six decimals.
+const priceScale = 1e6
+
+// amountTolerance is the rounding difference below which amounts are equal.
+const amountTolerance = 0.005
+
+// book is the double-entry representation of a journal shared by the formats.
+type book struct {
+	// start is the date the accounts and commodities are declared at.
+	start       time.Time
+	commodities []string
+	accounts    []string
+	prices      []price
+	entries     []entry
+}
+
+// entry is a balanced transaction.
+type entry struct {
+	date      time.Time
+	payee     string
+	narration string
+	ids       []string
+	postings  []posting
+}
+
+// posting is a leg of an entry.
+type posting struct {
+	account  string
+	amount   float64
+	currency string
+
+	// cost is the total price of the amount in another currency, if any.
+	cost *money
+
+	memo string
+}
+
+type money struct {
+	amount   float64
+	currency string
+}
+
+// price is the value of a commodity in another one at a date.
+type price struct {
+	date      time.Time
+	commodity string
+	amount    float64
+	currency  string
+}
+
+// amounts returns the formatted amounts of the postings, right aligned.
+func (e entry) amounts() []string {
+	amounts := make([]string, len(e.postings))
+	width := 0
+	for i, p := range e.postings {
+		amounts[i] = formatAmount(p.amount)
+		width = max(width, len(amounts[i]))
+	}
+
+	for i, p := range e.postings {
+		amounts[i] = strings.Repeat(" ", width-len(amounts[i])) + amounts[i] + " " + p.currency
+		if p.cost != nil {
+			amounts[i] += " @@ " + formatAmount(p.cost.amount) + " " + p.cost.currency
+		}
+	}
+	return amounts
+}
+
+// accountWidth returns the width of the longest account of the postings.
+func (e entry) accountWidth() int {
+	width := 0
+	for _, p := range e.postings {
+		width = max(width, utf8.RuneCountInString(p.account))
+	}
+	return width
+}
+
+// pad fills the account with spaces up to the given width and the separation of the amounts.
+func pad(account string, width int) string {
+	return account + strings.Repeat(" ", width-utf8.RuneCountInString(account)+4)
+}
+
+// accountNamer builds the account name of the given levels, e.g. Assets:Bank:Checking.
+type accountNamer func(levels ...string) string
+
+// bookBuilder converts the journal transactions into balanced entries.
+type bookBuilder struct {
+	name       accountNamer
+	assets     map[string]bookAsset
+	byName     map[string]string
+	kinds      map[string]string
+	accounts   map[string]bool
+	currencies map[string]bool
+}
+
+type bookAsset struct {
+	account  string
+	currency string
+}
+
+func newBook(journal transactionsqueries.JournalView, name accountNamer) book {
+	b := bookBuilder{
+		name:       name,
+		assets:     make(map[string]bookAsset, len(journal.Assets)),
+		byName:     make(map[string]string, len(journal.Assets)),
+		kinds:      make(map[string]string, len(journal.Categories)),
+		accounts:   make(map[string]bool),
+		currencies: make(map[string]bool),
+	}
+
+	for _, asset := range journal.Assets {
+		view := bookAsset{
+			account:  b.assetAccount(asset.Type, asset.Name),
+			currency: asset.MoneyCurrency,
+		}
+		b.assets[asset.ID] = view
+		b.byName[strings.ToLower(asset.Name)] = asset.ID
+		b.accounts[view.account] = true
+		b.currencies[view.currency] = true
+	}
+
+	for _, category := range journal.Categories {
+		b.kinds[strings.ToLower(category.Name)] = category.Kind
+	}
+
+	var (
+		result   book
+		consumed = make(map[string]bool)
+	)
+
+	for i, t := range journal.Transactions {
+		if consumed[t.ID] {
+			continue
+		}
+
+		asset, ok := b.assets[t.AssetID]
+		if !ok {
+			continue
+		}
+
+		if result.start.IsZero() || t.BookingDate.Before(result.start) {
+			result.start = t.BookingDate
+		}
+		b.currencies[t.Currency] = true
+
+		e := entry{
+			date:      t.BookingDate,
+			payee:     t.Payee,
+			narration: t.Description,
+			ids:       []string{t.ID},
+			postings: []posting{
+				{account: asset.account, amount: t.Amount, currency: t.Currency},
+			},
+		}
+
+		switch {
+		case len(t.Splits) > 0:
+			// the last split takes the rounding difference, so the entry balances
+			remaining := -t.Amount
+			for j, split := range t.Splits {
+				amount := -split.Amount
+				if j == len(t.Splits)-1 {
+					amount = remaining
+				}
+				remaining -= amount
+
+				e.postings = append(e.postings, posting{
+					account:  b.counterAccount(split.Category, split.Transfer, split.Amount),
+					amount:   amount,
+					currency: t.Currency,
+					memo:     split.Memo,
+				})
+			}
+
+		case t.Transfer != "":
+			// both legs of a transfer between known assets are merged into one entry
+			if mirror, ok := b.mirror(t, journal.Transactions[i+1:], consumed); ok {
+				other := b.assets[mirror.AssetID]
+				leg := posting{account: other.account, amount: mirror.Amount, currency: mirror.Currency}
+				if mirror.Currency != t.Currency {
+					leg.cost = &money{amount: math.Abs(t.Amount), currency: t.Currency}
+					result.prices = append(result.prices, price{
+						date:      t.BookingDate,
+						commodity: mirror.Currency,
+						amount:    math.Round(math.Abs(t.Amount)/math.Abs(mirror.Amount)*priceScale) / priceScale,
+						currency:  t.Currency,
+					})
+					b.currencies[mirror.Currency] = true
+				}
+
+				consumed[mirror.ID] = true
+				e.ids = append(e.ids, mirror.ID)
+				e.postings = append(e.postings, leg)
+				break
+			}
+
+			e.postings = append(e.postings, posting{
+				account:  b.counterAccount("", t.Transfer, t.Amount),
+				amount:   -t.Amount,
+				currency: t.Currency,
+			})
+
+		default:
+			e.postings = append(e.postings, posting{
+				account:  b.counterAccount(t.Category, "", t.Amount),
+				amount:   -t.Amount,
+				currency: t.Currency,
+			})
+		}
+
+		result.entries = append(result.entries, e)
+	}
+
+	if result.start.IsZero() {
+		result.start = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	for account := range b.accounts {
+		result.accounts = append(result.accounts, account)
+	}
+	sort.Strings(result.accounts)
+
+	for currency := range b.currencies {
+		if currency != "" {
+			result.commodities = append(result.commodities, currency)
+		}
+	}
+	sort.Strings(result.commodities)
+
+	return result
+}
+
+// mirror finds the other leg of a transfer, recorded in the asset the money was moved to or from.
+func (b *bookBuilder) mirror(
+	t transactionsqueries.TransactionView,
+	candidates []transactionsqueries.TransactionView,
+	consumed map[string]bool,
+) (transactionsqueries.TransactionView, bool) {
+	otherID, ok := b.byName[strings.ToLower(t.Transfer)]
+	if !ok || otherID == t.AssetID {
+		return transactionsqueries.TransactionView{}, false
+	}
+
+	for _, candidate := range candidates {
+		if candidate.BookingDate.After(t.BookingDate) {
+			break
+		}
+
+		if consumed[candidate.ID] || candidate.AssetID != otherID || len(candidate.Splits) > 0 {
+			continue
+		}
+
+		if b.byName[strings.ToLower(candidate.Transfer)] != t.AssetID || candidate.Amount*t.Amount >= 0 {
+			continue
+		}
+
+		// legs of the same currency move the same amount
+		if candidate.Currency == t.Currency && math.Abs(candidate.Amount+t.Amount) > amountTolerance {
+			continue
+		}
+
+		return candidate, true
+	}
+
+	return transactionsqueries.TransactionView{}, false
+}
+
+// assetAccount returns the account of an asset, where credit cards are liabilities.
+func (b *bookBuilder) assetAccount(assetType, name string) string {
+	switch assets.AssetType(assetType) {
+	case assets.AssetTypeCreditCard:
+		return b.name(rootLiabilities, "Credit Card", name)
+	case assets.AssetTypeBank:
+		return b.name(rootAssets, "Bank", name)
+	case assets.AssetTypeCash:
+		return b.name(rootAssets, "Cash", name)
+	case assets.AssetTypeInvestment:
+		return b.name(rootAssets, "Investment", name)
+	default:
+		return b.name(rootAssets, "Other", name)
+	}
+}
+
+// counterAccount returns the account balancing an amount of an asset: the transfer
+// account, or the income or expense account of the category. The kind of the
+// unknown categories is guessed from the sign of the amount.
+func (b *bookBuilder) counterAccount(category, transfer string, amount float64) string {
+	var account string
+	switch {
+	case transfer != "":
+		if id, ok := b.byName[strings.ToLower(transfer)]; ok {
+			account = b.assets[id].account
+		} else {
+			account = b.name(rootEquity, "Transfers", transfer)
+		}
+
+	default:
+		category = transactions.NormalizeCategoryName(category)
+		if category == "" {
+			category = uncategorized
+		}
+
+		kind, ok := b.kinds[strings.ToLower(category)]
+		if !ok {
+			kind = string(transactions.CategoryKindExpense)
+			if amount > 0 {
+				kind = string(transactions.CategoryKindIncome)
+			}
+		}
+
+		root := rootExpenses
+		if kind == string(transactions.CategoryKindIncome) {
+			root = rootIncome
+		}
+
+		account = b.name(append([]string{root}, strings.Split(category, transactions.CategorySeparator)...)...)
+	}
+
+	b.accounts[account] = true
+	return account
+}
@@ -0,0 +1,197 @@
+package exports_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsqueries "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/exports"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+)
+
+var journal = transactionsqueries.JournalView{
+	Assets: []assetsqueries.AssetView{
+		{ID: "checking", Name: "Checking", Type: "bank", MoneyCurrency: "EUR"},
+		{ID: "savings", Name: "Savings", Type: "bank", MoneyCurrency: "USD"},
+		{ID: "visa", Name: "Visa: Gold", Type: "credit_card", MoneyCurrency: "EUR"},
+	},
+	Categories: []transactionsqueries.CategoryView{
+		{Name: "Food:Groceries", Kind: "expense"},
+		{Name: "Salary", Kind: "income"},
+	},
+	Transactions: []transactionsqueries.TransactionView{
+		{
+			ID: "t1", AssetID: "checking", BookingDate: date(5), Amount: -42.5, Currency: "EUR",
+			Payee: "Grocery Market", Description: "Weekly shopping", Category: "Food:Groceries",
+		},
+		{
+			ID: "t2", AssetID: "checking", BookingDate: date(10), Amount: -100, Currency: "EUR",
+			Description: "To savings", Transfer: "Savings",
+		},
+		{
+			ID: "t3", AssetID: "savings", BookingDate: date(10), Amount: 108.5, Currency: "USD",
+			Description: "From checking", Transfer: "Checking",
+		},
+		{
+			ID: "t4", AssetID: "visa", BookingDate: date(15), Amount: -60, Currency: "EUR",
+			Description: `Café "Bio"`,
+			Splits: []transactionsqueries.SplitView{
+				{Category: "Food:Groceries", Amount: -40},
+				{Category: "Dining & Café", Memo: "Lunch", Amount: -20},
+			},
+		},
+		{
+			ID: "t5", AssetID: "checking", BookingDate: date(31), Amount: 2500, Currency: "EUR",
+			Payee: "ACME Corp", Category: "Salary",
+		},
+		{
+			ID: "t6", AssetID: "checking", BookingDate: date(31), Amount: 12.3, Currency: "EUR",
+			Description: "Refund",
+		},
+	},
+}
+
+func date(day int) time.Time {
+	return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+const expectedLedger = `; Exported from FinanTrack
+
+commodity EUR
+    format 1,000.00 EUR
+
+commodity USD
+    format 1,000.00 USD
+
+account Assets:Bank:Checking
+account Assets:Bank:Savings
+account Expenses:Dining & Café
+account Expenses:Food:Groceries
+account Income:Salary
+account Income:Uncategorized
+account Liabilities:Credit Card:Visa Gold
+
+P 2024-01-10 USD 0.921659 EUR
+
+2024-01-05 * Grocery Market
+    ; Weekly shopping
+    ; id: t1
+    Assets:Bank:Checking       -42.50 EUR
+    Expenses:Food:Groceries     42.50 EUR
+
+2024-01-10 * To savings
+    ; id: t2
+    ; id: t3
+    Assets:Bank:Checking    -100.00 EUR
+    Assets:Bank:Savings      108.50 USD @@ 100.00 EUR
+
+2024-01-15 * Café "Bio"
+    ; id: t4
+    Liabilities:Credit Card:Visa Gold    -60.00 EUR
+    Expenses:Food:Groceries               40.00 EUR
+    Expenses:Dining & Café                20.00 EUR  ; Lunch
+
+2024-01-31 * ACME Corp
+    ; id: t5
+    Assets:Bank:Checking     2500.00 EUR
+    Income:Salary           -2500.00 EUR
+
+2024-01-31 * Refund
+    ; id: t6
+    Assets:Bank:Checking     12.30 EUR
+    Income:Uncategorized    -12.30 EUR
+`
+
+const expectedBeancount = `; Exported from FinanTrack
+option "title" "FinanTrack"
+option "operating_currency" "EUR"
+option "operating_currency" "USD"
+
+2024-01-05 commodity EUR
+2024-01-05 commodity USD
+
+2024-01-05 open Assets:Bank:Checking
+2024-01-05 open Assets:Bank:Savings
+2024-01-05 open Expenses:Dining-Cafe
+2024-01-05 open Expenses:Food:Groceries
+2024-01-05 open Income:Salary
+2024-01-05 open Income:Uncategorized
+2024-01-05 open Liabilities:Credit-Card:Visa-Gold
+
+2024-01-10 price USD 0.921659 EUR
+
+2024-01-05 * "Grocery Market" "Weekly shopping"
+  id: "t1"
+  Assets:Bank:Checking       -42.50 EUR
+  Expenses:Food:Groceries     42.50 EUR
+
+2024-01-10 * "To savings"
+  id: "t2,t3"
+  Assets:Bank:Checking    -100.00 EUR
+  Assets:Bank:Savings      108.50 USD @@ 100.00 EUR
+
+2024-01-15 * "Café \"Bio\""
+  id: "t4"
+  Liabilities:Credit-Card:Visa-Gold    -60.00 EUR
+  Expenses:Food:Groceries               40.00 EUR
+  Expenses:Dining-Cafe                  20.00 EUR
+    memo: "Lunch"
+
+2024-01-31 * "ACME Corp" ""
+  id: "t5"
+  Assets:Bank:Checking     2500.00 EUR
+  Income:Salary           -2500.00 EUR
+
+2024-01-31 * "Refund"
+  id: "t6"
+  Assets:Bank:Checking     12.30 EUR
+  Income:Uncategorized    -12.30 EUR
+`
+
+func TestWrite(t *testing.T) {
+	var specs = []struct {
+		name           string
+		format         Format
+		expectedOutput string
+		expectedErr    error
+	}{
+		{
+			name:           "ledger journal",
+			format:         FormatLedger,
+			expectedOutput: expectedLedger,
+		},
+		{
+			name:           "hledger journal",
+			format:         FormatHLedger,
+			expectedOutput: expectedLedger,
+		},
+		{
+			name:           "beancount file",
+			format:         FormatBeancount,
+			expectedOutput: expectedBeancount,
+		},
+		{
+			name:        "unsupported format",
+			format:      "gnucash",
+			expectedErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Write(&buf, spec.format, journal)
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, spec.expectedOutput, buf.String())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package exports
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ledgerAccount builds a ledger account name. Colons separate the levels, and
+// brackets, parentheses, semicolons and repeated spaces are not allowed in them.
+func ledgerAccount(levels ...string) string {
+	replacer := strings.NewReplacer(
+		":", " ", ";", " ", "(", "", ")", "", "[", "", "]", "", "\t", " ",
+	)
+
+	names := make([]string, 0, len(levels))
+	for _, level := range levels {
+		name := strings.Join(strings.Fields(replacer.Replace(level)), " ")
+		if name == "" {
+			name = uncategorized
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ":")
+}
+
+// writeLedger renders the book as a ledger journal, which hledger reads too.
+func writeLedger(w io.Writer, b book) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("; Exported from FinanTrack\n")
+
+	for _, commodity := range b.commodities {
+		bw.WriteString("\ncommodity " + commodity + "\n")
+		bw.WriteString("    format 1,000.00 " + commodity + "\n")
+	}
+
+	if len(b.accounts) > 0 {
+		bw.WriteString("\n")
+	}
+	for _, account := range b.accounts {
+		bw.WriteString("account " + account + "\n")
+	}
+
+	if len(b.prices) > 0 {
+		bw.WriteString("\n")
+	}
+	for _, p := range b.prices {
+		bw.WriteString("P " + p.date.Format("2006-01-02") + " " + p.commodity + " " + formatPrice(p.amount) + " " + p.currency + "\n")
+	}
+
+	for _, e := range b.entries {
+		title := e.payee
+		if title == "" {
+			title = e.narration
+		}
+
+		bw.WriteString("\n" + e.date.Format("2006-01-02") + " *")
+		if title = ledgerText(title); title != "" {
+			bw.WriteString(" " + title)
+		}
+		bw.WriteString("\n")
+		if e.payee != "" && e.narration != "" {
+			bw.WriteString("    ; " + ledgerText(e.narration) + "\n")
+		}
+		for _, id := range e.ids {
+			bw.WriteString("    ; id: " + id + "\n")
+		}
+
+		width := e.accountWidth()
+		for i, amount := range e.amounts() {
+			bw.WriteString("    " + pad(e.postings[i].account, width) + amount)
+			if memo := ledgerText(e.postings[i].memo); memo != "" {
+				bw.WriteString("  ; " + memo)
+			}
+			bw.WriteString("\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ledgerText removes the line breaks of a payee or note.
+func ledgerText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func formatAmount(amount float64) string {
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	if s == "-0.00" {
+		return "0.00"
+	}
+	return s
+}
+
+// formatPrice formats a price with the precision of the exchange rates.
+func formatPrice(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
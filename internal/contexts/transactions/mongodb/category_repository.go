@@ -0,0 +1,75 @@
+package transactionsmongo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+var _ transactiondomain.CategoryRepository = (*CategoryRepository)(nil)
+
+// CategoryRepository implements the CategoryRepository interface using MongoDB.
+type CategoryRepository struct {
+	eventStore xmongo.EventStore
+}
+
+// NewCategoryRepository creates a new CategoryRepository with the given MongoDB event store.
+func NewCategoryRepository(eventStore xmongo.EventStore) *CategoryRepository {
+	return &CategoryRepository{
+		eventStore: eventStore,
+	}
+}
+
+// Save saves the category changes into the event store.
+func (r *CategoryRepository) Save(ctx context.Context, category *transactiondomain.Category) (err error) {
+	changes := category.AggregateChanges()
+
+	ctx, span := tracer.Start(ctx, "transactionsmongo.CategoryRepository.Save", trace.WithAttributes(xtracing.ChangesAttributes(changes)...))
+	defer xtracing.End(span, &err)
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Categories can only be written on behalf of the tenant they belong to
+	if tenantID, ok := xtenant.FromContext(ctx); ok && tenantID != category.TenantID() {
+		return transactiondomain.ErrCategoryTenantMismatch
+	}
+	if category.TenantID() != "" {
+		ctx = xtenant.WithTenant(ctx, category.TenantID())
+	}
+
+	return r.eventStore.Save(ctx, changes...)
+}
+
+// GetAll retrieves all the categories sorted by name.
+func (r *CategoryRepository) GetAll(ctx context.Context) ([]*transactiondomain.Category, error) {
+	categories, err := transactiondomain.HydrateCategories(r.eventStore.Stream(ctx,
+		scope(ctx, xmongo.WithAggregateTypeCriteria(transactiondomain.CategoryAggregateType)()),
+		xmongo.WithSort(xmongo.SortByAggregateID, xmongo.Ascending),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].Name() < categories[j].Name()
+	})
+
+	return categories, nil
+}
+
+// Exists checks if a category with the given ID exists in the event store.
+// It is not scoped to the tenant, since category IDs are derived from the tenant.
+func (r *CategoryRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.eventStore.ExistsByAggregateID(ctx, id)
+}
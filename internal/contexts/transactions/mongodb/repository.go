@@ -0,0 +1,134 @@
+package transactionsmongo
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+var _ transactiondomain.Repository = (*Repository)(nil)
+
+var tracer = xtracing.Tracer("github.com/xfrr/finantrack/internal/contexts/transactions/mongodb")
+
+// Repository implements the Repository interface using MongoDB.
+type Repository struct {
+	eventStore xmongo.EventStore
+}
+
+// NewRepository creates a new Repository with the given MongoDB event store.
+func NewRepository(eventStore xmongo.EventStore) *Repository {
+	return &Repository{
+		eventStore: eventStore,
+	}
+}
+
+// Save saves the transaction changes into the event store.
+func (r *Repository) Save(ctx context.Context, transaction *transactiondomain.Transaction) (err error) {
+	changes := transaction.AggregateChanges()
+
+	ctx, span := tracer.Start(ctx, "transactionsmongo.Repository.Save", trace.WithAttributes(xtracing.ChangesAttributes(changes)...))
+	defer xtracing.End(span, &err)
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Transactions can only be written on behalf of the tenant they belong to
+	if tenantID, ok := xtenant.FromContext(ctx); ok && tenantID != transaction.TenantID() {
+		return transactiondomain.ErrTransactionTenantMismatch
+	}
+	if transaction.TenantID() != "" {
+		ctx = xtenant.WithTenant(ctx, transaction.TenantID())
+	}
+
+	return r.eventStore.Save(ctx, changes...)
+}
+
+// GetByID retrieves a transaction by its ID from the event store.
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (_ *transactiondomain.Transaction, err error) {
+	ctx, span := tracer.Start(ctx, "transactionsmongo.Repository.GetByID", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(id.String()),
+		xtracing.AggregateTypeKey.String(transactiondomain.AggregateType),
+	))
+	defer xtracing.End(span, &err)
+
+	changes, err := r.eventStore.Get(ctx,
+		scope(ctx, xmongo.And(
+			xmongo.WithAggregateIDCriteria(id.String())(),
+			xmongo.WithAggregateTypeCriteria(transactiondomain.AggregateType)(),
+		)()),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, transactiondomain.ErrTransactionNotFound
+	}
+
+	return transactiondomain.HydrateTransaction(id, changes)
+}
+
+// GetAll retrieves all the transactions sorted by booking date.
+func (r *Repository) GetAll(ctx context.Context) ([]*transactiondomain.Transaction, error) {
+	return r.find(ctx, xmongo.All()())
+}
+
+// GetByAsset retrieves the transactions of the given asset sorted by booking date.
+func (r *Repository) GetByAsset(ctx context.Context, assetID uuid.UUID) ([]*transactiondomain.Transaction, error) {
+	return r.find(ctx, xmongo.WithParentIDCriteria(assetID.String())())
+}
+
+// GetByBookingDate retrieves the transactions booked between from and to, both inclusive,
+// sorted by booking date.
+func (r *Repository) GetByBookingDate(ctx context.Context, from, to time.Time) ([]*transactiondomain.Transaction, error) {
+	return r.find(ctx, xmongo.WithEventDateRangeCriteria(from, to)())
+}
+
+// find retrieves the transactions whose events match the criteria, sorted by booking date.
+// The transactions are recorded by a single event, so the criteria select whole transactions.
+func (r *Repository) find(ctx context.Context, criteria xmongo.Criteria) ([]*transactiondomain.Transaction, error) {
+	transactions, err := transactiondomain.HydrateTransactions(r.eventStore.Stream(ctx,
+		scope(ctx, xmongo.And(
+			xmongo.WithAggregateTypeCriteria(transactiondomain.AggregateType)(),
+			criteria,
+		)()),
+		xmongo.WithSort(xmongo.SortByAggregateID, xmongo.Ascending),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Details().BookingDate.Before(transactions[j].Details().BookingDate)
+	})
+
+	return transactions, nil
+}
+
+// Exists checks if a transaction with the given ID exists in the event store.
+// It is not scoped to the tenant, since transaction IDs are unique across tenants.
+func (r *Repository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.eventStore.ExistsByAggregateID(ctx, id)
+}
+
+// scope restricts the criteria to the tenant of the context, if any.
+func scope(ctx context.Context, criteria xmongo.Criteria) xmongo.Criteria {
+	tenantID, ok := xtenant.FromContext(ctx)
+	if !ok {
+		return criteria
+	}
+
+	return xmongo.And(criteria, xmongo.WithTenantIDCriteria(tenantID)())()
+}
@@ -0,0 +1,25 @@
+package transactionsqueries
+
+import (
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// CategoryView represents the state of a category returned by the queries.
+type CategoryView struct {
+	ID          string
+	TenantID    string
+	Name        string
+	Kind        string
+	Description string
+}
+
+// NewCategoryView creates a new CategoryView from the given category.
+func NewCategoryView(category *transactions.Category) CategoryView {
+	return CategoryView{
+		ID:          category.ID().String(),
+		TenantID:    category.TenantID(),
+		Name:        category.Name(),
+		Kind:        string(category.Kind()),
+		Description: category.Description(),
+	}
+}
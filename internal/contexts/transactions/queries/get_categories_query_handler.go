@@ -0,0 +1,37 @@
+package transactionsqueries
+
+import (
+	"context"
+
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+type GetCategoriesQuery struct{}
+
+func (q GetCategoriesQuery) QueryName() string {
+	return "GetCategoriesQuery"
+}
+
+type GetCategoriesQueryHandler struct {
+	categories transactions.CategoryRepository
+}
+
+func NewGetCategoriesQueryHandler(categories transactions.CategoryRepository) *GetCategoriesQueryHandler {
+	return &GetCategoriesQueryHandler{
+		categories: categories,
+	}
+}
+
+func (h *GetCategoriesQueryHandler) Handle(ctx context.Context, _ GetCategoriesQuery) (interface{}, error) {
+	all, err := h.categories.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]CategoryView, 0, len(all))
+	for _, category := range all {
+		views = append(views, NewCategoryView(category))
+	}
+
+	return views, nil
+}
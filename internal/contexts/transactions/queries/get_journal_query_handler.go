@@ -0,0 +1,106 @@
+package transactionsqueries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	assetsqueries "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// GetJournalQuery returns the assets, categories and transactions of the tenant,
+// e.g. to export them. When AssetID is set, only that asset is returned.
+type GetJournalQuery struct {
+	AssetID string
+}
+
+func (q GetJournalQuery) QueryName() string {
+	return "GetJournalQuery"
+}
+
+// JournalView represents the books of a tenant returned by the journal query.
+type JournalView struct {
+	Assets     []assetsqueries.AssetView
+	Categories []CategoryView
+
+	// Transactions are sorted by booking date.
+	Transactions []TransactionView
+}
+
+type GetJournalQueryHandler struct {
+	transactions transactions.Repository
+	categories   transactions.CategoryRepository
+	assets       assets.Repository
+}
+
+func NewGetJournalQueryHandler(
+	transactions transactions.Repository,
+	categories transactions.CategoryRepository,
+	assets assets.Repository,
+) *GetJournalQueryHandler {
+	return &GetJournalQueryHandler{
+		transactions: transactions,
+		categories:   categories,
+		assets:       assets,
+	}
+}
+
+func (h *GetJournalQueryHandler) Handle(ctx context.Context, query GetJournalQuery) (interface{}, error) {
+	var (
+		all []*assets.Asset
+		err error
+	)
+
+	if query.AssetID != "" {
+		assetID, err := uuid.Parse(query.AssetID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
+		}
+
+		asset, err := h.assets.GetByID(ctx, assetID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, asset)
+	} else if all, err = h.assets.GetAll(ctx); err != nil {
+		return nil, err
+	}
+
+	categories, err := h.categories.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := h.transactions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := JournalView{
+		Assets:       make([]assetsqueries.AssetView, 0, len(all)),
+		Categories:   make([]CategoryView, 0, len(categories)),
+		Transactions: make([]TransactionView, 0, len(records)),
+	}
+
+	// Transactions of deleted or hidden assets are left out
+	visible := make(map[uuid.UUID]bool, len(all))
+	for _, asset := range all {
+		visible[asset.ID()] = true
+		journal.Assets = append(journal.Assets, assetsqueries.NewAssetView(asset))
+	}
+
+	for _, category := range categories {
+		journal.Categories = append(journal.Categories, NewCategoryView(category))
+	}
+
+	for _, transaction := range records {
+		if visible[transaction.AssetID()] {
+			journal.Transactions = append(journal.Transactions, NewTransactionView(transaction))
+		}
+	}
+
+	return journal, nil
+}
@@ -0,0 +1,58 @@
+package transactionsqueries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+type GetTransactionsQuery struct {
+	AssetID string
+}
+
+func (q GetTransactionsQuery) QueryName() string {
+	return "GetTransactionsQuery"
+}
+
+type GetTransactionsQueryHandler struct {
+	transactions transactions.Repository
+	assets       assets.Repository
+}
+
+func NewGetTransactionsQueryHandler(
+	transactions transactions.Repository,
+	assets assets.Repository,
+) *GetTransactionsQueryHandler {
+	return &GetTransactionsQueryHandler{
+		transactions: transactions,
+		assets:       assets,
+	}
+}
+
+func (h *GetTransactionsQueryHandler) Handle(ctx context.Context, query GetTransactionsQuery) (interface{}, error) {
+	assetID, err := uuid.Parse(query.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", assets.ErrInvalidAssetID, err)
+	}
+
+	// The asset must be visible within the tenant of the request
+	if _, err = h.assets.GetByID(ctx, assetID); err != nil {
+		return nil, err
+	}
+
+	all, err := h.transactions.GetByAsset(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]TransactionView, 0, len(all))
+	for _, transaction := range all {
+		views = append(views, NewTransactionView(transaction))
+	}
+
+	return views, nil
+}
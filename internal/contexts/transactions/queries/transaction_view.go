@@ -0,0 +1,63 @@
+package transactionsqueries
+
+import (
+	"time"
+
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// TransactionView represents the state of a transaction returned by the queries.
+type TransactionView struct {
+	ID          string
+	TenantID    string
+	AssetID     string
+	BookingDate time.Time
+	ValueDate   time.Time
+	Amount      float64
+	Currency    string
+	Description string
+	Payee       string
+	Reference   string
+	Category    string
+	Transfer    string
+	Splits      []SplitView
+	Source      string
+	ExternalID  string
+	Fingerprint string
+}
+
+// SplitView represents a part of a transaction returned by the queries.
+type SplitView struct {
+	Category string
+	Transfer string
+	Memo     string
+	Amount   float64
+}
+
+// NewTransactionView creates a new TransactionView from the given transaction.
+func NewTransactionView(transaction *transactions.Transaction) TransactionView {
+	details := transaction.Details()
+	view := TransactionView{
+		ID:          transaction.ID().String(),
+		TenantID:    transaction.TenantID(),
+		AssetID:     transaction.AssetID().String(),
+		BookingDate: details.BookingDate,
+		ValueDate:   details.ValueDate,
+		Amount:      details.Amount,
+		Currency:    details.Currency,
+		Description: details.Description,
+		Payee:       details.Payee,
+		Reference:   details.Reference,
+		Category:    details.Category,
+		Transfer:    details.Transfer,
+		Source:      details.Source,
+		ExternalID:  details.ExternalID,
+		Fingerprint: transaction.Fingerprint(),
+	}
+
+	for _, split := range details.Splits {
+		view.Splits = append(view.Splits, SplitView(split))
+	}
+
+	return view
+}
@@ -0,0 +1,229 @@
+package statements
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var _ Parser = CAMT053Parser{}
+
+// CAMT053Parser parses ISO 20022 camt.053 bank to customer statements.
+// Only booked entries are read, and all the statements of a file must belong to the same account.
+type CAMT053Parser struct{}
+
+// NewCAMT053Parser creates a new CAMT053Parser.
+func NewCAMT053Parser() CAMT053Parser {
+	return CAMT053Parser{}
+}
+
+// Parse reads the booked entries and the closing balance of a camt.053 document.
+func (p CAMT053Parser) Parse(r io.Reader) (Statement, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return Statement{}, fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+
+	if len(doc.Statements) == 0 {
+		return Statement{}, fmt.Errorf("%w: no statement found", ErrInvalidStatement)
+	}
+
+	var statement Statement
+	for i, stmt := range doc.Statements {
+		account := stmt.Account.ID.IBAN
+		if account == "" {
+			account = stmt.Account.ID.Other
+		}
+
+		if i > 0 && account != statement.AccountID {
+			return Statement{}, fmt.Errorf("%w: statements of several accounts found, only one is supported", ErrInvalidStatement)
+		}
+
+		statement.AccountID = account
+		statement.Currency = stmt.Account.Currency
+
+		for j, ntry := range stmt.Entries {
+			if !ntry.isBooked() {
+				continue
+			}
+
+			entry, err := ntry.toEntry(statement.Currency)
+			if err != nil {
+				return Statement{}, fmt.Errorf("%w: statement %d entry %d: %w", ErrInvalidStatement, i+1, j+1, err)
+			}
+			statement.Entries = append(statement.Entries, entry)
+		}
+
+		// the closing booked balance of the last statement is the current balance
+		for _, bal := range stmt.Balances {
+			if bal.Code != "CLBD" {
+				continue
+			}
+
+			date, err := bal.Date.time()
+			if err != nil {
+				return Statement{}, fmt.Errorf("%w: closing balance: %w", ErrInvalidStatement, err)
+			}
+
+			statement.Balance = &Balance{
+				Amount:   bal.Amount.signed(bal.CreditDebit),
+				Currency: bal.Amount.Currency,
+				Date:     date,
+			}
+		}
+	}
+
+	return statement, nil
+}
+
+// camtDocument maps the camt.053 elements, regardless of the schema version.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Account struct {
+		ID struct {
+			IBAN  string `xml:"IBAN"`
+			Other string `xml:"Othr>Id"`
+		} `xml:"Id"`
+		Currency string `xml:"Ccy"`
+	} `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtBalance struct {
+	Code        string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount      camtAmount `xml:"Amt"`
+	CreditDebit string     `xml:"CdtDbtInd"`
+	Date        camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	Reference         string     `xml:"NtryRef"`
+	Amount            camtAmount `xml:"Amt"`
+	CreditDebit       string     `xml:"CdtDbtInd"`
+	Status            camtStatus `xml:"Sts"`
+	BookingDate       camtDate   `xml:"BookgDt"`
+	ValueDate         camtDate   `xml:"ValDt"`
+	ServicerReference string     `xml:"AcctSvcrRef"`
+	AdditionalInfo    string     `xml:"AddtlNtryInf"`
+	Transactions      []struct {
+		EndToEndID string   `xml:"Refs>EndToEndId"`
+		Remittance []string `xml:"RmtInf>Ustrd"`
+		Creditor   string   `xml:"RltdPties>Cdtr>Nm"`
+		CreditorV8 string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+		Debtor     string   `xml:"RltdPties>Dbtr>Nm"`
+		DebtorV8   string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+// camtStatus is the entry status, which is a code element since camt.053.001.08.
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtAmount struct {
+	Value    float64 `xml:",chardata"`
+	Currency string  `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// isBooked checks the entry was booked, as opposed to pending or informational.
+func (e camtEntry) isBooked() bool {
+	status := strings.TrimSpace(e.Status.Code)
+	if status == "" {
+		status = strings.TrimSpace(e.Status.Text)
+	}
+	return status == "" || status == "BOOK"
+}
+
+func (e camtEntry) toEntry(currency string) (Entry, error) {
+	bookingDate, err := e.BookingDate.time()
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid booking date: %w", err)
+	}
+
+	var valueDate time.Time
+	if e.ValueDate != (camtDate{}) {
+		valueDate, err = e.ValueDate.time()
+		if err != nil {
+			return Entry{}, fmt.Errorf("invalid value date: %w", err)
+		}
+	}
+
+	entry := Entry{
+		BookingDate: bookingDate,
+		ValueDate:   valueDate,
+		Amount:      e.Amount.signed(e.CreditDebit),
+		Currency:    e.Amount.Currency,
+		Description: strings.TrimSpace(e.AdditionalInfo),
+		Reference:   e.Reference,
+		ExternalID:  e.ServicerReference,
+	}
+	if entry.Currency == "" {
+		entry.Currency = currency
+	}
+
+	// the details of the first transaction describe single transaction entries
+	if len(e.Transactions) > 0 {
+		tx := e.Transactions[0]
+		if remittance := strings.TrimSpace(strings.Join(tx.Remittance, " ")); remittance != "" {
+			entry.Description = remittance
+		}
+
+		if entry.Amount < 0 {
+			entry.Payee = firstNonEmpty(tx.Creditor, tx.CreditorV8)
+		} else {
+			entry.Payee = firstNonEmpty(tx.Debtor, tx.DebtorV8)
+		}
+
+		if entry.Reference == "" && tx.EndToEndID != "NOTPROVIDED" {
+			entry.Reference = tx.EndToEndID
+		}
+	}
+
+	return entry, nil
+}
+
+// signed returns the amount, negative for debits.
+func (a camtAmount) signed(creditDebit string) float64 {
+	if strings.TrimSpace(creditDebit) == "DBIT" {
+		return -a.Value
+	}
+	return a.Value
+}
+
+func (d camtDate) time() (time.Time, error) {
+	if d.DateTime != "" {
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(d.DateTime)); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid date time %q", d.DateTime)
+	}
+
+	t, err := time.Parse(time.DateOnly, strings.TrimSpace(d.Date))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", d.Date)
+	}
+	return t, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+)
+
+const camt053Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG1</MsgId><CreDtTm>2024-02-01T08:00:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT1</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">950.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-31</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <NtryRef>E1</NtryRef>
+        <Amt Ccy="EUR">50.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-01-10</Dt></BookgDt>
+        <ValDt><Dt>2024-01-11</Dt></ValDt>
+        <AcctSvcrRef>BANKREF1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>E2E-1</EndToEndId></Refs>
+          <RltdPties><Cdtr><Nm>Power Company</Nm></Cdtr></RltdPties>
+          <RmtInf><Ustrd>Invoice 42</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>E3</NtryRef>
+        <Amt Ccy="EUR">20.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-01-12</Dt></BookgDt>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>E2E-3</EndToEndId></Refs>
+          <RmtInf><Ustrd>Bakery</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">10.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2024-01-31</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+const camt053V8Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id></Acct>
+      <Ntry>
+        <Amt Ccy="EUR">1200.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-01-31T10:00:00+01:00</DtTm></BookgDt>
+        <AddtlNtryInf>Salary</AddtlNtryInf>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+          <RltdPties><Dbtr><Pty><Nm>ACME</Nm></Pty></Dbtr></RltdPties>
+        </TxDtls></NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func TestCAMT053Parser_Parse(t *testing.T) {
+	var specs = []struct {
+		name              string
+		input             string
+		expectedStatement Statement
+		expectedErr       error
+	}{
+		{
+			name:  "booked entries and closing balance",
+			input: camt053Statement,
+			expectedStatement: Statement{
+				AccountID: "DE89370400440532013000",
+				Currency:  "EUR",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+						ValueDate:   time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+						Amount:      -50,
+						Currency:    "EUR",
+						Description: "Invoice 42",
+						Payee:       "Power Company",
+						Reference:   "E1",
+						ExternalID:  "BANKREF1",
+					},
+					{
+						// without servicer reference the entry is identified by its content
+						BookingDate: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+						Amount:      -20,
+						Currency:    "EUR",
+						Description: "Bakery",
+						Reference:   "E3",
+					},
+				},
+				Balance: &Balance{
+					Amount:   950,
+					Currency: "EUR",
+					Date:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:  "camt.053.001.08 status code and date times",
+			input: camt053V8Statement,
+			expectedStatement: Statement{
+				AccountID: "DE89370400440532013000",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 31, 10, 0, 0, 0, time.FixedZone("", 3600)),
+						Amount:      1200,
+						Currency:    "EUR",
+						Description: "Salary",
+						Payee:       "ACME",
+					},
+				},
+			},
+		},
+		{
+			name:        "not a camt.053 document",
+			input:       "<Document><Other/></Document>",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "malformed xml",
+			input:       "<Document><BkToCstmrStmt>",
+			expectedErr: ErrInvalidStatement,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			statement, err := NewCAMT053Parser().Parse(strings.NewReader(spec.input))
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, statement.Entries, len(spec.expectedStatement.Entries))
+			for i := range statement.Entries {
+				assert.True(t, spec.expectedStatement.Entries[i].BookingDate.Equal(statement.Entries[i].BookingDate))
+				statement.Entries[i].BookingDate = spec.expectedStatement.Entries[i].BookingDate
+			}
+			assert.Equal(t, spec.expectedStatement, statement)
+		})
+	}
+}
@@ -0,0 +1,398 @@
+package statements
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// ErrUnknownCSVProfile represents the error when a CSV profile is not found.
+var ErrUnknownCSVProfile = errors.New("unknown csv profile")
+
+// CSVColumns maps the statement fields to the CSV columns.
+// Columns are referenced by their header name or by their zero-based index.
+type CSVColumns struct {
+	Date        string `json:"date"`
+	ValueDate   string `json:"valueDate,omitempty"`
+	Amount      string `json:"amount,omitempty"`
+	Debit       string `json:"debit,omitempty"`
+	Credit      string `json:"credit,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	Description string `json:"description,omitempty"`
+	Payee       string `json:"payee,omitempty"`
+	Reference   string `json:"reference,omitempty"`
+}
+
+// CSVProfile describes the CSV format exported by a bank.
+type CSVProfile struct {
+	Name string `json:"name"`
+
+	// Delimiter is the field separator, a comma by default.
+	Delimiter string `json:"delimiter,omitempty"`
+
+	// DateFormat is the layout of the dates, either a Go layout
+	// or a pattern such as DD/MM/YYYY. It defaults to YYYY-MM-DD.
+	DateFormat string `json:"dateFormat,omitempty"`
+
+	// DecimalSeparator is the separator of the amount decimals, a dot by default.
+	DecimalSeparator string `json:"decimalSeparator,omitempty"`
+
+	// Encoding is the character encoding of the file, e.g. windows-1252. It defaults to UTF-8.
+	Encoding string `json:"encoding,omitempty"`
+
+	// Currency is the currency of the entries when there is no currency column.
+	Currency string `json:"currency,omitempty"`
+
+	// NoHeader is set when the first row is an entry instead of the column names.
+	NoHeader bool `json:"noHeader,omitempty"`
+
+	// SkipRows is the number of rows before the header, e.g. account details.
+	SkipRows int `json:"skipRows,omitempty"`
+
+	Columns CSVColumns `json:"columns"`
+}
+
+// DefaultCSVProfiles are the profiles available without configuration.
+var DefaultCSVProfiles = []CSVProfile{
+	{
+		Name:       "generic",
+		Delimiter:  ",",
+		DateFormat: "YYYY-MM-DD",
+		Columns: CSVColumns{
+			Date:        "date",
+			Amount:      "amount",
+			Currency:    "currency",
+			Description: "description",
+			Payee:       "payee",
+			Reference:   "reference",
+		},
+	},
+	{
+		Name:             "generic-eu",
+		Delimiter:        ";",
+		DateFormat:       "DD/MM/YYYY",
+		DecimalSeparator: ",",
+		Columns: CSVColumns{
+			Date:        "date",
+			ValueDate:   "value date",
+			Amount:      "amount",
+			Currency:    "currency",
+			Description: "description",
+			Payee:       "payee",
+			Reference:   "reference",
+		},
+	},
+}
+
+// LoadCSVProfiles reads a JSON array of profiles.
+func LoadCSVProfiles(r io.Reader) ([]CSVProfile, error) {
+	var profiles []CSVProfile
+	if err := json.NewDecoder(r).Decode(&profiles); err != nil {
+		return nil, fmt.Errorf("failed to decode csv profiles: %w", err)
+	}
+
+	for _, p := range profiles {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("csv profile %q: %w", p.Name, err)
+		}
+	}
+
+	return profiles, nil
+}
+
+// FindCSVProfile returns the profile with the given name.
+func FindCSVProfile(profiles []CSVProfile, name string) (CSVProfile, error) {
+	i := slices.IndexFunc(profiles, func(p CSVProfile) bool {
+		return strings.EqualFold(p.Name, name)
+	})
+	if i < 0 {
+		return CSVProfile{}, fmt.Errorf("%w: %s", ErrUnknownCSVProfile, name)
+	}
+
+	return profiles[i], nil
+}
+
+// Validate checks the profile describes a parseable format.
+func (p CSVProfile) Validate() error {
+	if len([]rune(p.Delimiter)) > 1 {
+		return fmt.Errorf("%w: delimiter must be a single character", ErrInvalidStatement)
+	}
+
+	if p.Columns.Date == "" {
+		return fmt.Errorf("%w: date column is required", ErrInvalidStatement)
+	}
+
+	if p.Columns.Amount == "" && p.Columns.Debit == "" && p.Columns.Credit == "" {
+		return fmt.Errorf("%w: amount or debit/credit columns are required", ErrInvalidStatement)
+	}
+
+	if p.Encoding != "" {
+		if _, err := htmlindex.Get(p.Encoding); err != nil {
+			return fmt.Errorf("%w: unsupported encoding %s", ErrInvalidStatement, p.Encoding)
+		}
+	}
+
+	return nil
+}
+
+var _ Parser = CSVParser{}
+
+// CSVParser parses CSV statements described by a profile.
+type CSVParser struct {
+	Profile CSVProfile
+}
+
+// NewCSVParser creates a new CSVParser with the given profile.
+func NewCSVParser(profile CSVProfile) CSVParser {
+	return CSVParser{Profile: profile}
+}
+
+// Parse reads the entries of a CSV statement.
+func (p CSVParser) Parse(r io.Reader) (Statement, error) {
+	profile := p.Profile
+	if err := profile.Validate(); err != nil {
+		return Statement{}, err
+	}
+
+	if profile.Encoding != "" {
+		enc, _ := htmlindex.Get(profile.Encoding)
+		r = enc.NewDecoder().Reader(r)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if profile.Delimiter != "" {
+		reader.Comma = []rune(profile.Delimiter)[0]
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return Statement{}, fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+
+	if profile.SkipRows > len(rows) {
+		return Statement{}, nil
+	}
+	rows = rows[profile.SkipRows:]
+
+	// firstRow is the line number of the first entry
+	firstRow := profile.SkipRows + 1
+
+	var header []string
+	if !profile.NoHeader && len(rows) > 0 {
+		header, rows = rows[0], rows[1:]
+		firstRow++
+		if len(header) > 0 {
+			// drop the UTF-8 byte order mark of the first column
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+	}
+
+	columns, err := resolveColumns(profile.Columns, header)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	layout := dateLayout(profile.DateFormat)
+	statement := Statement{
+		Currency: profile.Currency,
+		Entries:  make([]Entry, 0, len(rows)),
+	}
+
+	for i, row := range rows {
+		if isBlank(row) {
+			continue
+		}
+
+		entry, err := parseCSVEntry(row, columns, layout, profile)
+		if err != nil {
+			return Statement{}, fmt.Errorf("%w: row %d: %w", ErrInvalidStatement, firstRow+i, err)
+		}
+
+		statement.Entries = append(statement.Entries, entry)
+	}
+
+	return statement, nil
+}
+
+// csvColumns holds the indexes of the mapped columns, -1 when not mapped.
+type csvColumns struct {
+	date, valueDate, amount, debit, credit, currency, description, payee, reference int
+}
+
+// resolveColumns finds the indexes of the mapped columns. The date and amount
+// columns are required, while the optional ones are ignored when not found.
+func resolveColumns(mapping CSVColumns, header []string) (csvColumns, error) {
+	var err error
+	index := func(column string, required bool) int {
+		if column == "" {
+			return -1
+		}
+
+		if i, convErr := strconv.Atoi(column); convErr == nil {
+			return i
+		}
+
+		i := slices.IndexFunc(header, func(name string) bool {
+			return strings.EqualFold(strings.TrimSpace(name), column)
+		})
+		if i < 0 && required && err == nil {
+			err = fmt.Errorf("%w: column %q not found", ErrInvalidStatement, column)
+		}
+		return i
+	}
+
+	columns := csvColumns{
+		date:        index(mapping.Date, true),
+		valueDate:   index(mapping.ValueDate, false),
+		amount:      index(mapping.Amount, true),
+		debit:       index(mapping.Debit, true),
+		credit:      index(mapping.Credit, true),
+		currency:    index(mapping.Currency, false),
+		description: index(mapping.Description, false),
+		payee:       index(mapping.Payee, false),
+		reference:   index(mapping.Reference, false),
+	}
+
+	return columns, err
+}
+
+func parseCSVEntry(row []string, columns csvColumns, layout string, profile CSVProfile) (Entry, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var (
+		entry Entry
+		err   error
+	)
+
+	entry.BookingDate, err = time.Parse(layout, field(columns.date))
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid date: %w", err)
+	}
+
+	if value := field(columns.valueDate); value != "" {
+		entry.ValueDate, err = time.Parse(layout, value)
+		if err != nil {
+			return Entry{}, fmt.Errorf("invalid value date: %w", err)
+		}
+	}
+
+	if columns.amount >= 0 {
+		entry.Amount, err = ParseAmount(field(columns.amount), profile.DecimalSeparator)
+		if err != nil {
+			return Entry{}, err
+		}
+	} else {
+		debit, err := ParseAmount(field(columns.debit), profile.DecimalSeparator)
+		if err != nil {
+			return Entry{}, err
+		}
+
+		credit, err := ParseAmount(field(columns.credit), profile.DecimalSeparator)
+		if err != nil {
+			return Entry{}, err
+		}
+
+		// debits may be exported either as positive or negative values
+		entry.Amount = credit - abs(debit)
+	}
+
+	entry.Currency = field(columns.currency)
+	if entry.Currency == "" {
+		entry.Currency = profile.Currency
+	}
+	entry.Description = field(columns.description)
+	entry.Payee = field(columns.payee)
+	entry.Reference = field(columns.reference)
+
+	return entry, nil
+}
+
+// ParseAmount parses a decimal amount with the given decimal separator,
+// ignoring the thousands separators and the spaces. Empty amounts are zero.
+func ParseAmount(value, decimalSeparator string) (float64, error) {
+	if decimalSeparator == "" {
+		decimalSeparator = "."
+	}
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+
+	value = strings.Join(strings.Fields(value), "")
+	value = strings.ReplaceAll(value, "'", "")
+	value = strings.ReplaceAll(value, thousandsSeparator, "")
+	value = strings.Replace(value, decimalSeparator, ".", 1)
+	if value == "" {
+		return 0, nil
+	}
+
+	// trailing minus signs and parentheses are common for debits
+	negative := false
+	switch {
+	case strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")"):
+		negative, value = true, value[1:len(value)-1]
+	case strings.HasSuffix(value, "-"):
+		negative, value = true, strings.TrimSuffix(value, "-")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// dateLayout converts date patterns such as DD/MM/YYYY into Go layouts.
+func dateLayout(format string) string {
+	if format == "" {
+		return time.DateOnly
+	}
+
+	if !strings.Contains(format, "YY") {
+		return format
+	}
+
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MM", "01",
+		"DD", "02",
+	).Replace(format)
+}
+
+func isBlank(row []string) bool {
+	for _, field := range row {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,158 @@
+package statements_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+)
+
+func TestCSVParser_Parse(t *testing.T) {
+	var specs = []struct {
+		name            string
+		profile         CSVProfile
+		input           string
+		expectedEntries []Entry
+		expectedErr     error
+	}{
+		{
+			name:    "generic profile",
+			profile: DefaultCSVProfiles[0],
+			input: "date,amount,currency,description,payee,reference\n" +
+				"2024-01-05,-42.50,EUR,Groceries,Market,R1\n" +
+				"2024-01-06,1500,EUR,Salary,ACME,\n",
+			expectedEntries: []Entry{
+				{
+					BookingDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+					Amount:      -42.5,
+					Currency:    "EUR",
+					Description: "Groceries",
+					Payee:       "Market",
+					Reference:   "R1",
+				},
+				{
+					BookingDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+					Amount:      1500,
+					Currency:    "EUR",
+					Description: "Salary",
+					Payee:       "ACME",
+				},
+			},
+		},
+		{
+			name: "debit and credit columns with european format",
+			profile: CSVProfile{
+				Delimiter:        ";",
+				DateFormat:       "DD.MM.YYYY",
+				DecimalSeparator: ",",
+				Currency:         "EUR",
+				SkipRows:         1,
+				Columns: CSVColumns{
+					Date:        "Fecha",
+					ValueDate:   "Valor",
+					Debit:       "Cargo",
+					Credit:      "Abono",
+					Description: "Concepto",
+				},
+			},
+			input: "Cuenta ES00 0000;;;;\n" +
+				"Fecha;Valor;Concepto;Cargo;Abono\n" +
+				"31.01.2024;01.02.2024;Alquiler;1.200,00;\n" +
+				"\n" +
+				"01.02.2024;01.02.2024;Nómina;;2.345,67\n",
+			expectedEntries: []Entry{
+				{
+					BookingDate: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+					ValueDate:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+					Amount:      -1200,
+					Currency:    "EUR",
+					Description: "Alquiler",
+				},
+				{
+					BookingDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+					ValueDate:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+					Amount:      2345.67,
+					Currency:    "EUR",
+					Description: "Nómina",
+				},
+			},
+		},
+		{
+			name: "columns by index without header",
+			profile: CSVProfile{
+				NoHeader: true,
+				Currency: "USD",
+				Columns:  CSVColumns{Date: "0", Amount: "2", Description: "1"},
+			},
+			input: "2024-03-01,Coffee,(3.20)\n",
+			expectedEntries: []Entry{
+				{
+					BookingDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+					Amount:      -3.2,
+					Currency:    "USD",
+					Description: "Coffee",
+				},
+			},
+		},
+		{
+			name:        "unknown column",
+			profile:     CSVProfile{Columns: CSVColumns{Date: "date", Amount: "importe"}},
+			input:       "date,amount\n2024-01-01,1\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "invalid date",
+			profile:     DefaultCSVProfiles[0],
+			input:       "date,amount\n01/02/2024,1\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "missing amount columns",
+			profile:     CSVProfile{Columns: CSVColumns{Date: "date"}},
+			input:       "date\n2024-01-01\n",
+			expectedErr: ErrInvalidStatement,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			statement, err := NewCSVParser(spec.profile).Parse(strings.NewReader(spec.input))
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, spec.expectedEntries, statement.Entries)
+		})
+	}
+}
+
+func TestCSVParser_ParseEncoding(t *testing.T) {
+	input, err := charmap.Windows1252.NewEncoder().String("date,amount,description\n2024-01-01,-5,Café\n")
+	require.NoError(t, err)
+
+	profile := DefaultCSVProfiles[0]
+	profile.Encoding = "windows-1252"
+	profile.Currency = "EUR"
+
+	statement, err := NewCSVParser(profile).Parse(bytes.NewReader([]byte(input)))
+	require.NoError(t, err)
+	require.Len(t, statement.Entries, 1)
+	assert.Equal(t, "Café", statement.Entries[0].Description)
+}
+
+func TestFindCSVProfile(t *testing.T) {
+	profile, err := FindCSVProfile(DefaultCSVProfiles, "Generic-EU")
+	require.NoError(t, err)
+	assert.Equal(t, "generic-eu", profile.Name)
+
+	_, err = FindCSVProfile(DefaultCSVProfiles, "unknown")
+	assert.ErrorIs(t, err, ErrUnknownCSVProfile)
+}
@@ -0,0 +1,263 @@
+package statements
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var _ Parser = MT940Parser{}
+
+// MT940Parser parses SWIFT MT940 customer statements, including the
+// structured :86: information of the German banks. All the statements
+// of a file must belong to the same account.
+type MT940Parser struct{}
+
+// NewMT940Parser creates a new MT940Parser.
+func NewMT940Parser() MT940Parser {
+	return MT940Parser{}
+}
+
+// mt940Field represents a tag of a statement with its value, which may span several lines.
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+var (
+	// mt940TagPattern matches the beginning of a field, e.g. :61: or :28C:.
+	mt940TagPattern = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+
+	// mt940LinePattern matches the statement line of an entry (:61:).
+	mt940LinePattern = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])([A-Z])?(\d+,\d*)([A-Z]\w{3})(.*)$`)
+
+	// mt940BalancePattern matches the balances (:60F:, :62F:...), e.g. C240131EUR1000,00.
+	mt940BalancePattern = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)$`)
+)
+
+// Parse reads the entries and the closing balance of a MT940 file.
+func (p MT940Parser) Parse(r io.Reader) (Statement, error) {
+	fields, err := readMT940Fields(r)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	var (
+		statement Statement
+		accounts  int
+		entry     *Entry
+	)
+
+	flush := func() {
+		if entry != nil {
+			statement.Entries = append(statement.Entries, *entry)
+			entry = nil
+		}
+	}
+
+	for _, field := range fields {
+		switch field.tag {
+		case "25":
+			account := strings.ReplaceAll(strings.TrimSpace(field.value), " ", "")
+			if accounts > 0 && account != statement.AccountID {
+				return Statement{}, fmt.Errorf("%w: statements of several accounts found, only one is supported", ErrInvalidStatement)
+			}
+			statement.AccountID = account
+			accounts++
+		case "60F", "60M":
+			balance, err := parseMT940Balance(field.value)
+			if err != nil {
+				return Statement{}, fmt.Errorf("%w: opening balance: %w", ErrInvalidStatement, err)
+			}
+			statement.Currency = balance.Currency
+		case "61":
+			flush()
+			parsed, err := parseMT940Line(field.value, statement.Currency)
+			if err != nil {
+				return Statement{}, fmt.Errorf("%w: entry %d: %w", ErrInvalidStatement, len(statement.Entries)+1, err)
+			}
+			entry = &parsed
+		case "86":
+			if entry != nil {
+				applyMT940Information(entry, field.value)
+				flush()
+			}
+		case "62F":
+			flush()
+			balance, err := parseMT940Balance(field.value)
+			if err != nil {
+				return Statement{}, fmt.Errorf("%w: closing balance: %w", ErrInvalidStatement, err)
+			}
+			statement.Balance = &balance
+		}
+	}
+	flush()
+
+	if accounts == 0 {
+		return Statement{}, fmt.Errorf("%w: no statement found", ErrInvalidStatement)
+	}
+
+	return statement, nil
+}
+
+// readMT940Fields splits the statements into their fields, skipping the SWIFT blocks.
+func readMT940Fields(r io.Reader) ([]mt940Field, error) {
+	var (
+		fields  []mt940Field
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r ")
+
+		// the message blocks {1:...}{2:...}{4: and the -} or - terminators
+		if strings.HasPrefix(line, "{") || line == "-" || line == "-}" || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if m := mt940TagPattern.FindStringSubmatch(line); m != nil {
+			fields = append(fields, mt940Field{tag: m[1], value: m[2]})
+			continue
+		}
+
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidStatement, line)
+		}
+
+		// continuation lines of the previous field
+		fields[len(fields)-1].value += "\n" + line
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func parseMT940Balance(value string) (Balance, error) {
+	m := mt940BalancePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return Balance{}, fmt.Errorf("invalid balance %q", value)
+	}
+
+	date, err := time.Parse("060102", m[2])
+	if err != nil {
+		return Balance{}, fmt.Errorf("invalid balance date: %w", err)
+	}
+
+	amount, err := ParseAmount(m[4], ",")
+	if err != nil {
+		return Balance{}, err
+	}
+
+	if m[1] == "D" {
+		amount = -amount
+	}
+
+	return Balance{Amount: amount, Currency: m[3], Date: date}, nil
+}
+
+func parseMT940Line(value, currency string) (Entry, error) {
+	line, details, _ := strings.Cut(value, "\n")
+
+	m := mt940LinePattern.FindStringSubmatch(line)
+	if m == nil {
+		return Entry{}, fmt.Errorf("invalid statement line %q", line)
+	}
+
+	valueDate, err := time.Parse("060102", m[1])
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid value date: %w", err)
+	}
+
+	// the booking date has no year, so it is taken from the value date
+	bookingDate := valueDate
+	if m[2] != "" {
+		bookingDate, err = time.Parse("0102", m[2])
+		if err != nil {
+			return Entry{}, fmt.Errorf("invalid booking date: %w", err)
+		}
+
+		year := valueDate.Year()
+		switch {
+		case bookingDate.Month()-valueDate.Month() > 6:
+			year--
+		case valueDate.Month()-bookingDate.Month() > 6:
+			year++
+		}
+		bookingDate = time.Date(year, bookingDate.Month(), bookingDate.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	amount, err := ParseAmount(m[5], ",")
+	if err != nil {
+		return Entry{}, err
+	}
+
+	// debits and reversals of credits reduce the balance
+	if m[3] == "D" || m[3] == "RC" {
+		amount = -amount
+	}
+
+	customerRef, bankRef, _ := strings.Cut(m[7], "//")
+	if customerRef == "NONREF" {
+		customerRef = ""
+	}
+
+	entry := Entry{
+		BookingDate: bookingDate,
+		ValueDate:   valueDate,
+		Amount:      amount,
+		Currency:    currency,
+		Reference:   strings.TrimSpace(customerRef),
+		Description: strings.TrimSpace(details),
+		ExternalID:  strings.TrimSpace(bankRef),
+	}
+
+	return entry, nil
+}
+
+// mt940SubfieldPattern matches the subfields of the structured information, e.g. ?20.
+var mt940SubfieldPattern = regexp.MustCompile(`\?(\d{2})`)
+
+// applyMT940Information sets the description and payee of the entry from its :86: field.
+func applyMT940Information(entry *Entry, value string) {
+	value = strings.ReplaceAll(value, "\n", "")
+
+	// unstructured information is the description of the entry
+	if !mt940SubfieldPattern.MatchString(value) {
+		entry.Description = strings.TrimSpace(value)
+		return
+	}
+
+	var (
+		purpose []string
+		payee   []string
+	)
+
+	indexes := mt940SubfieldPattern.FindAllStringSubmatchIndex(value, -1)
+	for i, idx := range indexes {
+		end := len(value)
+		if i+1 < len(indexes) {
+			end = indexes[i+1][0]
+		}
+
+		code, content := value[idx[2]:idx[3]], strings.TrimSpace(value[idx[1]:end])
+		switch {
+		case code >= "20" && code <= "29", code >= "60" && code <= "63":
+			purpose = append(purpose, content)
+		case code == "32" || code == "33":
+			payee = append(payee, content)
+		}
+	}
+
+	if len(purpose) > 0 {
+		entry.Description = strings.Join(purpose, " ")
+	}
+	if len(payee) > 0 {
+		entry.Payee = strings.Join(payee, " ")
+	}
+}
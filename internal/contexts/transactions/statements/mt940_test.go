@@ -0,0 +1,124 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+)
+
+const mt940Statement = `{1:F01BANKDEFFAXXX0000000000}{2:O9401200240201BANKDEFFAXXX00000000002402011200N}{4:
+:20:STARTUMSE
+:25:DE89370400440532013000
+:28C:00001/001
+:60F:C231229EUR1000,00
+:61:2401020102DR50,00NTRFNONREF//BANKREF1
+:86:166?00SEPA-UEBERWEISUNG?20Invoice 42 ?21January?32Power Company
+:61:2312291229CR1200,00NMSCSALARY-01
+:86:Salary December
+:61:2401030103RD5,00NCHGNONREF
+:62F:C240131EUR2145,00
+-}`
+
+func TestMT940Parser_Parse(t *testing.T) {
+	var specs = []struct {
+		name              string
+		input             string
+		expectedStatement Statement
+		expectedErr       error
+	}{
+		{
+			name:  "statement with structured and unstructured information",
+			input: mt940Statement,
+			expectedStatement: Statement{
+				AccountID: "DE89370400440532013000",
+				Currency:  "EUR",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						ValueDate:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						Amount:      -50,
+						Currency:    "EUR",
+						Description: "Invoice 42 January",
+						Payee:       "Power Company",
+						ExternalID:  "BANKREF1",
+					},
+					{
+						BookingDate: time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC),
+						ValueDate:   time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC),
+						Amount:      1200,
+						Currency:    "EUR",
+						Description: "Salary December",
+						Reference:   "SALARY-01",
+					},
+					{
+						BookingDate: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+						ValueDate:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+						Amount:      5,
+						Currency:    "EUR",
+					},
+				},
+				Balance: &Balance{
+					Amount:   2145,
+					Currency: "EUR",
+					Date:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "booking date in the next year of the value date",
+			input: ":20:X\n:25:NL91ABNA0417164300\n:60F:C231231EUR0,00\n" +
+				":61:2312310102D10,00NTRFREF1\n:62F:D240102EUR10,00\n",
+			expectedStatement: Statement{
+				AccountID: "NL91ABNA0417164300",
+				Currency:  "EUR",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						ValueDate:   time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+						Amount:      -10,
+						Currency:    "EUR",
+						Reference:   "REF1",
+					},
+				},
+				Balance: &Balance{
+					Amount:   -10,
+					Currency: "EUR",
+					Date:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:        "several accounts",
+			input:       ":20:A\n:25:ACCOUNT1\n-\n:20:B\n:25:ACCOUNT2\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "invalid statement line",
+			input:       ":20:A\n:25:ACCOUNT1\n:61:INVALID\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "not a mt940 file",
+			input:       "date,amount\n",
+			expectedErr: ErrInvalidStatement,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			statement, err := NewMT940Parser().Parse(strings.NewReader(spec.input))
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, spec.expectedStatement, statement)
+		})
+	}
+}
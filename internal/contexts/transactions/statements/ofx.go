@@ -0,0 +1,316 @@
+package statements
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+var _ Parser = OFXParser{}
+
+// OFXParser parses OFX 1.x (SGML) and 2.x (XML) statements, also known as QFX.
+// Bank and credit card statements are supported, with a single account per file.
+type OFXParser struct{}
+
+// NewOFXParser creates a new OFXParser.
+func NewOFXParser() OFXParser {
+	return OFXParser{}
+}
+
+// Parse reads the transactions and the ledger balance of an OFX statement.
+func (p OFXParser) Parse(r io.Reader) (Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	start := bytes.Index(bytes.ToUpper(data), []byte("<OFX>"))
+	if start < 0 {
+		return Statement{}, fmt.Errorf("%w: missing OFX element", ErrInvalidStatement)
+	}
+
+	body, err := decodeOFX(data[:start], data[start:])
+	if err != nil {
+		return Statement{}, err
+	}
+
+	root := parseOFXTree(body)
+
+	var statements []*ofxNode
+	for _, name := range []string{"STMTRS", "CCSTMTRS"} {
+		statements = append(statements, root.findAll(name)...)
+	}
+
+	switch len(statements) {
+	case 0:
+		return Statement{}, fmt.Errorf("%w: no statement found", ErrInvalidStatement)
+	case 1:
+	default:
+		return Statement{}, fmt.Errorf("%w: %d account statements found, only one is supported", ErrInvalidStatement, len(statements))
+	}
+
+	return parseOFXStatement(statements[0])
+}
+
+func parseOFXStatement(node *ofxNode) (Statement, error) {
+	statement := Statement{
+		AccountID: node.value("ACCTID"),
+		Currency:  node.value("CURDEF"),
+	}
+
+	for i, trn := range node.findAll("STMTTRN") {
+		entry, err := parseOFXTransaction(trn, statement.Currency)
+		if err != nil {
+			return Statement{}, fmt.Errorf("%w: transaction %d: %w", ErrInvalidStatement, i+1, err)
+		}
+		statement.Entries = append(statement.Entries, entry)
+	}
+
+	if balance := node.find("LEDGERBAL"); balance != nil {
+		amount, err := parseOFXAmount(balance.value("BALAMT"))
+		if err != nil {
+			return Statement{}, fmt.Errorf("%w: ledger balance: %w", ErrInvalidStatement, err)
+		}
+
+		date, err := parseOFXDate(balance.value("DTASOF"))
+		if err != nil {
+			return Statement{}, fmt.Errorf("%w: ledger balance: %w", ErrInvalidStatement, err)
+		}
+
+		statement.Balance = &Balance{
+			Amount:   amount,
+			Currency: statement.Currency,
+			Date:     date,
+		}
+	}
+
+	return statement, nil
+}
+
+func parseOFXTransaction(node *ofxNode, currency string) (Entry, error) {
+	var (
+		entry Entry
+		err   error
+	)
+
+	entry.BookingDate, err = parseOFXDate(node.value("DTPOSTED"))
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid posted date: %w", err)
+	}
+
+	if value := node.value("DTAVAIL"); value != "" {
+		entry.ValueDate, err = parseOFXDate(value)
+		if err != nil {
+			return Entry{}, fmt.Errorf("invalid available date: %w", err)
+		}
+	}
+
+	entry.Amount, err = parseOFXAmount(node.value("TRNAMT"))
+	if err != nil {
+		return Entry{}, err
+	}
+
+	// the amount is in the CURRENCY of the transaction when it differs from the default one,
+	// while the ORIGCURRENCY of an amount converted to the default one is only informative
+	entry.Currency = currency
+	if converted := node.find("CURRENCY"); converted != nil {
+		if symbol := converted.value("CURSYM"); symbol != "" {
+			entry.Currency = symbol
+		}
+	}
+
+	entry.ExternalID = node.value("FITID")
+	entry.Payee = node.value("NAME")
+	entry.Description = node.value("MEMO")
+	if entry.Description == "" {
+		entry.Description = entry.Payee
+	}
+	entry.Reference = node.value("CHECKNUM")
+	if entry.Reference == "" {
+		entry.Reference = node.value("REFNUM")
+	}
+
+	return entry, nil
+}
+
+// ofxDatePattern matches the OFX datetimes, e.g. 20240131120000.000[-5:EST].
+var ofxDatePattern = regexp.MustCompile(`^(\d{8})(\d{4}(\d{2})?)?(\.\d+)?(\[([+-]?\d+(\.\d+)?)(:[^\]]*)?\])?$`)
+
+// parseOFXDate parses an OFX datetime, in UTC unless a time zone offset is given.
+func parseOFXDate(value string) (time.Time, error) {
+	m := ofxDatePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	digits := m[1] + m[2]
+	layout := "20060102150405"[:len(digits)]
+
+	location := time.UTC
+	if m[6] != "" {
+		hours, err := strconv.ParseFloat(m[6], 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time zone %q", m[6])
+		}
+		location = time.FixedZone(strings.TrimPrefix(m[8], ":"), int(hours*3600))
+	}
+
+	return time.ParseInLocation(layout, digits, location)
+}
+
+// parseOFXAmount parses an OFX amount, whose decimal separator may be a dot or a comma.
+func parseOFXAmount(value string) (float64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("amount is required")
+	}
+
+	if strings.Contains(value, ",") && !strings.Contains(value, ".") {
+		return ParseAmount(value, ",")
+	}
+	return ParseAmount(value, ".")
+}
+
+// decodeOFX converts the body to UTF-8 according to the charset of the OFX 1.x headers.
+func decodeOFX(header, body []byte) (string, error) {
+	var charset string
+	for _, line := range strings.Split(string(header), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok && strings.EqualFold(key, "CHARSET") {
+			charset = strings.ToLower(strings.TrimSpace(value))
+		}
+	}
+
+	switch charset {
+	case "", "none", "utf-8", "csunicode":
+		return string(body), nil
+	case "1252":
+		charset = "windows-1252"
+	}
+
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return "", fmt.Errorf("%w: unsupported charset %s", ErrInvalidStatement, charset)
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+
+	return string(decoded), nil
+}
+
+// ofxNode represents an OFX element, either an aggregate with children or a leaf with a value.
+type ofxNode struct {
+	name     string
+	text     string
+	children []*ofxNode
+}
+
+// parseOFXTree builds the elements tree of an OFX body. The closing tags are optional
+// for the leaf elements in OFX 1.x, so any element followed by text is a leaf.
+func parseOFXTree(body string) *ofxNode {
+	var (
+		root     = &ofxNode{}
+		stack    = []*ofxNode{root}
+		lastLeaf string
+		pos      int
+	)
+
+	for pos < len(body) {
+		open := strings.IndexByte(body[pos:], '<')
+		if open < 0 {
+			break
+		}
+
+		top := stack[len(stack)-1]
+		if text := strings.TrimSpace(body[pos : pos+open]); text != "" && top != root && len(top.children) == 0 {
+			top.text = html.UnescapeString(text)
+			stack = stack[:len(stack)-1]
+			lastLeaf = top.name
+		}
+
+		pos += open
+		end := strings.IndexByte(body[pos:], '>')
+		if end < 0 {
+			break
+		}
+
+		tag := strings.TrimSpace(body[pos+1 : pos+end])
+		pos += end + 1
+
+		switch {
+		case tag == "" || tag[0] == '?' || tag[0] == '!':
+			continue
+		case tag[0] == '/':
+			name := strings.ToUpper(tag[1:])
+			if name == lastLeaf {
+				lastLeaf = ""
+				continue
+			}
+			// close the aggregate along with any unclosed element inside it
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			selfClosing := strings.HasSuffix(tag, "/")
+			node := &ofxNode{name: strings.ToUpper(strings.TrimSuffix(tag, "/"))}
+			top = stack[len(stack)-1]
+			top.children = append(top.children, node)
+			if !selfClosing {
+				stack = append(stack, node)
+			}
+			lastLeaf = ""
+		}
+	}
+
+	return root
+}
+
+// find returns the first element with the given name within the node.
+func (n *ofxNode) find(name string) *ofxNode {
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+
+	for _, child := range n.children {
+		if found := child.find(name); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// findAll returns the outermost elements with the given name within the node.
+func (n *ofxNode) findAll(name string) []*ofxNode {
+	var found []*ofxNode
+	for _, child := range n.children {
+		if child.name == name {
+			found = append(found, child)
+			continue
+		}
+		found = append(found, child.findAll(name)...)
+	}
+	return found
+}
+
+// value returns the value of the first leaf with the given name within the node.
+func (n *ofxNode) value(name string) string {
+	if node := n.find(name); node != nil {
+		return node.text
+	}
+	return ""
+}
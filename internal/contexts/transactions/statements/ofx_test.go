@@ -0,0 +1,247 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+)
+
+const ofxSGMLStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240201120000<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>1
+<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM>
+<BANKID>121000248
+<ACCTID>123456789
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20240101
+<DTEND>20240131
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240105120000.000[-5:EST]
+<TRNAMT>-42.50
+<FITID>2024010501
+<NAME>GROCERY MARKET
+<MEMO>Groceries &amp; more
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CHECK
+<DTPOSTED>20240110
+<DTAVAIL>20240111
+<TRNAMT>-100,00
+<FITID>2024011001
+<CHECKNUM>1001
+<NAME>LANDLORD
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>1857.50
+<DTASOF>20240131
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const ofxXMLStatement = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <CCACCTFROM><ACCTID>4111111111111111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20240101</DTSTART>
+          <DTEND>20240131</DTEND>
+          <STMTTRN>
+            <TRNTYPE>CREDIT</TRNTYPE>
+            <DTPOSTED>20240115083000</DTPOSTED>
+            <TRNAMT>25.00</TRNAMT>
+            <FITID>A1</FITID>
+            <PAYEE><NAME>REFUND SHOP</NAME><ADDR1>Main St</ADDR1></PAYEE>
+            <MEMO></MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL><BALAMT>0</BALAMT><DTASOF>20240131120000[+1:CET]</DTASOF></LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+const ofxForeignCurrencyStatement = `<OFX>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>EUR
+<CCACCTFROM><ACCTID>4111111111111111</CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240120
+<TRNAMT>-45.90
+<FITID>B1
+<NAME>BOOKSHOP LONDON
+<ORIGCURRENCY><CURRATE>1.17<CURSYM>GBP</ORIGCURRENCY>
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240122
+<TRNAMT>-30.00
+<FITID>B2
+<NAME>HOTEL NEW YORK
+<CURRENCY><CURRATE>0.92<CURSYM>USD</CURRENCY>
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>-120.50<DTASOF>20240131</LEDGERBAL>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestOFXParser_Parse(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	cet := time.FixedZone("CET", 3600)
+
+	var specs = []struct {
+		name              string
+		input             string
+		expectedStatement Statement
+		expectedErr       error
+	}{
+		{
+			name:  "ofx 1.x sgml bank statement",
+			input: ofxSGMLStatement,
+			expectedStatement: Statement{
+				AccountID: "123456789",
+				Currency:  "USD",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 5, 12, 0, 0, 0, est),
+						Amount:      -42.5,
+						Currency:    "USD",
+						Description: "Groceries & more",
+						Payee:       "GROCERY MARKET",
+						ExternalID:  "2024010501",
+					},
+					{
+						BookingDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+						ValueDate:   time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+						Amount:      -100,
+						Currency:    "USD",
+						Description: "LANDLORD",
+						Payee:       "LANDLORD",
+						Reference:   "1001",
+						ExternalID:  "2024011001",
+					},
+				},
+				Balance: &Balance{
+					Amount:   1857.5,
+					Currency: "USD",
+					Date:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:  "ofx 2.x xml credit card statement",
+			input: ofxXMLStatement,
+			expectedStatement: Statement{
+				AccountID: "4111111111111111",
+				Currency:  "EUR",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC),
+						Amount:      25,
+						Currency:    "EUR",
+						Description: "REFUND SHOP",
+						Payee:       "REFUND SHOP",
+						ExternalID:  "A1",
+					},
+				},
+				Balance: &Balance{
+					Currency: "EUR",
+					Date:     time.Date(2024, 1, 31, 12, 0, 0, 0, cet),
+				},
+			},
+		},
+		{
+			name:  "ofx entries in a foreign currency and a negative credit card balance",
+			input: ofxForeignCurrencyStatement,
+			expectedStatement: Statement{
+				AccountID: "4111111111111111",
+				Currency:  "EUR",
+				Entries: []Entry{
+					{
+						BookingDate: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+						Amount:      -45.9,
+						Currency:    "EUR",
+						Description: "BOOKSHOP LONDON",
+						Payee:       "BOOKSHOP LONDON",
+						ExternalID:  "B1",
+					},
+					{
+						BookingDate: time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC),
+						Amount:      -30,
+						Currency:    "USD",
+						Description: "HOTEL NEW YORK",
+						Payee:       "HOTEL NEW YORK",
+						ExternalID:  "B2",
+					},
+				},
+				Balance: &Balance{
+					Amount:   -120.5,
+					Currency: "EUR",
+					Date:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:        "not an ofx file",
+			input:       "date,amount\n2024-01-01,1\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "multiple account statements",
+			input:       "<OFX><STMTRS><CURDEF>USD</STMTRS><STMTRS><CURDEF>USD</STMTRS></OFX>",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "invalid posted date",
+			input:       "<OFX><STMTRS><STMTTRN><DTPOSTED>2024-01-01<TRNAMT>1</STMTTRN></STMTRS></OFX>",
+			expectedErr: ErrInvalidStatement,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			statement, err := NewOFXParser().Parse(strings.NewReader(spec.input))
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, spec.expectedStatement, statement)
+		})
+	}
+}
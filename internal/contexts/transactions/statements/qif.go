@@ -0,0 +1,617 @@
+package statements
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// QIFAccountType represents the type of a QIF account.
+type QIFAccountType string
+
+const (
+	QIFBank           QIFAccountType = "Bank"
+	QIFCash           QIFAccountType = "Cash"
+	QIFCreditCard     QIFAccountType = "CCard"
+	QIFInvestment     QIFAccountType = "Invst"
+	QIFOtherAsset     QIFAccountType = "Oth A"
+	QIFOtherLiability QIFAccountType = "Oth L"
+)
+
+// qifAccountTypes are the QIF account types holding transactions.
+var qifAccountTypes = []QIFAccountType{QIFBank, QIFCash, QIFCreditCard, QIFInvestment, QIFOtherAsset, QIFOtherLiability}
+
+// parseQIFAccountType returns the account type of a QIF header or account record, if supported.
+func parseQIFAccountType(value string) (QIFAccountType, bool) {
+	value = strings.TrimSpace(value)
+	for _, t := range qifAccountTypes {
+		if strings.EqualFold(value, string(t)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// QIFDateOrder represents the order of the day and month of the QIF dates.
+type QIFDateOrder string
+
+const (
+	// QIFMonthDayYear is the order of the dates exported by US software, e.g. 12/31/2024.
+	QIFMonthDayYear QIFDateOrder = "mdy"
+
+	// QIFDayMonthYear is the order of the dates exported by most european software, e.g. 31/12/2024.
+	QIFDayMonthYear QIFDateOrder = "dmy"
+)
+
+// QIFOptions describes the conventions of a QIF file, which the format itself does not declare.
+type QIFOptions struct {
+	// DateOrder is the order of the dates, month first by default.
+	DateOrder QIFDateOrder
+
+	// DecimalSeparator is the decimal separator of the amounts, a dot by default.
+	DecimalSeparator string
+
+	// Encoding is the character encoding of the file, UTF-8 by default.
+	Encoding string
+
+	// Currency is the currency of the entries, since QIF files have none.
+	Currency string
+
+	// Account is the name of the account of the transactions not preceded
+	// by an account record, e.g. in single account exports.
+	Account string
+}
+
+// Validate validates the QIF options.
+func (o QIFOptions) Validate() error {
+	switch o.DateOrder {
+	case "", QIFMonthDayYear, QIFDayMonthYear:
+	default:
+		return fmt.Errorf("%w: unsupported date order %s", ErrInvalidStatement, o.DateOrder)
+	}
+
+	switch o.DecimalSeparator {
+	case "", ".", ",":
+	default:
+		return fmt.Errorf("%w: decimal separator must be a dot or a comma", ErrInvalidStatement)
+	}
+
+	if o.Encoding != "" {
+		if _, err := htmlindex.Get(o.Encoding); err != nil {
+			return fmt.Errorf("%w: unsupported encoding %s", ErrInvalidStatement, o.Encoding)
+		}
+	}
+
+	return nil
+}
+
+// QIFAccount represents an account of a QIF file and its transactions.
+type QIFAccount struct {
+	Name        string
+	Type        QIFAccountType
+	Description string
+	Statement   Statement
+}
+
+// QIFCategory represents a category of a QIF file.
+type QIFCategory struct {
+	Name        string
+	Description string
+	Income      bool
+}
+
+// QIFFile represents the accounts and categories of a QIF file.
+type QIFFile struct {
+	Accounts   []QIFAccount
+	Categories []QIFCategory
+}
+
+// Account returns the account with the given name, if any.
+func (f *QIFFile) Account(name string) (*QIFAccount, bool) {
+	for i := range f.Accounts {
+		if strings.EqualFold(f.Accounts[i].Name, name) {
+			return &f.Accounts[i], true
+		}
+	}
+	return nil, false
+}
+
+// account returns the account with the given name, adding it when missing.
+func (f *QIFFile) account(name string, accountType QIFAccountType, currency string) *QIFAccount {
+	if account, ok := f.Account(name); ok {
+		if account.Type == "" {
+			account.Type = accountType
+		}
+		return account
+	}
+
+	f.Accounts = append(f.Accounts, QIFAccount{
+		Name:      name,
+		Type:      accountType,
+		Statement: Statement{Currency: currency},
+	})
+	return &f.Accounts[len(f.Accounts)-1]
+}
+
+// qifField is a line of a QIF record, made of a one letter code and a value.
+type qifField struct {
+	code  byte
+	value string
+}
+
+// ReadQIF reads the accounts, transactions and categories of a QIF file.
+// Bank, cash, credit card, investment and other asset and liability accounts
+// are supported, while memorized transactions, classes and securities are ignored.
+func ReadQIF(r io.Reader, opts QIFOptions) (QIFFile, error) {
+	if err := opts.Validate(); err != nil {
+		return QIFFile{}, err
+	}
+
+	if opts.Encoding != "" {
+		enc, _ := htmlindex.Get(opts.Encoding)
+		r = enc.NewDecoder().Reader(r)
+	}
+
+	var (
+		file       QIFFile
+		section    string
+		autoSwitch bool
+		current    string
+		record     []qifField
+		lineNumber int
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r\n\t ")
+		if lineNumber == 1 {
+			// drop the UTF-8 byte order mark
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		switch {
+		case line == "":
+			continue
+
+		case strings.HasPrefix(line, "!"):
+			header := strings.ToLower(strings.TrimSpace(line[1:]))
+			switch {
+			case header == "option:autoswitch":
+				autoSwitch = true
+			case header == "clear:autoswitch":
+				autoSwitch = false
+			case header == "account":
+				section = "account"
+			case strings.HasPrefix(header, "type:"):
+				section = strings.TrimSpace(header[len("type:"):])
+				if accountType, ok := parseQIFAccountType(section); ok {
+					// transactions without account record belong to the default account
+					if current == "" {
+						current = opts.Account
+						if current == "" {
+							current = string(accountType)
+						}
+					}
+					file.account(current, accountType, opts.Currency)
+				}
+			default:
+				section = header
+			}
+			record = nil
+
+		case line == "^":
+			if err := readQIFRecord(&file, section, &current, autoSwitch, record, opts); err != nil {
+				return QIFFile{}, fmt.Errorf("%w: record ending at line %d: %w", ErrInvalidStatement, lineNumber, err)
+			}
+			record = nil
+
+		default:
+			record = append(record, qifField{code: line[0], value: strings.TrimSpace(line[1:])})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return QIFFile{}, fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+
+	if len(record) > 0 {
+		if err := readQIFRecord(&file, section, &current, autoSwitch, record, opts); err != nil {
+			return QIFFile{}, fmt.Errorf("%w: last record: %w", ErrInvalidStatement, err)
+		}
+	}
+
+	return file, nil
+}
+
+func readQIFRecord(file *QIFFile, section string, current *string, autoSwitch bool, record []qifField, opts QIFOptions) error {
+	if len(record) == 0 {
+		return nil
+	}
+
+	switch section {
+	case "account":
+		var name, description string
+		var accountType QIFAccountType
+		for _, field := range record {
+			switch field.code {
+			case 'N':
+				name = field.value
+			case 'T':
+				accountType, _ = parseQIFAccountType(field.value)
+			case 'D':
+				description = field.value
+			}
+		}
+
+		if name == "" {
+			return fmt.Errorf("account name is required")
+		}
+
+		account := file.account(name, accountType, opts.Currency)
+		if account.Description == "" {
+			account.Description = description
+		}
+
+		// account lists only declare the accounts, while
+		// the other account records select the following transactions
+		if !autoSwitch {
+			*current = account.Name
+		}
+		return nil
+
+	case "cat":
+		category := QIFCategory{}
+		for _, field := range record {
+			switch field.code {
+			case 'N':
+				category.Name = field.value
+			case 'D':
+				category.Description = field.value
+			case 'I':
+				category.Income = true
+			case 'E':
+				category.Income = false
+			}
+		}
+
+		if category.Name == "" {
+			return fmt.Errorf("category name is required")
+		}
+
+		file.Categories = append(file.Categories, category)
+		return nil
+	}
+
+	accountType, ok := parseQIFAccountType(section)
+	if !ok {
+		// memorized transactions, classes, securities, prices...
+		return nil
+	}
+
+	account := file.account(*current, accountType, opts.Currency)
+
+	var (
+		entry Entry
+		err   error
+	)
+	if accountType == QIFInvestment {
+		entry, err = parseQIFInvestmentEntry(record, opts)
+	} else {
+		entry, err = parseQIFEntry(record, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	entry.Currency = account.Statement.Currency
+	account.Statement.Entries = append(account.Statement.Entries, entry)
+	return nil
+}
+
+// parseQIFEntry parses a bank, cash, credit card or other account transaction.
+func parseQIFEntry(record []qifField, opts QIFOptions) (Entry, error) {
+	var (
+		entry     Entry
+		hasAmount bool
+		err       error
+	)
+
+	for _, field := range record {
+		switch field.code {
+		case 'D':
+			entry.BookingDate, err = parseQIFDate(field.value, opts.DateOrder)
+		case 'T', 'U':
+			if hasAmount {
+				continue
+			}
+			entry.Amount, err = ParseAmount(field.value, opts.DecimalSeparator)
+			hasAmount = true
+		case 'P':
+			entry.Payee = field.value
+		case 'M':
+			entry.Description = field.value
+		case 'N':
+			entry.Reference = field.value
+		case 'L':
+			entry.Category, entry.Transfer = parseQIFCategory(field.value)
+		case 'S':
+			split := Split{}
+			split.Category, split.Transfer = parseQIFCategory(field.value)
+			entry.Splits = append(entry.Splits, split)
+		case 'E':
+			if len(entry.Splits) > 0 {
+				entry.Splits[len(entry.Splits)-1].Memo = field.value
+			}
+		case '$':
+			if len(entry.Splits) > 0 {
+				entry.Splits[len(entry.Splits)-1].Amount, err = ParseAmount(field.value, opts.DecimalSeparator)
+			}
+		}
+
+		if err != nil {
+			return Entry{}, err
+		}
+	}
+
+	if entry.BookingDate.IsZero() {
+		return Entry{}, fmt.Errorf("date is required")
+	}
+
+	if !hasAmount {
+		return Entry{}, fmt.Errorf("amount is required")
+	}
+
+	return entry, nil
+}
+
+// qifInvestmentInflows and qifInvestmentOutflows are the investment actions
+// moving cash into and out of the investment account. The actions ending in X
+// move the cash to or from another account instead, and the rest, e.g.
+// reinvestments or share transfers, do not involve cash.
+var (
+	qifInvestmentInflows = map[string]bool{
+		"sell": true, "div": true, "intinc": true, "cglong": true, "cgmid": true, "cgshort": true,
+		"rtrncap": true, "miscinc": true, "xin": true, "shtsell": true, "contrib": true,
+	}
+	qifInvestmentOutflows = map[string]bool{
+		"buy": true, "miscexp": true, "xout": true, "margint": true, "cvrshrt": true, "withdrwl": true,
+	}
+)
+
+// parseQIFInvestmentEntry parses an investment account transaction as its cash
+// movement, describing the action, the security, the quantity and the price.
+func parseQIFInvestmentEntry(record []qifField, opts QIFOptions) (Entry, error) {
+	var (
+		entry                             Entry
+		action, security, quantity, price string
+		memo                              string
+		amount                            float64
+		hasAmount                         bool
+		err                               error
+	)
+
+	for _, field := range record {
+		switch field.code {
+		case 'D':
+			entry.BookingDate, err = parseQIFDate(field.value, opts.DateOrder)
+		case 'N':
+			action = field.value
+		case 'Y':
+			security = field.value
+		case 'Q':
+			quantity = field.value
+		case 'I':
+			price = field.value
+		case 'T', 'U':
+			if hasAmount {
+				continue
+			}
+			amount, err = ParseAmount(field.value, opts.DecimalSeparator)
+			hasAmount = true
+		case 'P':
+			entry.Payee = field.value
+		case 'M':
+			memo = field.value
+		case 'L':
+			entry.Category, entry.Transfer = parseQIFCategory(field.value)
+		}
+
+		if err != nil {
+			return Entry{}, err
+		}
+	}
+
+	if entry.BookingDate.IsZero() {
+		return Entry{}, fmt.Errorf("date is required")
+	}
+
+	key := strings.ToLower(action)
+	switch {
+	case key == "cash":
+		entry.Amount = amount
+	case qifInvestmentInflows[key]:
+		entry.Amount = abs(amount)
+	case qifInvestmentOutflows[key]:
+		entry.Amount = -abs(amount)
+	default:
+		entry.Amount = 0
+	}
+
+	description := []string{action, quantity, security}
+	if price != "" {
+		description = append(description, "@ "+price)
+	}
+	entry.Description = strings.Join(strings.Fields(strings.Join(description, " ")), " ")
+	if memo != "" {
+		entry.Description = strings.TrimPrefix(entry.Description+" - "+memo, " - ")
+	}
+
+	return entry, nil
+}
+
+// parseQIFCategory splits a category field into its category or, when enclosed
+// in brackets, its transfer account. The class after the slash is dropped.
+func parseQIFCategory(value string) (category, transfer string) {
+	if i := strings.Index(value, "/"); i >= 0 && !strings.HasPrefix(value, "[") {
+		value = value[:i]
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") {
+		if i := strings.Index(value, "]"); i > 0 {
+			return "", strings.TrimSpace(value[1:i])
+		}
+	}
+
+	return value, ""
+}
+
+var qifISODate = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}$`)
+
+// parseQIFDate parses a QIF date such as 12/31/2024, 12/31/99 or 12/31'24,
+// where the apostrophe marks the years since 2000.
+func parseQIFDate(value string, order QIFDateOrder) (time.Time, error) {
+	value = strings.ReplaceAll(value, " ", "")
+	if qifISODate.MatchString(value) {
+		return time.Parse("2006-1-2", value)
+	}
+
+	since2000 := strings.Contains(value, "'")
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '\''
+	})
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		numbers[i] = n
+	}
+
+	month, day, year := numbers[0], numbers[1], numbers[2]
+	if order == QIFDayMonthYear {
+		month, day = day, month
+	}
+
+	if len(parts[2]) <= 2 {
+		switch {
+		case since2000, year < 70:
+			year += 2000
+		default:
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	return date, nil
+}
+
+// WriteQIF writes the categories, accounts and transactions of a QIF file.
+// Investment transactions are written as cash movements, since only
+// their cash effect is kept.
+func WriteQIF(w io.Writer, file QIFFile, opts QIFOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	layout := "01/02/2006"
+	if opts.DateOrder == QIFDayMonthYear {
+		layout = "02/01/2006"
+	}
+
+	amount := func(v float64) string {
+		s := strconv.FormatFloat(v, 'f', 2, 64)
+		if opts.DecimalSeparator == "," {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	}
+
+	bw := bufio.NewWriter(w)
+	field := func(code byte, value string) {
+		if value == "" {
+			return
+		}
+		// values cannot span several lines
+		value = strings.Join(strings.Fields(value), " ")
+		bw.WriteByte(code)
+		bw.WriteString(value)
+		bw.WriteByte('\n')
+	}
+	category := func(category, transfer string) string {
+		if transfer != "" {
+			return "[" + transfer + "]"
+		}
+		return category
+	}
+
+	if len(file.Categories) > 0 {
+		bw.WriteString("!Type:Cat\n")
+		for _, c := range file.Categories {
+			field('N', c.Name)
+			field('D', c.Description)
+			if c.Income {
+				bw.WriteString("I\n")
+			} else {
+				bw.WriteString("E\n")
+			}
+			bw.WriteString("^\n")
+		}
+	}
+
+	for _, account := range file.Accounts {
+		accountType := account.Type
+		if accountType == "" {
+			accountType = QIFBank
+		}
+
+		bw.WriteString("!Account\n")
+		field('N', account.Name)
+		field('T', string(accountType))
+		field('D', account.Description)
+		bw.WriteString("^\n")
+		bw.WriteString("!Type:" + string(accountType) + "\n")
+
+		for _, entry := range account.Statement.Entries {
+			field('D', entry.BookingDate.Format(layout))
+			if accountType == QIFInvestment {
+				field('N', "Cash")
+			}
+			field('T', amount(entry.Amount))
+			field('P', entry.Payee)
+			field('M', entry.Description)
+			if accountType != QIFInvestment {
+				field('N', entry.Reference)
+			}
+			field('L', category(entry.Category, entry.Transfer))
+			if accountType != QIFInvestment {
+				for _, split := range entry.Splits {
+					field('S', category(split.Category, split.Transfer))
+					field('E', split.Memo)
+					field('$', amount(split.Amount))
+				}
+			}
+			bw.WriteString("^\n")
+		}
+	}
+
+	return bw.Flush()
+}
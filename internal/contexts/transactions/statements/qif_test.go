@@ -0,0 +1,244 @@
+package statements_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+)
+
+const qifQuickenExport = `!Option:AutoSwitch
+!Account
+NChecking
+TBank
+DMain account
+^
+NBrokerage
+TInvst
+^
+!Clear:AutoSwitch
+!Type:Cat
+NFood:Groceries
+DWeekly shopping
+E
+^
+NSalary
+I
+^
+!Account
+NChecking
+TBank
+^
+!Type:Bank
+D1/ 5'24
+T-1,042.50
+PGrocery Market
+MWeekly shopping
+N1001
+SFood:Groceries
+EFruit
+$-42.50
+S[Brokerage]
+$-1,000.00
+^
+D01/31/2024
+T2,500.00
+PACME Corp
+LSalary/Work
+^
+!Account
+NBrokerage
+TInvst
+^
+!Type:Invst
+D1/6'24
+NBuy
+YACME
+I12.5
+Q10
+T125.00
+^
+D1/20'24
+NDiv
+YACME
+T3.10
+MQuarterly
+^
+D1/21'24
+NReinvDiv
+YACME
+Q0.25
+T3.10
+^
+`
+
+const qifSingleAccountExport = `!Type:CCard
+D31.01.99
+T-19,99
+PBookshop
+L[Visa]
+^
+`
+
+func TestReadQIF(t *testing.T) {
+	var specs = []struct {
+		name         string
+		input        string
+		opts         QIFOptions
+		expectedFile QIFFile
+		expectedErr  error
+	}{
+		{
+			name:  "quicken export with several accounts",
+			input: qifQuickenExport,
+			opts:  QIFOptions{Currency: "USD"},
+			expectedFile: QIFFile{
+				Accounts: []QIFAccount{
+					{
+						Name:        "Checking",
+						Type:        QIFBank,
+						Description: "Main account",
+						Statement: Statement{
+							Currency: "USD",
+							Entries: []Entry{
+								{
+									BookingDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+									Amount:      -1042.5,
+									Currency:    "USD",
+									Description: "Weekly shopping",
+									Payee:       "Grocery Market",
+									Reference:   "1001",
+									Splits: []Split{
+										{Category: "Food:Groceries", Memo: "Fruit", Amount: -42.5},
+										{Transfer: "Brokerage", Amount: -1000},
+									},
+								},
+								{
+									BookingDate: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+									Amount:      2500,
+									Currency:    "USD",
+									Payee:       "ACME Corp",
+									Category:    "Salary",
+								},
+							},
+						},
+					},
+					{
+						Name: "Brokerage",
+						Type: QIFInvestment,
+						Statement: Statement{
+							Currency: "USD",
+							Entries: []Entry{
+								{
+									BookingDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+									Amount:      -125,
+									Currency:    "USD",
+									Description: "Buy 10 ACME @ 12.5",
+								},
+								{
+									BookingDate: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+									Amount:      3.1,
+									Currency:    "USD",
+									Description: "Div ACME - Quarterly",
+								},
+								{
+									BookingDate: time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC),
+									Currency:    "USD",
+									Description: "ReinvDiv 0.25 ACME",
+								},
+							},
+						},
+					},
+				},
+				Categories: []QIFCategory{
+					{Name: "Food:Groceries", Description: "Weekly shopping"},
+					{Name: "Salary", Income: true},
+				},
+			},
+		},
+		{
+			name:  "single account export with european conventions",
+			input: qifSingleAccountExport,
+			opts:  QIFOptions{DateOrder: QIFDayMonthYear, DecimalSeparator: ",", Currency: "EUR", Account: "Credit card"},
+			expectedFile: QIFFile{
+				Accounts: []QIFAccount{
+					{
+						Name: "Credit card",
+						Type: QIFCreditCard,
+						Statement: Statement{
+							Currency: "EUR",
+							Entries: []Entry{
+								{
+									BookingDate: time.Date(1999, 1, 31, 0, 0, 0, 0, time.UTC),
+									Amount:      -19.99,
+									Currency:    "EUR",
+									Payee:       "Bookshop",
+									Transfer:    "Visa",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "invalid date",
+			input:       "!Type:Bank\nD13/31/2024\nT1\n^\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "missing amount",
+			input:       "!Type:Cash\nD01/31/2024\nPShop\n^\n",
+			expectedErr: ErrInvalidStatement,
+		},
+		{
+			name:        "unsupported date order",
+			input:       qifSingleAccountExport,
+			opts:        QIFOptions{DateOrder: "ymd"},
+			expectedErr: ErrInvalidStatement,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			file, err := ReadQIF(strings.NewReader(spec.input), spec.opts)
+			if spec.expectedErr != nil {
+				assert.ErrorIs(t, err, spec.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, spec.expectedFile, file)
+		})
+	}
+}
+
+func TestWriteQIF(t *testing.T) {
+	opts := QIFOptions{Currency: "USD"}
+
+	file, err := ReadQIF(strings.NewReader(qifQuickenExport), opts)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteQIF(&buf, file, opts))
+
+	assert.Contains(t, buf.String(), "!Account\nNChecking\nTBank\nDMain account\n^\n!Type:Bank\n")
+	assert.Contains(t, buf.String(), "SFood:Groceries\nEFruit\n$-42.50\nS[Brokerage]\n$-1000.00\n^\n")
+
+	written, err := ReadQIF(&buf, opts)
+	require.NoError(t, err)
+	assert.Equal(t, file.Categories, written.Categories)
+	require.Len(t, written.Accounts, 2)
+	assert.Equal(t, file.Accounts[0], written.Accounts[0])
+
+	// investment transactions are written as cash movements
+	for i, entry := range written.Accounts[1].Statement.Entries {
+		assert.Equal(t, file.Accounts[1].Statement.Entries[i].Amount, entry.Amount)
+		assert.Equal(t, file.Accounts[1].Statement.Entries[i].BookingDate, entry.BookingDate)
+	}
+}
@@ -0,0 +1,69 @@
+// Package statements parses the bank statements imported as transactions.
+package statements
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrInvalidStatement represents the error when a statement cannot be parsed.
+var ErrInvalidStatement = errors.New("invalid statement")
+
+// Entry represents a booked movement of a statement.
+// Positive amounts are credits and negative amounts debits.
+type Entry struct {
+	BookingDate time.Time
+	ValueDate   time.Time
+	Amount      float64
+	Currency    string
+	Description string
+	Payee       string
+	Reference   string
+	Category    string
+
+	// Transfer is the name of the account the money was moved to or from, if any.
+	Transfer string
+
+	// Splits are the parts of the entry assigned to different categories, if any.
+	Splits []Split
+
+	// ExternalID is the identifier given to the entry by the bank, if any. Only unique
+	// identifiers are set, as entries with the same one are imported once: references
+	// chosen by the customers are not, and such entries are identified by their content.
+	ExternalID string
+}
+
+// Split represents a part of an entry.
+type Split struct {
+	Category string
+	Transfer string
+	Memo     string
+	Amount   float64
+}
+
+// Balance represents the balance of the account at a date.
+type Balance struct {
+	Amount   float64
+	Currency string
+	Date     time.Time
+}
+
+// Statement represents the entries of an account exported by a bank.
+type Statement struct {
+	// AccountID identifies the account of the statement, e.g. its IBAN.
+	AccountID string
+
+	// Currency is the default currency of the entries.
+	Currency string
+
+	Entries []Entry
+
+	// Balance is the closing balance of the account, if the format provides it.
+	Balance *Balance
+}
+
+// Parser parses the statements of a format.
+type Parser interface {
+	Parse(r io.Reader) (Statement, error)
+}
@@ -0,0 +1,151 @@
+package xauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix is the prefix of the generated API keys.
+// It makes the keys easy to recognize by secret scanners.
+const APIKeyPrefix = "ftk_"
+
+// ErrAPIKeyNotFound represents the error when no API key matches the given hash.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKey represents an API key issued to a user.
+// Only the hash of the key is stored, the key itself is shown once on creation.
+type APIKey struct {
+	ID        string
+	Name      string
+	Hash      string
+	Subject   string
+	Scopes    []string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	Revoked   bool
+}
+
+// IsActive checks if the key can be used at the given time.
+func (k APIKey) IsActive(at time.Time) bool {
+	if k.Revoked {
+		return false
+	}
+	return k.ExpiresAt.IsZero() || at.Before(k.ExpiresAt)
+}
+
+// APIKeyStore defines the interface for saving and retrieving API keys.
+type APIKeyStore interface {
+	Save(ctx context.Context, key APIKey) error
+	GetByHash(ctx context.Context, hash string) (APIKey, error)
+}
+
+// NewAPIKey generates a new random API key for the given subject.
+// It returns the plain key, to be handed to the user, along with the APIKey to be stored.
+func NewAPIKey(name, subject string, scopes []string, expiresAt time.Time) (string, APIKey, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", APIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return key, APIKey{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Hash:      HashAPIKey(key),
+		Subject:   subject,
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash of the given key.
+// API keys are high entropy random values, so a fast hash is enough
+// and allows to look them up by hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ Authenticator = (*APIKeyAuthenticator)(nil)
+
+// APIKeyAuthenticator authenticates principals with the API keys kept in a store.
+type APIKeyAuthenticator struct {
+	store APIKeyStore
+	now   func() time.Time
+}
+
+// NewAPIKeyAuthenticator creates a new APIKeyAuthenticator.
+func NewAPIKeyAuthenticator(store APIKeyStore) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Authenticate looks up the given key by its hash and checks it is still active.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, key string) (Principal, error) {
+	apiKey, err := a.store.GetByHash(ctx, HashAPIKey(key))
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return Principal{}, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if !apiKey.IsActive(a.now()) {
+		return Principal{}, fmt.Errorf("%w: api key is revoked or expired", ErrInvalidCredentials)
+	}
+
+	return Principal{
+		Subject: apiKey.Subject,
+		Method:  MethodAPIKey,
+		Scopes:  apiKey.Scopes,
+	}, nil
+}
+
+var _ APIKeyStore = (*InMemoryAPIKeyStore)(nil)
+
+// InMemoryAPIKeyStore is the in-memory implementation of APIKeyStore.
+type InMemoryAPIKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]APIKey
+}
+
+// NewInMemoryAPIKeyStore creates a new instance of InMemoryAPIKeyStore.
+func NewInMemoryAPIKeyStore() *InMemoryAPIKeyStore {
+	return &InMemoryAPIKeyStore{
+		keys: make(map[string]APIKey),
+	}
+}
+
+// Save saves the API key, replacing any key with the same hash.
+func (s *InMemoryAPIKeyStore) Save(_ context.Context, key APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[key.Hash] = key
+	return nil
+}
+
+// GetByHash retrieves the API key with the given hash.
+func (s *InMemoryAPIKeyStore) GetByHash(_ context.Context, hash string) (APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[hash]
+	if !ok {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
@@ -0,0 +1,122 @@
+package xauth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xauth"
+)
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	secret := []byte("test-secret")
+
+	authenticator, err := NewHMACAuthenticator(secret,
+		WithIssuer("finantrack"),
+		WithAudience("assets"),
+	)
+	require.NoError(t, err)
+
+	sign := func(claims jwt.MapClaims, key []byte) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "user-1",
+			"iss":   "finantrack",
+			"aud":   "assets",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "assets:read assets:write",
+		}
+	}
+
+	var specs = []struct {
+		name      string
+		token     func() string
+		expectErr bool
+	}{
+		{
+			name:  "valid token",
+			token: func() string { return sign(validClaims(), secret) },
+		},
+		{
+			name:      "invalid signature",
+			token:     func() string { return sign(validClaims(), []byte("other-secret")) },
+			expectErr: true,
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return sign(claims, secret)
+			},
+			expectErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "reports"
+				return sign(claims, secret)
+			},
+			expectErr: true,
+		},
+		{
+			name: "missing subject",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "sub")
+				return sign(claims, secret)
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			principal, err := authenticator.Authenticate(context.Background(), spec.token())
+			if spec.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", principal.Subject)
+			assert.Equal(t, MethodJWT, principal.Method)
+			assert.True(t, principal.HasScope("assets:write"))
+		})
+	}
+}
+
+func TestAPIKeyAuthenticator_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryAPIKeyStore()
+	authenticator := NewAPIKeyAuthenticator(store)
+
+	key, apiKey, err := NewAPIKey("cli", "user-1", []string{"assets:read"}, time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, apiKey))
+	assert.NotContains(t, apiKey.Hash, key)
+
+	principal, err := authenticator.Authenticate(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+	assert.Equal(t, MethodAPIKey, principal.Method)
+
+	_, err = authenticator.Authenticate(ctx, key+"x")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	apiKey.Revoked = true
+	require.NoError(t, store.Save(ctx, apiKey))
+
+	_, err = authenticator.Authenticate(ctx, key)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
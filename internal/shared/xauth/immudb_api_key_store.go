@@ -0,0 +1,122 @@
+package xauth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// DefaultAPIKeysTableName is the default table name for API keys.
+const DefaultAPIKeysTableName = "api_keys"
+
+var _ APIKeyStore = (*ImmuAPIKeyStore)(nil)
+
+// ImmuAPIKeyStore is the immudb implementation of APIKeyStore.
+// The api_keys table must be created beforehand with the CreateAPIKeysTable migration.
+type ImmuAPIKeyStore struct {
+	db *sql.DB
+}
+
+// NewImmuAPIKeyStore creates a new instance of ImmuAPIKeyStore.
+func NewImmuAPIKeyStore(db *sql.DB) *ImmuAPIKeyStore {
+	return &ImmuAPIKeyStore{
+		db: db,
+	}
+}
+
+// Save saves the API key, replacing any key with the same ID.
+// Previous versions of the key are kept in the immudb history.
+func (s *ImmuAPIKeyStore) Save(ctx context.Context, key APIKey) error {
+	ctx, cancel := context.WithTimeout(ctx, ximmudb.DefaultTimeout)
+	defer cancel()
+
+	var expiresAt sql.NullTime
+	if !key.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: key.ExpiresAt.UTC(), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		UPSERT INTO %s (id, name, hash, subject, scopes, created_at, expires_at, revoked)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+		DefaultAPIKeysTableName,
+	),
+		key.ID,
+		key.Name,
+		key.Hash,
+		key.Subject,
+		strings.Join(key.Scopes, " "),
+		key.CreatedAt.UTC(),
+		expiresAt,
+		key.Revoked,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash retrieves the API key with the given hash.
+func (s *ImmuAPIKeyStore) GetByHash(ctx context.Context, hash string) (APIKey, error) {
+	var (
+		key       APIKey
+		scopes    sql.NullString
+		expiresAt sql.NullTime
+	)
+
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT id, name, hash, subject, scopes, created_at, expires_at, revoked
+		FROM %s WHERE hash = ? LIMIT 1`,
+		DefaultAPIKeysTableName,
+	), hash).Scan(
+		&key.ID,
+		&key.Name,
+		&key.Hash,
+		&key.Subject,
+		&scopes,
+		&key.CreatedAt,
+		&expiresAt,
+		&key.Revoked,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	key.Scopes = strings.Fields(scopes.String)
+	key.ExpiresAt = expiresAt.Time
+	return key, nil
+}
+
+// ImmuAPIKeyStoreMigrations returns the migrations of the API keys table, in the order they are applied.
+func ImmuAPIKeyStoreMigrations() []ximmudb.Migration {
+	return []ximmudb.Migration{
+		NewCreateAPIKeysTable(),
+	}
+}
+
+// NewCreateAPIKeysTable creates the migration creating the API keys table
+// and the unique index over the key hashes.
+func NewCreateAPIKeysTable() ximmudb.Migration {
+	return ximmudb.NewMigration("api_keys/0001_create_api_keys_table",
+		fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				id VARCHAR[100],
+				name VARCHAR[256],
+				hash VARCHAR[64],
+				subject VARCHAR[100],
+				scopes VARCHAR[1024],
+				created_at TIMESTAMP,
+				expires_at TIMESTAMP,
+				revoked BOOLEAN,
+				PRIMARY KEY id
+			);`, DefaultAPIKeysTableName),
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS ON %s(hash);`, DefaultAPIKeysTableName),
+	)
+}
@@ -0,0 +1,135 @@
+package xauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultJWTLeeway is the clock skew tolerated when validating the token times.
+const DefaultJWTLeeway = 30 * time.Second
+
+var _ Authenticator = (*JWTAuthenticator)(nil)
+
+// JWTOption configures the JWTAuthenticator.
+type JWTOption func(*jwtOptions)
+
+type jwtOptions struct {
+	issuer   string
+	audience string
+	leeway   time.Duration
+}
+
+// WithIssuer requires the tokens to be issued by the given issuer.
+func WithIssuer(issuer string) JWTOption {
+	return func(o *jwtOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithAudience requires the tokens to be issued for the given audience.
+func WithAudience(audience string) JWTOption {
+	return func(o *jwtOptions) {
+		o.audience = audience
+	}
+}
+
+// WithLeeway sets the clock skew tolerated when validating the token times.
+func WithLeeway(leeway time.Duration) JWTOption {
+	return func(o *jwtOptions) {
+		o.leeway = leeway
+	}
+}
+
+// JWTAuthenticator authenticates principals with signed JSON Web Tokens.
+// The subject claim identifies the principal and the space separated
+// scope claim holds its permissions.
+type JWTAuthenticator struct {
+	parser  *jwt.Parser
+	keyfunc jwt.Keyfunc
+}
+
+// NewHMACAuthenticator creates a new JWTAuthenticator that verifies
+// HS256, HS384 and HS512 tokens with the given shared secret.
+func NewHMACAuthenticator(secret []byte, opts ...JWTOption) (*JWTAuthenticator, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("hmac secret is required")
+	}
+
+	return newJWTAuthenticator(
+		func(*jwt.Token) (any, error) { return secret, nil },
+		[]string{"HS256", "HS384", "HS512"},
+		opts...,
+	), nil
+}
+
+// NewJWKSAuthenticator creates a new JWTAuthenticator that verifies tokens
+// with the public keys of the given JSON Web Key Set.
+// The key is selected by the kid header of the token.
+func NewJWKSAuthenticator(jwks []byte, opts ...JWTOption) (*JWTAuthenticator, error) {
+	k, err := keyfunc.NewJWKSetJSON(json.RawMessage(jwks))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	return newJWTAuthenticator(
+		k.Keyfunc,
+		[]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"},
+		opts...,
+	), nil
+}
+
+func newJWTAuthenticator(kf jwt.Keyfunc, methods []string, opts ...JWTOption) *JWTAuthenticator {
+	o := jwtOptions{
+		leeway: DefaultJWTLeeway,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithLeeway(o.leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if o.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(o.issuer))
+	}
+	if o.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(o.audience))
+	}
+
+	return &JWTAuthenticator{
+		parser:  jwt.NewParser(parserOpts...),
+		keyfunc: kf,
+	}
+}
+
+// Authenticate verifies the signature and claims of the given token.
+func (a *JWTAuthenticator) Authenticate(_ context.Context, token string) (Principal, error) {
+	var claims jwtClaims
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.keyfunc); err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+
+	return Principal{
+		Subject: claims.Subject,
+		Method:  MethodJWT,
+		Scopes:  strings.Fields(claims.Scope),
+	}, nil
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope,omitempty"`
+}
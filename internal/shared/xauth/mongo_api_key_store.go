@@ -0,0 +1,104 @@
+package xauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+)
+
+// DefaultAPIKeysCollectionName is the default collection name for API keys.
+const DefaultAPIKeysCollectionName = "api_keys"
+
+var _ APIKeyStore = (*MongoAPIKeyStore)(nil)
+
+// MongoAPIKeyStore is the MongoDB implementation of APIKeyStore.
+type MongoAPIKeyStore struct {
+	client *xmongo.Client
+}
+
+// MongoAPIKeyStoreMigrations returns the migrations of the API keys collection, in the order they are applied.
+func MongoAPIKeyStoreMigrations() []xmongo.Migration {
+	return []xmongo.Migration{
+		xmongo.NewIndexMigration("api_keys/0001_create_hash_index", DefaultAPIKeysCollectionName,
+			bson.D{{Key: "hash", Value: 1}}, options.Index().SetUnique(true)),
+	}
+}
+
+// NewMongoAPIKeyStore creates a new instance of MongoAPIKeyStore.
+// The unique index over the key hashes is created by MongoAPIKeyStoreMigrations.
+func NewMongoAPIKeyStore(client *xmongo.Client) *MongoAPIKeyStore {
+	return &MongoAPIKeyStore{
+		client: client,
+	}
+}
+
+// Save saves the API key, replacing any key with the same ID.
+func (s *MongoAPIKeyStore) Save(ctx context.Context, key APIKey) error {
+	dto := apiKeyDTO{
+		ID:        key.ID,
+		Name:      key.Name,
+		Hash:      key.Hash,
+		Subject:   key.Subject,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+		ExpiresAt: key.ExpiresAt,
+		Revoked:   key.Revoked,
+	}
+
+	_, err := s.collection().ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: key.ID}},
+		dto,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash retrieves the API key with the given hash.
+func (s *MongoAPIKeyStore) GetByHash(ctx context.Context, hash string) (APIKey, error) {
+	var dto apiKeyDTO
+	err := s.collection().FindOne(ctx, bson.D{{Key: "hash", Value: hash}}).Decode(&dto)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return APIKey{
+		ID:        dto.ID,
+		Name:      dto.Name,
+		Hash:      dto.Hash,
+		Subject:   dto.Subject,
+		Scopes:    dto.Scopes,
+		CreatedAt: dto.CreatedAt,
+		ExpiresAt: dto.ExpiresAt,
+		Revoked:   dto.Revoked,
+	}, nil
+}
+
+func (s *MongoAPIKeyStore) collection() *mongo.Collection {
+	return s.client.Collection(DefaultAPIKeysCollectionName)
+}
+
+// apiKeyDTO represents the structure of an API key stored in MongoDB.
+type apiKeyDTO struct {
+	ID        string    `bson:"_id"`
+	Name      string    `bson:"name"`
+	Hash      string    `bson:"hash"`
+	Subject   string    `bson:"subject"`
+	Scopes    []string  `bson:"scopes,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	ExpiresAt time.Time `bson:"expires_at,omitempty"`
+	Revoked   bool      `bson:"revoked"`
+}
@@ -0,0 +1,65 @@
+package xauth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUnauthenticated represents the error when the request carries no valid credentials.
+	ErrUnauthenticated = errors.New("unauthenticated")
+
+	// ErrInvalidCredentials represents the error when the given credentials cannot be verified.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Method represents the method used to authenticate a principal.
+type Method string
+
+const (
+	// MethodJWT authenticates principals with a signed JSON Web Token.
+	MethodJWT Method = "jwt"
+
+	// MethodAPIKey authenticates principals with an API key.
+	MethodAPIKey Method = "api_key"
+)
+
+// Principal represents the authenticated identity performing a request.
+type Principal struct {
+	// Subject identifies the user the request is performed on behalf of.
+	Subject string
+
+	// Method is the method used to authenticate the principal.
+	Method Method
+
+	// Scopes are the permissions granted to the principal.
+	Scopes []string
+}
+
+// HasScope checks if the principal was granted the given scope.
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator verifies a credential and returns the principal it belongs to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (Principal, error)
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of the context that carries the given principal.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by the context, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,416 @@
+// Package xbackup writes and reads the portable backups of the event log of a tenant.
+//
+// A backup is a gzip compressed tar archive with three files:
+//   - manifest.json describes the archive: format, version, tenant and contents.
+//   - events.jsonl holds one event per line, ordered by aggregate and version.
+//   - checksums.sha256 holds the SHA-256 checksums of the other files, in the sha256sum format.
+//
+// The events are engine agnostic, so a backup taken from one engine can be restored into any other.
+package xbackup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"iter"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+)
+
+const (
+	// Format identifies the archives written by this package.
+	Format = "finantrack-backup"
+
+	// Version is the version of the archive layout written by this package.
+	Version = 1
+
+	// ManifestFile is the name of the manifest inside the archive.
+	ManifestFile = "manifest.json"
+
+	// EventsFile is the name of the event log inside the archive.
+	EventsFile = "events.jsonl"
+
+	// ChecksumsFile is the name of the checksums inside the archive.
+	ChecksumsFile = "checksums.sha256"
+)
+
+var (
+	// ErrInvalidArchive represents the error when the archive is malformed or incomplete.
+	ErrInvalidArchive = errors.New("invalid backup archive")
+
+	// ErrUnsupportedVersion represents the error when the archive was written by an unknown version.
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+
+	// ErrChecksumMismatch represents the error when the contents of the archive do not match their checksums.
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+)
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	TenantID  string    `json:"tenantId"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Source is the database engine the events were read from.
+	Source string `json:"source,omitempty"`
+
+	Events     int    `json:"events"`
+	Aggregates int    `json:"aggregates"`
+	Files      []File `json:"files"`
+}
+
+// File describes a file of the archive.
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Record is the engine agnostic representation of an event, a line of the event log.
+type Record struct {
+	ID               string           `json:"id"`
+	Type             string           `json:"type"`
+	AggregateID      string           `json:"aggregateId"`
+	AggregateType    string           `json:"aggregateType"`
+	AggregateVersion int              `json:"aggregateVersion"`
+	Timestamp        time.Time        `json:"timestamp"`
+	Metadata         *xevent.Metadata `json:"metadata,omitempty"`
+	Data             json.RawMessage  `json:"data"`
+}
+
+// archiveWriter spools the event log to a temporary file, as the manifest
+// that precedes it in the archive is only known once every event is written.
+type archiveWriter struct {
+	manifest   Manifest
+	spool      *os.File
+	buf        *bufio.Writer
+	hash       hashWriter
+	aggregates map[string]bool
+}
+
+func newArchiveWriter(tenantID, source string) (*archiveWriter, error) {
+	spool, err := os.CreateTemp("", "finantrack-backup-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup spool: %w", err)
+	}
+
+	w := &archiveWriter{
+		manifest: Manifest{
+			Format:    Format,
+			Version:   Version,
+			TenantID:  tenantID,
+			CreatedAt: time.Now().UTC(),
+			Source:    source,
+		},
+		spool:      spool,
+		aggregates: make(map[string]bool),
+	}
+	w.hash = hashWriter{w: spool, h: sha256.New()}
+	w.buf = bufio.NewWriter(&w.hash)
+
+	return w, nil
+}
+
+// Add appends a record to the event log.
+func (w *archiveWriter) Add(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", record.ID, err)
+	}
+
+	if _, err = w.buf.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	w.manifest.Events++
+	if !w.aggregates[record.AggregateID] {
+		w.aggregates[record.AggregateID] = true
+		w.manifest.Aggregates++
+	}
+
+	return nil
+}
+
+// Finish writes the archive with the manifest, the event log and the checksums.
+func (w *archiveWriter) Finish(out io.Writer) (Manifest, error) {
+	if err := w.buf.Flush(); err != nil {
+		return Manifest{}, err
+	}
+
+	if _, err := w.spool.Seek(0, io.SeekStart); err != nil {
+		return Manifest{}, err
+	}
+
+	w.manifest.Files = []File{{
+		Name:   EventsFile,
+		Size:   w.hash.n,
+		SHA256: hex.EncodeToString(w.hash.h.Sum(nil)),
+	}}
+
+	manifest, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifestSum := sha256.Sum256(manifest)
+	checksums := fmt.Sprintf("%x  %s\n%s  %s\n",
+		manifestSum, ManifestFile,
+		w.manifest.Files[0].SHA256, EventsFile,
+	)
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	if err = writeTarFile(tw, ManifestFile, int64(len(manifest)), bytes.NewReader(manifest), w.manifest.CreatedAt); err != nil {
+		return Manifest{}, err
+	}
+	if err = writeTarFile(tw, EventsFile, w.hash.n, w.spool, w.manifest.CreatedAt); err != nil {
+		return Manifest{}, err
+	}
+	if err = writeTarFile(tw, ChecksumsFile, int64(len(checksums)), strings.NewReader(checksums), w.manifest.CreatedAt); err != nil {
+		return Manifest{}, err
+	}
+
+	if err = tw.Close(); err != nil {
+		return Manifest{}, err
+	}
+	if err = gz.Close(); err != nil {
+		return Manifest{}, err
+	}
+
+	return w.manifest, nil
+}
+
+// Close removes the spooled event log.
+func (w *archiveWriter) Close() error {
+	return errors.Join(w.spool.Close(), os.Remove(w.spool.Name()))
+}
+
+func writeTarFile(tw *tar.Writer, name string, size int64, r io.Reader, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+
+	if _, err = io.Copy(tw, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Archive is a verified backup archive opened for reading.
+type Archive struct {
+	Manifest Manifest
+
+	spool *os.File
+}
+
+// Open reads and verifies the archive: the format and version of the manifest
+// and the checksums of every file. The event log is spooled to a temporary file,
+// so nothing is read from it before the whole archive is known to be intact.
+// The archive must be closed to remove the spooled file.
+func Open(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+
+	spool, err := os.CreateTemp("", "finantrack-restore-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create restore spool: %w", err)
+	}
+
+	archive := &Archive{spool: spool}
+	if err = archive.read(tar.NewReader(gz)); err != nil {
+		return nil, errors.Join(err, archive.Close())
+	}
+
+	return archive, nil
+}
+
+func (a *Archive) read(tr *tar.Reader) error {
+	var (
+		manifest  []byte
+		checksums []byte
+		sums      = make(map[string]string)
+		sizes     = make(map[string]int64)
+	)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+
+		h := hashWriter{h: sha256.New()}
+		switch header.Name {
+		case ManifestFile:
+			var buf bytes.Buffer
+			h.w = &buf
+			if _, err = io.Copy(&h, tr); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+			}
+			manifest = buf.Bytes()
+
+		case EventsFile:
+			h.w = a.spool
+			if _, err = io.Copy(&h, tr); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+			}
+
+		case ChecksumsFile:
+			if checksums, err = io.ReadAll(tr); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+			}
+			continue
+
+		default:
+			// unknown files are left for the newer versions of the layout
+			continue
+		}
+
+		sums[header.Name] = hex.EncodeToString(h.h.Sum(nil))
+		sizes[header.Name] = h.n
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("%w: missing %s", ErrInvalidArchive, ManifestFile)
+	}
+	if checksums == nil {
+		return fmt.Errorf("%w: missing %s", ErrInvalidArchive, ChecksumsFile)
+	}
+
+	if err := verifyChecksums(string(checksums), sums); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(manifest, &a.Manifest); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	if a.Manifest.Format != Format {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidArchive, a.Manifest.Format)
+	}
+	if a.Manifest.Version != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, a.Manifest.Version)
+	}
+	if a.Manifest.TenantID == "" {
+		return fmt.Errorf("%w: missing tenant", ErrInvalidArchive)
+	}
+
+	// the manifest describes the files as well, they must agree with the archive
+	for _, file := range a.Manifest.Files {
+		if sums[file.Name] != file.SHA256 || sizes[file.Name] != file.Size {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, file.Name)
+		}
+	}
+
+	if _, ok := sums[EventsFile]; !ok {
+		return fmt.Errorf("%w: missing %s", ErrInvalidArchive, EventsFile)
+	}
+
+	_, err := a.spool.Seek(0, io.SeekStart)
+	return err
+}
+
+// verifyChecksums checks the computed sums against the checksums file.
+// Every file of the archive must be listed.
+func verifyChecksums(checksums string, sums map[string]string) error {
+	listed := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(checksums), "\n") {
+		sum, name, ok := strings.Cut(line, "  ")
+		if !ok {
+			return fmt.Errorf("%w: malformed %s", ErrInvalidArchive, ChecksumsFile)
+		}
+		listed[name] = sum
+	}
+
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if listed[name] != sums[name] {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+		}
+	}
+
+	return nil
+}
+
+// Records returns an iterator over the records of the event log.
+// The iteration stops after yielding the first error.
+func (a *Archive) Records() iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		if _, err := a.spool.Seek(0, io.SeekStart); err != nil {
+			yield(Record{}, err)
+			return
+		}
+
+		scanner := bufio.NewScanner(a.spool)
+		scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+		for line := 1; scanner.Scan(); line++ {
+			var record Record
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				yield(Record{}, fmt.Errorf("%w: %s line %d: %w", ErrInvalidArchive, EventsFile, line, err))
+				return
+			}
+
+			if !yield(record, nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(Record{}, fmt.Errorf("%w: %w", ErrInvalidArchive, err))
+		}
+	}
+}
+
+// Close removes the spooled event log.
+func (a *Archive) Close() error {
+	return errors.Join(a.spool.Close(), os.Remove(a.spool.Name()))
+}
+
+// maxRecordSize is the maximum size of a line of the event log.
+const maxRecordSize = 16 * 1024 * 1024
+
+// hashWriter writes to the underlying writer while computing the checksum and size.
+type hashWriter struct {
+	w io.Writer
+	h hash.Hash
+	n int64
+}
+
+func (w *hashWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.h.Write(p[:n])
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,258 @@
+package xbackup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"github.com/xfrr/go-cqrsify/event"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+)
+
+// Event represents an event of the log.
+type Event = aggregate.Change
+
+// EventLog is the event log of a database engine.
+type EventLog interface {
+	// Stream returns the events of the tenant of the context, ordered by aggregate and version.
+	Stream(ctx context.Context) iter.Seq2[Event, error]
+
+	// StreamAggregate returns the events of the aggregate in any tenant, ordered by version.
+	StreamAggregate(ctx context.Context, aggregateID uuid.UUID) iter.Seq2[Event, error]
+
+	// Save appends the events with the tenant and the metadata of the context.
+	Save(ctx context.Context, events ...Event) error
+
+	// AggregateVersion returns the version of the last event of the aggregate in any tenant,
+	// zero if it has no events.
+	AggregateVersion(ctx context.Context, aggregateID uuid.UUID) (int, error)
+}
+
+// Backup writes the archive of the event log of the tenant of the context.
+// The source is the name of the engine of the log, recorded in the manifest.
+func Backup(ctx context.Context, w io.Writer, log EventLog, source string) (manifest Manifest, err error) {
+	tenantID, ok := xtenant.FromContext(ctx)
+	if !ok {
+		return Manifest{}, xtenant.ErrTenantRequired
+	}
+
+	archive, err := newArchiveWriter(tenantID, source)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer func() { err = errors.Join(err, archive.Close()) }()
+
+	for e, err := range log.Stream(ctx) {
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		record, err := newRecord(e)
+		if err != nil {
+			return Manifest{}, err
+		}
+
+		if err = archive.Add(record); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	return archive.Finish(w)
+}
+
+// RestoreResult represents the outcome of a restore.
+type RestoreResult struct {
+	Manifest Manifest
+
+	// Events and Aggregates are the number of events and aggregates saved.
+	Events     int
+	Aggregates int
+
+	// Skipped is the number of aggregates left untouched, as all their events already existed.
+	Skipped int
+}
+
+// Restore replays the events of the archive into the log, in the tenant of the manifest
+// and with their original identifiers, versions, timestamps and metadata.
+// The payloads are decoded with the factories of the registry.
+//
+// Only the events after the last version of each aggregate stored in the log are saved,
+// so restoring the same archive twice, or after a failure, does not duplicate events,
+// and the aggregates partially restored, or that fell behind the archive, are completed.
+func Restore(ctx context.Context, archive *Archive, log EventLog, registry xevent.Registry) (RestoreResult, error) {
+	result := RestoreResult{Manifest: archive.Manifest}
+	ctx = xtenant.WithTenant(ctx, archive.Manifest.TenantID)
+
+	var (
+		batch    []Event
+		metadata *xevent.Metadata
+		current  string
+		stored   int
+		restored bool
+	)
+
+	// events are saved in batches of the same aggregate and metadata
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		saveCtx := ctx
+		if metadata != nil {
+			saveCtx = xevent.WithMetadata(ctx, *metadata)
+		}
+
+		if err := log.Save(saveCtx, batch...); err != nil {
+			return fmt.Errorf("failed to restore aggregate %s: %w", current, err)
+		}
+
+		result.Events += len(batch)
+		batch = nil
+		return nil
+	}
+
+	// aggregates are counted once all their records were read
+	count := func() {
+		switch {
+		case current == "":
+		case restored:
+			result.Aggregates++
+		default:
+			result.Skipped++
+		}
+	}
+
+	for record, err := range archive.Records() {
+		if err != nil {
+			return result, err
+		}
+
+		if record.AggregateID != current {
+			if err = flush(); err != nil {
+				return result, err
+			}
+			count()
+
+			aggregateID, err := uuid.Parse(record.AggregateID)
+			if err != nil {
+				return result, fmt.Errorf("%w: aggregate id %s: %w", ErrInvalidArchive, record.AggregateID, err)
+			}
+
+			stored, err = log.AggregateVersion(ctx, aggregateID)
+			if err != nil {
+				return result, err
+			}
+
+			current = record.AggregateID
+			restored = false
+		}
+
+		if record.AggregateVersion <= stored {
+			continue
+		}
+		restored = true
+
+		if !sameMetadata(metadata, record.Metadata) {
+			if err = flush(); err != nil {
+				return result, err
+			}
+		}
+		metadata = record.Metadata
+
+		e, err := record.event(registry)
+		if err != nil {
+			return result, err
+		}
+		batch = append(batch, e)
+	}
+
+	if err := flush(); err != nil {
+		return result, err
+	}
+	count()
+
+	return result, nil
+}
+
+// newRecord converts an event of the log into a record of the archive.
+func newRecord(e Event) (Record, error) {
+	ev, ok := event.Cast[uuid.UUID, any](e)
+	if !ok {
+		return Record{}, fmt.Errorf("event must have UUID IDs, got %v", e.ID())
+	}
+
+	if e.Aggregate() == nil {
+		return Record{}, errors.New("event must have an aggregate reference")
+	}
+
+	aggregateID, ok := e.Aggregate().ID.(uuid.UUID)
+	if !ok {
+		return Record{}, fmt.Errorf("aggregate ID must be a UUID, got %v", e.Aggregate().ID)
+	}
+
+	data, err := json.Marshal(ev.Payload())
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to encode payload of event %s: %w", ev.ID(), err)
+	}
+
+	record := Record{
+		ID:               ev.ID().String(),
+		Type:             e.Reason(),
+		AggregateID:      aggregateID.String(),
+		AggregateType:    e.Aggregate().Name,
+		AggregateVersion: e.Aggregate().Version,
+		Timestamp:        e.Time().UTC(),
+		Data:             data,
+	}
+
+	if md, ok := xevent.MetadataOf(e); ok && !md.IsZero() {
+		record.Metadata = &md
+	}
+
+	return record, nil
+}
+
+// event converts the record back into an event, decoding the payload with the registry.
+func (r Record) event(registry xevent.Registry) (Event, error) {
+	eventID, err := uuid.Parse(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: event id %s: %w", ErrInvalidArchive, r.ID, err)
+	}
+
+	aggregateID, err := uuid.Parse(r.AggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: aggregate id %s: %w", ErrInvalidArchive, r.AggregateID, err)
+	}
+
+	payloadFactory, err := registry.GetFactory(r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := payloadFactory()
+	if err = json.Unmarshal(r.Data, payload); err != nil {
+		return nil, fmt.Errorf("%w: payload of event %s: %w", ErrInvalidArchive, r.ID, err)
+	}
+
+	return event.New[any](
+		eventID,
+		r.Type,
+		payload,
+		event.WithAggregate(aggregateID, r.AggregateType, r.AggregateVersion),
+		event.WithTime(r.Timestamp),
+	), nil
+}
+
+func sameMetadata(a, b *xevent.Metadata) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
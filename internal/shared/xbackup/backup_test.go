@@ -0,0 +1,289 @@
+package xbackup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/event"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	. "github.com/xfrr/finantrack/internal/shared/xbackup"
+)
+
+type mockEventPayload struct {
+	Key string `json:"key"`
+}
+
+// storedEvent is an event saved in the memory log along with its tenant and metadata.
+type storedEvent struct {
+	Event
+	tenantID string
+	metadata xevent.Metadata
+}
+
+func (e storedEvent) Metadata() xevent.Metadata {
+	return e.metadata
+}
+
+type memoryEventLog struct {
+	events []storedEvent
+}
+
+func (l *memoryEventLog) Stream(ctx context.Context) iter.Seq2[Event, error] {
+	tenantID, _ := xtenant.FromContext(ctx)
+	return func(yield func(Event, error) bool) {
+		for _, e := range l.events {
+			if e.tenantID == tenantID && !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (l *memoryEventLog) StreamAggregate(_ context.Context, aggregateID uuid.UUID) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for _, e := range l.events {
+			if e.Aggregate().ID == aggregateID && !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (l *memoryEventLog) Save(ctx context.Context, events ...Event) error {
+	tenantID, _ := xtenant.FromContext(ctx)
+	md, _ := xevent.MetadataFromContext(ctx)
+	for _, e := range events {
+		l.events = append(l.events, storedEvent{Event: e, tenantID: tenantID, metadata: md})
+	}
+	return nil
+}
+
+func (l *memoryEventLog) AggregateVersion(_ context.Context, aggregateID uuid.UUID) (int, error) {
+	var version int
+	for _, e := range l.events {
+		if e.Aggregate().ID == aggregateID {
+			version = max(version, e.Aggregate().Version)
+		}
+	}
+	return version, nil
+}
+
+func newEvent(aggregateID uuid.UUID, version int, key string) Event {
+	return event.New[any, any](
+		uuid.New(),
+		"mock.event",
+		&mockEventPayload{Key: key},
+		event.WithAggregate(aggregateID, "mock", version),
+		event.WithTime(time.Date(2024, 1, version, 0, 0, 0, 0, time.UTC)),
+	)
+}
+
+func newRegistry() xevent.Registry {
+	registry := xevent.NewPayloadRegistry()
+	xevent.Register(registry, "mock.event", func() interface{} {
+		return &mockEventPayload{}
+	})
+	return registry
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		tenantID   = uuid.NewString()
+		first      = uuid.New()
+		second     = uuid.New()
+		metadata   = xevent.Metadata{UserID: "user-1", Source: "assets"}
+		source     = &memoryEventLog{}
+		tenantCtx  = xtenant.WithTenant(ctx, tenantID)
+		archiveBuf bytes.Buffer
+	)
+
+	require.NoError(t, source.Save(xevent.WithMetadata(tenantCtx, metadata), newEvent(first, 1, "a"), newEvent(first, 2, "b")))
+	require.NoError(t, source.Save(tenantCtx, newEvent(second, 1, "c")))
+	require.NoError(t, source.Save(xtenant.WithTenant(ctx, "other"), newEvent(uuid.New(), 1, "d")))
+
+	_, err := Backup(ctx, &archiveBuf, source, "inmemory")
+	require.ErrorIs(t, err, xtenant.ErrTenantRequired)
+
+	manifest, err := Backup(tenantCtx, &archiveBuf, source, "inmemory")
+	require.NoError(t, err)
+	assert.Equal(t, Format, manifest.Format)
+	assert.Equal(t, Version, manifest.Version)
+	assert.Equal(t, tenantID, manifest.TenantID)
+	assert.Equal(t, 3, manifest.Events)
+	assert.Equal(t, 2, manifest.Aggregates)
+
+	archive, err := Open(bytes.NewReader(archiveBuf.Bytes()))
+	require.NoError(t, err)
+	defer archive.Close()
+	assert.Equal(t, manifest, archive.Manifest)
+
+	target := &memoryEventLog{}
+	result, err := Restore(ctx, archive, target, newRegistry())
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Events)
+	assert.Equal(t, 2, result.Aggregates)
+	assert.Zero(t, result.Skipped)
+
+	require.Len(t, target.events, 3)
+	for i, restored := range target.events {
+		original := source.events[i]
+		assert.Equal(t, tenantID, restored.tenantID)
+		assert.Equal(t, original.metadata, restored.metadata)
+		assert.Equal(t, original.ID(), restored.ID())
+		assert.Equal(t, original.Reason(), restored.Reason())
+		assert.Equal(t, original.Aggregate(), restored.Aggregate())
+		assert.True(t, original.Time().Equal(restored.Time()))
+		assert.Equal(t, original.Payload(), restored.Payload())
+	}
+
+	// restoring again leaves the existing aggregates untouched
+	result, err = Restore(ctx, archive, target, newRegistry())
+	require.NoError(t, err)
+	assert.Zero(t, result.Events)
+	assert.Zero(t, result.Aggregates)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Len(t, target.events, 3)
+
+	// an aggregate partially restored, e.g. after a failure, is completed
+	partial := &memoryEventLog{events: target.events[:1]}
+	result, err = Restore(ctx, archive, partial, newRegistry())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Events)
+	assert.Equal(t, 2, result.Aggregates)
+	assert.Zero(t, result.Skipped)
+
+	require.Len(t, partial.events, 3)
+	for i, restored := range partial.events {
+		assert.Equal(t, source.events[i].ID(), restored.ID())
+		assert.Equal(t, source.events[i].Aggregate(), restored.Aggregate())
+	}
+}
+
+func TestOpen(t *testing.T) {
+	var archiveBuf bytes.Buffer
+	source := &memoryEventLog{}
+	tenantCtx := xtenant.WithTenant(context.Background(), uuid.NewString())
+	require.NoError(t, source.Save(tenantCtx, newEvent(uuid.New(), 1, "a")))
+
+	_, err := Backup(tenantCtx, &archiveBuf, source, "inmemory")
+	require.NoError(t, err)
+
+	var specs = []struct {
+		name   string
+		modify func(name string, content []byte) []byte
+
+		// resum rewrites the checksums file after the modification
+		resum       bool
+		expectedErr error
+	}{
+		{
+			name: "tampered events",
+			modify: func(name string, content []byte) []byte {
+				if name == EventsFile {
+					return bytes.Replace(content, []byte(`"a"`), []byte(`"z"`), 1)
+				}
+				return content
+			},
+			expectedErr: ErrChecksumMismatch,
+		},
+		{
+			name: "tampered events and checksums",
+			modify: func(name string, content []byte) []byte {
+				if name == EventsFile {
+					return bytes.Replace(content, []byte(`"a"`), []byte(`"z"`), 1)
+				}
+				return content
+			},
+			resum:       true,
+			expectedErr: ErrChecksumMismatch,
+		},
+		{
+			name: "unsupported version",
+			modify: func(name string, content []byte) []byte {
+				if name == ManifestFile {
+					return bytes.Replace(content, []byte(`"version": 1`), []byte(`"version": 9`), 1)
+				}
+				return content
+			},
+			resum:       true,
+			expectedErr: ErrUnsupportedVersion,
+		},
+		{
+			name: "missing manifest",
+			modify: func(name string, content []byte) []byte {
+				if name == ManifestFile {
+					return nil
+				}
+				return content
+			},
+			expectedErr: ErrInvalidArchive,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			_, err := Open(bytes.NewReader(rewrite(t, archiveBuf.Bytes(), spec.modify, spec.resum)))
+			assert.ErrorIs(t, err, spec.expectedErr)
+		})
+	}
+}
+
+// rewrite copies the archive through the modify function, dropping the files it returns nil for.
+func rewrite(t *testing.T, archive []byte, modify func(name string, content []byte) []byte, resum bool) []byte {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	var (
+		out  bytes.Buffer
+		sums strings.Builder
+	)
+	gzw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gzw)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		content = modify(header.Name, content)
+		if resum && header.Name == ChecksumsFile {
+			content = []byte(sums.String())
+		}
+		if content == nil {
+			continue
+		}
+
+		fmt.Fprintf(&sums, "%x  %s\n", sha256.Sum256(content), header.Name)
+
+		header.Size = int64(len(content))
+		require.NoError(t, tw.WriteHeader(header))
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return out.Bytes()
+}
@@ -0,0 +1,41 @@
+package xbackup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// ErrAggregateNotFound represents the error when the aggregate has no events in the log.
+var ErrAggregateNotFound = errors.New("aggregate not found")
+
+// Dump writes the events of the aggregate ordered by version, one record per line as in the
+// event log of the archives, and returns the number of events written.
+func Dump(ctx context.Context, w io.Writer, log EventLog, aggregateID uuid.UUID) (int, error) {
+	enc := json.NewEncoder(w)
+
+	var n int
+	for e, err := range log.StreamAggregate(ctx, aggregateID) {
+		if err != nil {
+			return n, err
+		}
+
+		record, err := newRecord(e)
+		if err != nil {
+			return n, err
+		}
+
+		if err = enc.Encode(record); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	if n == 0 {
+		return 0, ErrAggregateNotFound
+	}
+	return n, nil
+}
@@ -0,0 +1,45 @@
+package xbackup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	. "github.com/xfrr/finantrack/internal/shared/xbackup"
+)
+
+func TestDump(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		aggregateID = uuid.New()
+		log         = &memoryEventLog{}
+		out         bytes.Buffer
+	)
+
+	require.NoError(t, log.Save(xtenant.WithTenant(ctx, "tenant"), newEvent(aggregateID, 1, "a"), newEvent(aggregateID, 2, "b")))
+	require.NoError(t, log.Save(ctx, newEvent(uuid.New(), 1, "c")))
+
+	n, err := Dump(ctx, &out, log, aggregateID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	dec := json.NewDecoder(&out)
+	for version := 1; version <= 2; version++ {
+		var record Record
+		require.NoError(t, dec.Decode(&record))
+		assert.Equal(t, aggregateID.String(), record.AggregateID)
+		assert.Equal(t, version, record.AggregateVersion)
+		assert.Equal(t, "mock.event", record.Type)
+	}
+	assert.False(t, dec.More())
+
+	_, err = Dump(ctx, &out, log, uuid.New())
+	assert.ErrorIs(t, err, ErrAggregateNotFound)
+}
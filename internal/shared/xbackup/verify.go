@@ -0,0 +1,143 @@
+package xbackup
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+)
+
+// Issue is an inconsistency found in an event log.
+// The issues of the whole log, such as a wrong manifest, have no aggregate.
+type Issue struct {
+	AggregateID string
+	EventID     string
+	Problem     string
+}
+
+func (i Issue) String() string {
+	if i.AggregateID == "" {
+		return i.Problem
+	}
+	return fmt.Sprintf("aggregate %s, event %s: %s", i.AggregateID, i.EventID, i.Problem)
+}
+
+// Verification is the outcome of the verification of an event log.
+type Verification struct {
+	Events     int
+	Aggregates int
+	Issues     []Issue
+}
+
+// OK reports whether the event log has no issues.
+func (v Verification) OK() bool {
+	return len(v.Issues) == 0
+}
+
+// Verify checks the integrity of the event log of the tenant of the context: every event
+// must be decodable, have a unique ID and follow the previous version of its aggregate,
+// starting from 1, and the events of an aggregate must share its type.
+// The issues found are reported in the verification, the error is only returned
+// when the log cannot be read.
+func Verify(ctx context.Context, log EventLog) (Verification, error) {
+	if _, ok := xtenant.FromContext(ctx); !ok {
+		return Verification{}, xtenant.ErrTenantRequired
+	}
+
+	return verify(func(yield func(Record, error) bool) {
+		for e, err := range log.Stream(ctx) {
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+
+			record, err := newRecord(e)
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}, nil)
+}
+
+// VerifyArchive checks the integrity of the event log of an archive, as Verify does,
+// decoding the payloads with the factories of the registry so the archive can be restored.
+// The checksums of the archive are already verified when it is opened.
+func VerifyArchive(archive *Archive, registry xevent.Registry) (Verification, error) {
+	v, err := verify(archive.Records(), func(r Record) string {
+		if _, err := r.event(registry); err != nil {
+			return err.Error()
+		}
+		return ""
+	})
+	if err != nil {
+		return v, err
+	}
+
+	if v.Events != archive.Manifest.Events || v.Aggregates != archive.Manifest.Aggregates {
+		v.Issues = append(v.Issues, Issue{
+			Problem: fmt.Sprintf("the manifest lists %d events of %d aggregates, the event log holds %d events of %d aggregates",
+				archive.Manifest.Events, archive.Manifest.Aggregates, v.Events, v.Aggregates),
+		})
+	}
+	return v, nil
+}
+
+// verify checks the records, ordered by aggregate and version, with the optional check of each record.
+func verify(records iter.Seq2[Record, error], check func(Record) string) (Verification, error) {
+	var (
+		v        Verification
+		current  Record
+		version  int
+		eventIDs = make(map[string]struct{})
+		seen     = make(map[string]struct{})
+	)
+
+	report := func(r Record, eventID, problem string) {
+		v.Issues = append(v.Issues, Issue{AggregateID: r.AggregateID, EventID: eventID, Problem: problem})
+	}
+
+	for r, err := range records {
+		if err != nil {
+			return v, err
+		}
+		v.Events++
+
+		if r.AggregateID != current.AggregateID || v.Events == 1 {
+			if _, ok := seen[r.AggregateID]; ok {
+				report(r, r.ID, "the events of the aggregate are not contiguous")
+			}
+			seen[r.AggregateID] = struct{}{}
+			current, version = r, 0
+			v.Aggregates++
+		}
+
+		if _, ok := eventIDs[r.ID]; ok {
+			report(r, r.ID, "duplicated event ID")
+		}
+		eventIDs[r.ID] = struct{}{}
+
+		if r.AggregateVersion != version+1 {
+			report(r, r.ID, fmt.Sprintf("version %d follows version %d", r.AggregateVersion, version))
+		}
+		version = r.AggregateVersion
+
+		if r.AggregateType != current.AggregateType {
+			report(r, r.ID, fmt.Sprintf("aggregate type %q differs from %q", r.AggregateType, current.AggregateType))
+		}
+
+		if check != nil {
+			if problem := check(r); problem != "" {
+				report(r, r.ID, problem)
+			}
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,117 @@
+package xbackup_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/event"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	. "github.com/xfrr/finantrack/internal/shared/xbackup"
+)
+
+func TestVerify(t *testing.T) {
+	var (
+		first  = uuid.New()
+		second = uuid.New()
+		dup    = newEvent(second, 1, "x")
+	)
+
+	var specs = []struct {
+		name     string
+		events   []Event
+		problems []string
+	}{
+		{
+			name:   "consistent",
+			events: []Event{newEvent(first, 1, "a"), newEvent(first, 2, "b"), newEvent(second, 1, "c")},
+		},
+		{
+			name:     "version gap",
+			events:   []Event{newEvent(first, 1, "a"), newEvent(first, 3, "b")},
+			problems: []string{"version 3 follows version 1"},
+		},
+		{
+			name:     "missing first version",
+			events:   []Event{newEvent(first, 2, "a")},
+			problems: []string{"version 2 follows version 0"},
+		},
+		{
+			name:     "duplicated event",
+			events:   []Event{dup, dup},
+			problems: []string{"duplicated event ID", "version 1 follows version 1"},
+		},
+		{
+			name: "aggregate type",
+			events: []Event{newEvent(first, 1, "a"), event.New[any, any](
+				uuid.New(), "mock.event", &mockEventPayload{},
+				event.WithAggregate(first, "other", 2),
+			)},
+			problems: []string{`aggregate type "other" differs from "mock"`},
+		},
+		{
+			name:     "not contiguous",
+			events:   []Event{newEvent(first, 1, "a"), newEvent(second, 1, "b"), newEvent(first, 2, "c")},
+			problems: []string{"the events of the aggregate are not contiguous", "version 2 follows version 0"},
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			ctx := xtenant.WithTenant(context.Background(), "tenant")
+			log := &memoryEventLog{}
+			require.NoError(t, log.Save(ctx, spec.events...))
+
+			v, err := Verify(ctx, log)
+			require.NoError(t, err)
+			assert.Equal(t, len(spec.events), v.Events)
+			assert.Equal(t, len(spec.problems) == 0, v.OK())
+
+			problems := make([]string, 0, len(v.Issues))
+			for _, issue := range v.Issues {
+				problems = append(problems, issue.Problem)
+			}
+			assert.ElementsMatch(t, spec.problems, problems)
+		})
+	}
+
+	_, err := Verify(context.Background(), &memoryEventLog{})
+	assert.ErrorIs(t, err, xtenant.ErrTenantRequired)
+}
+
+func TestVerifyArchive(t *testing.T) {
+	var archiveBuf bytes.Buffer
+	ctx := xtenant.WithTenant(context.Background(), "tenant")
+	log := &memoryEventLog{}
+	require.NoError(t, log.Save(ctx, newEvent(uuid.New(), 1, "a"), newEvent(uuid.New(), 1, "b")))
+
+	_, err := Backup(ctx, &archiveBuf, log, "inmemory")
+	require.NoError(t, err)
+
+	archive, err := Open(bytes.NewReader(archiveBuf.Bytes()))
+	require.NoError(t, err)
+	defer archive.Close()
+
+	v, err := VerifyArchive(archive, newRegistry())
+	require.NoError(t, err)
+	assert.True(t, v.OK())
+	assert.Equal(t, 2, v.Events)
+	assert.Equal(t, 2, v.Aggregates)
+
+	// the payloads of unknown types cannot be restored
+	v, err = VerifyArchive(archive, xevent.NewPayloadRegistry())
+	require.NoError(t, err)
+	assert.Len(t, v.Issues, 2)
+
+	archive.Manifest.Events = 3
+	v, err = VerifyArchive(archive, newRegistry())
+	require.NoError(t, err)
+	require.Len(t, v.Issues, 1)
+	assert.Empty(t, v.Issues[0].AggregateID)
+}
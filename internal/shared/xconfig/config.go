@@ -0,0 +1,411 @@
+// Package xconfig loads the configuration of a service into a struct, from a YAML or TOML file,
+// the environment and the command line flags, each source overriding the previous ones.
+//
+// The fields are bound to the sources with struct tags:
+//
+//	type Config struct {
+//		Database struct {
+//			Host string `config:"host" env:"DB_HOST" usage:"host of the database"`
+//		} `config:"database"`
+//	}
+//
+// The config tag is the key of the field in the file and, joined with the keys of the
+// parent structs, the name of its flag, e.g. -database.host. The env tag is the name of
+// its environment variable, which is read from a file instead when suffixed with _FILE.
+package xconfig
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FileSuffix is appended to the name of an environment variable to read its value from the file
+// at the path it holds, e.g. DB_PASS_FILE=/run/secrets/db-pass, for the Docker and Kubernetes secrets.
+const FileSuffix = "_FILE"
+
+// FileFlag is the name of the flag overriding the path of the configuration file.
+const FileFlag = "config"
+
+// ErrUnsupportedFile is returned when the configuration file is neither YAML nor TOML.
+var ErrUnsupportedFile = errors.New("unsupported config file, must be .yaml, .yml or .toml")
+
+// Validator is implemented by the configurations checking their values once loaded.
+type Validator interface {
+	Validate() error
+}
+
+// Option sets a source of the configuration.
+type Option func(*loader)
+
+// WithFile reads the configuration from the YAML or TOML file at the path, by its extension.
+// An empty path is ignored.
+func WithFile(path string) Option {
+	return func(l *loader) {
+		l.file = path
+	}
+}
+
+// WithEnv reads the configuration from the environment variables found by the lookup,
+// os.LookupEnv when nil.
+func WithEnv(lookup func(key string) (string, bool)) Option {
+	return func(l *loader) {
+		if lookup == nil {
+			lookup = os.LookupEnv
+		}
+		l.lookup = lookup
+	}
+}
+
+// WithFlags defines a flag per field of the configuration on the flag set, along with the
+// -config flag overriding the path of WithFile, and reads the configuration from the
+// flags set in the arguments.
+func WithFlags(fs *flag.FlagSet, args []string) Option {
+	return func(l *loader) {
+		l.flags = fs
+		l.args = args
+	}
+}
+
+// Load fills the configuration, a pointer to a struct holding the defaults, from the file,
+// the environment and the flags, in this order, and validates it when it is a Validator.
+// All the invalid values are reported together.
+func Load(cfg any, opts ...Option) error {
+	root := reflect.ValueOf(cfg)
+	if root.Kind() != reflect.Pointer || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to a struct, got %T", cfg)
+	}
+
+	l := &loader{}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	fields := fieldsOf(root.Elem(), "")
+
+	// the flags are parsed first, as they may set the path of the file
+	flags, err := l.parseFlags(fields)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if l.file != "" {
+		errs = append(errs, l.loadFile(root.Elem())...)
+	}
+	if l.lookup != nil {
+		errs = append(errs, l.loadEnv(fields)...)
+	}
+	for _, f := range fields {
+		if value, ok := flags[f.key]; ok {
+			errs = append(errs, set(f.value, value, "-"+f.key))
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if v, ok := cfg.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+type loader struct {
+	file   string
+	lookup func(key string) (string, bool)
+	flags  *flag.FlagSet
+	args   []string
+}
+
+// field is a settable leaf field of the configuration.
+type field struct {
+	key   string
+	env   string
+	usage string
+	value reflect.Value
+}
+
+// fieldsOf returns the leaf fields of the struct with a config tag, walking the nested structs.
+func fieldsOf(v reflect.Value, prefix string) []field {
+	var fields []field
+	for i := range v.NumField() {
+		sf := v.Type().Field(i)
+		name, ok := sf.Tag.Lookup("config")
+		if !ok || name == "-" || !sf.IsExported() {
+			continue
+		}
+
+		key := prefix + name
+		if isSection(sf.Type) {
+			fields = append(fields, fieldsOf(v.Field(i), key+".")...)
+			continue
+		}
+		fields = append(fields, field{
+			key:   key,
+			env:   sf.Tag.Get("env"),
+			usage: sf.Tag.Get("usage"),
+			value: v.Field(i),
+		})
+	}
+	return fields
+}
+
+// isSection reports whether the type is a nested struct of the configuration, rather than a value.
+func isSection(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct
+}
+
+// parseFlags parses the arguments and returns the values of the flags set, by key.
+func (l *loader) parseFlags(fields []field) (map[string]string, error) {
+	values := make(map[string]string)
+	if l.flags == nil {
+		return values, nil
+	}
+
+	file := l.flags.String(FileFlag, l.file, "path of the YAML or TOML configuration file")
+	for _, f := range fields {
+		setValue := func(s string) error {
+			values[f.key] = s
+			return nil
+		}
+		// the boolean flags can be set without a value, e.g. -auth.enabled
+		if f.value.Kind() == reflect.Bool {
+			l.flags.BoolFunc(f.key, f.usage, setValue)
+			continue
+		}
+		l.flags.Func(f.key, f.usage, setValue)
+	}
+
+	if err := l.flags.Parse(l.args); err != nil {
+		return nil, err
+	}
+
+	l.file = *file
+	return values, nil
+}
+
+// loadFile sets the fields with the values of the file.
+func (l *loader) loadFile(root reflect.Value) []error {
+	data, err := os.ReadFile(l.file)
+	if err != nil {
+		return []error{fmt.Errorf("error reading config file: %w", err)}
+	}
+
+	values := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(l.file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return []error{fmt.Errorf("%w: %s", ErrUnsupportedFile, l.file)}
+	}
+	if err != nil {
+		return []error{fmt.Errorf("error parsing config file %s: %w", l.file, err)}
+	}
+
+	return l.setSection(root, values, "")
+}
+
+// setSection sets the fields of the struct with the values of the file, rejecting the unknown keys
+// so the typos do not go unnoticed.
+func (l *loader) setSection(v reflect.Value, values map[string]any, prefix string) []error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		value := values[name]
+		key := prefix + name
+		source := l.file + ": " + key
+
+		fv, ok := fieldByKey(v, name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key", source))
+			continue
+		}
+
+		section, isMap := value.(map[string]any)
+		switch {
+		case isSection(fv.Type()) && isMap:
+			errs = append(errs, l.setSection(fv, section, key+".")...)
+		case isSection(fv.Type()):
+			errs = append(errs, fmt.Errorf("%s: must be a table of keys", source))
+		case isMap && fv.Kind() == reflect.Map:
+			errs = append(errs, setMap(fv, section, source))
+		case value == nil:
+			// an empty key keeps the default value
+		default:
+			if _, isList := value.([]any); isList || isMap {
+				errs = append(errs, fmt.Errorf("%s: must be a single value", source))
+				continue
+			}
+			errs = append(errs, set(fv, fmt.Sprint(value), source))
+		}
+	}
+	return errs
+}
+
+// fieldByKey returns the field of the struct with the config tag.
+func fieldByKey(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := range v.NumField() {
+		sf := v.Type().Field(i)
+		if tag, ok := sf.Tag.Lookup("config"); ok && tag == name && sf.IsExported() {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// loadEnv sets the fields with the environment variables, or with the files they point to.
+func (l *loader) loadEnv(fields []field) []error {
+	var errs []error
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+
+		value, ok, err := l.env(f.env)
+		switch {
+		case err != nil:
+			errs = append(errs, err)
+		case ok:
+			errs = append(errs, set(f.value, value, f.env))
+		}
+	}
+	return errs
+}
+
+// env returns the value of the environment variable, read from the file of NAME_FILE when set.
+func (l *loader) env(name string) (string, bool, error) {
+	value, ok := l.lookup(name)
+	path, fromFile := l.lookup(name + FileSuffix)
+	if !fromFile {
+		return unquote(value), ok, nil
+	}
+	if ok {
+		return "", false, fmt.Errorf("%s: both %s and %s%s are set", name, name, name, FileSuffix)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s%s: %w", name, FileSuffix, err)
+	}
+	// the secrets usually end with a line break
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// unquote removes the spaces and the double quotes around the value, as left by some env files.
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 1 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		value = value[1 : len(value)-1]
+	}
+	return value
+}
+
+// set parses the value into the field, by its type.
+func set(v reflect.Value, value, source string) error {
+	var err error
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		var d time.Duration
+		d, err = time.ParseDuration(value)
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.Map:
+		var m map[string]any
+		m, err = parsePairs(value)
+		if err == nil {
+			return setMap(v, m, source)
+		}
+	case v.Kind() == reflect.String:
+		v.SetString(value)
+	case v.Kind() == reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(value)
+		v.SetBool(b)
+	case v.CanInt():
+		var i int64
+		i, err = strconv.ParseInt(value, 10, v.Type().Bits())
+		v.SetInt(i)
+	case v.CanUint():
+		var u uint64
+		u, err = strconv.ParseUint(value, 10, v.Type().Bits())
+		v.SetUint(u)
+	case v.CanFloat():
+		var f float64
+		f, err = strconv.ParseFloat(value, v.Type().Bits())
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%s: unsupported type %s", source, v.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid value %q for %s", source, value, typeName(v.Type()))
+	}
+	return nil
+}
+
+// setMap replaces the map of strings of the field with the values.
+func setMap(v reflect.Value, values map[string]any, source string) error {
+	if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("%s: unsupported type %s", source, v.Type())
+	}
+
+	m := reflect.MakeMapWithSize(v.Type(), len(values))
+	for key, value := range values {
+		m.SetMapIndex(
+			reflect.ValueOf(key).Convert(v.Type().Key()),
+			reflect.ValueOf(fmt.Sprint(value)).Convert(v.Type().Elem()),
+		)
+	}
+	v.Set(m)
+	return nil
+}
+
+// parsePairs parses a comma separated list of key=value pairs.
+func parsePairs(s string) (map[string]any, error) {
+	pairs := make(map[string]any)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid pair %q, must be key=value", pair)
+		}
+		pairs[key] = strings.TrimSpace(value)
+	}
+	return pairs, nil
+}
+
+// typeName describes the expected type in the errors.
+func typeName(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return "a duration, e.g. 5s"
+	case t.Kind() == reflect.Map:
+		return "a list of key=value pairs"
+	case t.Kind() == reflect.Bool:
+		return "a boolean"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "a number"
+	default:
+		return "an integer"
+	}
+}
@@ -0,0 +1,225 @@
+package xconfig_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xconfig"
+)
+
+type testConfig struct {
+	Name    string            `config:"name" env:"TEST_NAME"`
+	Debug   bool              `config:"debug" env:"TEST_DEBUG"`
+	Timeout time.Duration     `config:"timeout" env:"TEST_TIMEOUT"`
+	Ratio   float64           `config:"ratio" env:"TEST_RATIO"`
+	Labels  map[string]string `config:"labels" env:"TEST_LABELS"`
+	Ignored string
+	DB      struct {
+		Port     int    `config:"port" env:"TEST_DB_PORT"`
+		Password string `config:"password" env:"TEST_DB_PASS"`
+	} `config:"db"`
+}
+
+func (c testConfig) Validate() error {
+	if c.DB.Password == "" {
+		return errors.New("db password is required")
+	}
+	return nil
+}
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Files(t *testing.T) {
+	var specs = []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			content: `
+name: finantrack
+debug: true
+timeout: 5s
+ratio: 0.5
+labels:
+  team: finances
+db:
+  port: 27017
+  password: secret
+`,
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			content: `
+name = "finantrack"
+debug = true
+timeout = "5s"
+ratio = 0.5
+labels = { team = "finances" }
+
+[db]
+port = 27017
+password = "secret"
+`,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			var cfg testConfig
+			err := Load(&cfg, WithFile(writeFile(t, spec.file, spec.content)))
+			require.NoError(t, err)
+
+			assert.Equal(t, "finantrack", cfg.Name)
+			assert.True(t, cfg.Debug)
+			assert.Equal(t, 5*time.Second, cfg.Timeout)
+			assert.Equal(t, 0.5, cfg.Ratio)
+			assert.Equal(t, map[string]string{"team": "finances"}, cfg.Labels)
+			assert.Equal(t, 27017, cfg.DB.Port)
+			assert.Equal(t, "secret", cfg.DB.Password)
+		})
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	file := writeFile(t, "config.yml", "name: file\nratio: 0.1\ndb:\n  port: 1\n  password: file\n")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	cfg := testConfig{Name: "default", Ignored: "default"}
+	err := Load(&cfg,
+		WithFile(file),
+		WithEnv(env(map[string]string{"TEST_RATIO": "0.2", "TEST_DB_PORT": "2", "TEST_LABELS": "a=1, b=2"})),
+		WithFlags(fs, []string{"-db.port", "3", "-debug"}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "file", cfg.Name)
+	assert.Equal(t, 0.2, cfg.Ratio)
+	assert.Equal(t, 3, cfg.DB.Port)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, cfg.Labels)
+	assert.Equal(t, "default", cfg.Ignored)
+}
+
+func TestLoad_FileFlag(t *testing.T) {
+	file := writeFile(t, "config.yaml", "db:\n  password: from-flag\n")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	var cfg testConfig
+	err := Load(&cfg, WithFile("missing.yaml"), WithFlags(fs, []string{"-" + FileFlag, file}))
+	require.NoError(t, err)
+	assert.Equal(t, "from-flag", cfg.DB.Password)
+}
+
+func TestLoad_EnvFromFile(t *testing.T) {
+	secret := writeFile(t, "db-pass", "s3cr3t\n")
+
+	var cfg testConfig
+	err := Load(&cfg, WithEnv(env(map[string]string{"TEST_DB_PASS" + FileSuffix: secret, "TEST_NAME": `"quoted"`})))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", cfg.DB.Password)
+	assert.Equal(t, "quoted", cfg.Name)
+
+	err = Load(&cfg, WithEnv(env(map[string]string{"TEST_DB_PASS": "x", "TEST_DB_PASS" + FileSuffix: secret})))
+	assert.ErrorContains(t, err, "both TEST_DB_PASS and TEST_DB_PASS_FILE are set")
+
+	err = Load(&cfg, WithEnv(env(map[string]string{"TEST_DB_PASS" + FileSuffix: "missing"})))
+	assert.ErrorContains(t, err, "TEST_DB_PASS_FILE")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	var specs = []struct {
+		name     string
+		opts     func(t *testing.T) []Option
+		contains []string
+		is       error
+	}{
+		{
+			name: "invalid values are reported together",
+			opts: func(*testing.T) []Option {
+				return []Option{WithEnv(env(map[string]string{"TEST_DB_PORT": "abc", "TEST_TIMEOUT": "5", "TEST_DEBUG": "maybe"}))}
+			},
+			contains: []string{
+				`TEST_DB_PORT: invalid value "abc" for an integer`,
+				`TEST_TIMEOUT: invalid value "5" for a duration`,
+				`TEST_DEBUG: invalid value "maybe" for a boolean`,
+			},
+		},
+		{
+			name: "unknown key",
+			opts: func(t *testing.T) []Option {
+				return []Option{WithFile(writeFile(t, "config.yaml", "db:\n  prot: 1\n"))}
+			},
+			contains: []string{"db.prot: unknown key"},
+		},
+		{
+			name: "section as a value",
+			opts: func(t *testing.T) []Option {
+				return []Option{WithFile(writeFile(t, "config.yaml", "db: local\n"))}
+			},
+			contains: []string{"db: must be a table of keys"},
+		},
+		{
+			name: "unsupported file",
+			opts: func(t *testing.T) []Option {
+				return []Option{WithFile(writeFile(t, "config.json", "{}"))}
+			},
+			is: ErrUnsupportedFile,
+		},
+		{
+			name: "validation",
+			opts: func(*testing.T) []Option {
+				return []Option{WithEnv(env(nil))}
+			},
+			contains: []string{"db password is required"},
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			var cfg testConfig
+			err := Load(&cfg, spec.opts(t)...)
+			require.Error(t, err)
+			for _, s := range spec.contains {
+				assert.ErrorContains(t, err, s)
+			}
+			if spec.is != nil {
+				assert.ErrorIs(t, err, spec.is)
+			}
+		})
+	}
+}
+
+func TestLoad_Help(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var cfg testConfig
+	err := Load(&cfg, WithFlags(fs, []string{"-h"}))
+	assert.ErrorIs(t, err, flag.ErrHelp)
+	assert.NotNil(t, fs.Lookup("db.password"))
+	assert.Nil(t, fs.Lookup("Ignored"))
+}
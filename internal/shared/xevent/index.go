@@ -0,0 +1,35 @@
+package xevent
+
+import "time"
+
+// Dated is implemented by the payloads of the events recording a fact of another date
+// than the time they were saved, e.g. a transaction booked before it was imported.
+// The event stores keep the date, so the events can be queried by it.
+type Dated interface {
+	EventDate() time.Time
+}
+
+// Child is implemented by the payloads of the events of an aggregate that belongs to
+// another one, e.g. a transaction of an asset.
+// The event stores keep the parent, so the events can be queried by it.
+type Child interface {
+	ParentID() string
+}
+
+// DateOf returns the date of the fact recorded by the given payload, if it is dated.
+func DateOf(payload any) (time.Time, bool) {
+	dated, ok := payload.(Dated)
+	if !ok || dated.EventDate().IsZero() {
+		return time.Time{}, false
+	}
+	return dated.EventDate(), true
+}
+
+// ParentOf returns the ID of the parent aggregate of the given payload, if any.
+func ParentOf(payload any) (string, bool) {
+	child, ok := payload.(Child)
+	if !ok || child.ParentID() == "" {
+		return "", false
+	}
+	return child.ParentID(), true
+}
@@ -0,0 +1,58 @@
+package xevent
+
+import "context"
+
+// Metadata represents the contextual information stored alongside an event.
+// It allows to audit who produced a change and to correlate it with
+// requests, commands and distributed traces.
+type Metadata struct {
+	// CorrelationID identifies the whole flow the event belongs to.
+	CorrelationID string `json:"correlation_id,omitempty" bson:"correlation_id,omitempty"`
+
+	// CausationID identifies the message (command) that caused the event.
+	CausationID string `json:"causation_id,omitempty" bson:"causation_id,omitempty"`
+
+	// RequestID identifies the inbound request that started the flow.
+	RequestID string `json:"request_id,omitempty" bson:"request_id,omitempty"`
+
+	// UserID identifies the authenticated user that produced the event.
+	UserID string `json:"user_id,omitempty" bson:"user_id,omitempty"`
+
+	// Source is the name of the service that produced the event.
+	Source string `json:"source,omitempty" bson:"source,omitempty"`
+
+	// TraceID is the OpenTelemetry trace identifier.
+	TraceID string `json:"trace_id,omitempty" bson:"trace_id,omitempty"`
+}
+
+// IsZero checks if none of the metadata fields is set.
+func (m Metadata) IsZero() bool {
+	return m == Metadata{}
+}
+
+// MetadataCarrier is implemented by events that carry metadata.
+type MetadataCarrier interface {
+	Metadata() Metadata
+}
+
+// MetadataOf returns the metadata carried by the given event, if any.
+func MetadataOf(e any) (Metadata, bool) {
+	carrier, ok := e.(MetadataCarrier)
+	if !ok {
+		return Metadata{}, false
+	}
+	return carrier.Metadata(), true
+}
+
+type metadataContextKey struct{}
+
+// WithMetadata returns a copy of the context that carries the given metadata.
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataContextKey{}, md)
+}
+
+// MetadataFromContext returns the metadata carried by the context, if any.
+func MetadataFromContext(ctx context.Context) (Metadata, bool) {
+	md, ok := ctx.Value(metadataContextKey{}).(Metadata)
+	return md, ok
+}
@@ -0,0 +1,201 @@
+// Package xhealth checks the health of the components the service depends on,
+// such as its databases, to report whether it is ready to serve requests.
+package xhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the time a checker has to report the health of its component.
+const DefaultTimeout = 2 * time.Second
+
+// Status is the health of a component or of the whole service.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+
+	// StatusDegraded is the status of the service when only optional components are down,
+	// so it still serves requests, e.g. with its projections lagging behind the events.
+	StatusDegraded Status = "degraded"
+)
+
+// Checker checks the health of a component, returning an error when it is unhealthy.
+// The checks must honor the cancellation of the context.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is a function implementing Checker, e.g. the Ping of a database client.
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls the function.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ComponentReport is the outcome of the check of a component.
+type ComponentReport struct {
+	Status   Status `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// Report is the outcome of the checks of all the components.
+// The service is up when all of them are, degraded when only optional ones are down
+// and down when any other is.
+type Report struct {
+	Status     Status                     `json:"status"`
+	Components map[string]ComponentReport `json:"components,omitempty"`
+}
+
+// Option configures the health checks.
+type Option func(*Health)
+
+// WithTimeout sets the time every checker has to report the health of its component.
+func WithTimeout(timeout time.Duration) Option {
+	return func(h *Health) {
+		h.timeout = timeout
+	}
+}
+
+// Health is a set of named checkers, run together to report the health of the service.
+// It is safe for concurrent use.
+type Health struct {
+	mu       sync.RWMutex
+	checkers map[string]registration
+	timeout  time.Duration
+}
+
+// registration is a checker registered with its options.
+type registration struct {
+	checker  Checker
+	optional bool
+}
+
+// New returns a Health without checkers, always up until some are registered.
+func New(opts ...Option) *Health {
+	h := &Health{
+		checkers: make(map[string]registration),
+		timeout:  DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Register adds the checker of the component with the given name, replacing the previous one.
+func (h *Health) Register(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkers[name] = registration{checker: checker}
+}
+
+// RegisterOptional adds the checker of a component the service can serve requests without,
+// replacing the previous one. The service is degraded rather than down when it is down.
+func (h *Health) RegisterOptional(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checkers[name] = registration{checker: checker, optional: true}
+}
+
+// Components returns the names of the registered components, sorted.
+func (h *Health) Components() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	names := make([]string, 0, len(h.checkers))
+	for name := range h.checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check runs all the checkers concurrently, each one with the timeout of the health checks,
+// and reports the health of every component.
+func (h *Health) Check(ctx context.Context) Report {
+	h.mu.RLock()
+	checkers := make(map[string]registration, len(h.checkers))
+	for name, checker := range h.checkers {
+		checkers[name] = checker
+	}
+	h.mu.RUnlock()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = Report{Status: StatusUp, Components: make(map[string]ComponentReport, len(checkers))}
+	)
+
+	for name, r := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			component := h.check(ctx, r.checker)
+			component.Optional = r.optional
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[name] = component
+			switch {
+			case component.Status == StatusUp:
+			case !component.Optional:
+				report.Status = StatusDown
+			case report.Status == StatusUp:
+				report.Status = StatusDegraded
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// check runs the checker with the timeout, giving up on it when it does not honor the context.
+func (h *Health) check(ctx context.Context, checker Checker) ComponentReport {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	component := ComponentReport{
+		Status:   StatusUp,
+		Duration: time.Since(start).Round(time.Microsecond).String(),
+	}
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", h.timeout)
+		}
+		component.Status = StatusDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
@@ -0,0 +1,109 @@
+package xhealth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhealth"
+)
+
+func TestHealth_Check(t *testing.T) {
+	up := CheckerFunc(func(context.Context) error { return nil })
+	down := CheckerFunc(func(context.Context) error { return errors.New("connection refused") })
+	slow := CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	stuck := CheckerFunc(func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	var specs = []struct {
+		name       string
+		checkers   map[string]Checker
+		optional   map[string]Checker
+		status     Status
+		components map[string]Status
+		errors     map[string]string
+	}{
+		{
+			name:       "without checkers",
+			status:     StatusUp,
+			components: map[string]Status{},
+		},
+		{
+			name:       "all up",
+			checkers:   map[string]Checker{"event_store": up, "projections": up},
+			status:     StatusUp,
+			components: map[string]Status{"event_store": StatusUp, "projections": StatusUp},
+		},
+		{
+			name:       "one down",
+			checkers:   map[string]Checker{"event_store": down, "projections": up},
+			status:     StatusDown,
+			components: map[string]Status{"event_store": StatusDown, "projections": StatusUp},
+			errors:     map[string]string{"event_store": "connection refused"},
+		},
+		{
+			name:       "timed out",
+			checkers:   map[string]Checker{"event_store": slow, "outbox": stuck},
+			status:     StatusDown,
+			components: map[string]Status{"event_store": StatusDown, "outbox": StatusDown},
+			errors:     map[string]string{"event_store": "timed out after 50ms", "outbox": "timed out after 50ms"},
+		},
+		{
+			name:       "optional down is degraded",
+			checkers:   map[string]Checker{"event_store": up},
+			optional:   map[string]Checker{"projections": down},
+			status:     StatusDegraded,
+			components: map[string]Status{"event_store": StatusUp, "projections": StatusDown},
+			errors:     map[string]string{"projections": "connection refused"},
+		},
+		{
+			name:       "optional and required down is down",
+			checkers:   map[string]Checker{"event_store": down},
+			optional:   map[string]Checker{"projections": slow},
+			status:     StatusDown,
+			components: map[string]Status{"event_store": StatusDown, "projections": StatusDown},
+			errors:     map[string]string{"event_store": "connection refused", "projections": "timed out after 50ms"},
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			health := New(WithTimeout(50 * time.Millisecond))
+			for name, checker := range spec.checkers {
+				health.Register(name, checker)
+			}
+			for name, checker := range spec.optional {
+				health.RegisterOptional(name, checker)
+			}
+
+			start := time.Now()
+			report := health.Check(context.Background())
+			assert.Less(t, time.Since(start), 500*time.Millisecond, "the checks run concurrently and time out")
+
+			assert.Equal(t, spec.status, report.Status)
+			assert.Len(t, report.Components, len(spec.components))
+			for name, status := range spec.components {
+				assert.Equal(t, status, report.Components[name].Status, name)
+				assert.NotEmpty(t, report.Components[name].Duration, name)
+				assert.Equal(t, spec.errors[name], report.Components[name].Error, name)
+				assert.Equal(t, spec.optional[name] != nil, report.Components[name].Optional, name)
+			}
+		})
+	}
+}
+
+func TestHealth_Components(t *testing.T) {
+	health := New()
+	health.Register("projections", CheckerFunc(func(context.Context) error { return nil }))
+	health.Register("event_store", CheckerFunc(func(context.Context) error { return nil }))
+
+	assert.Equal(t, []string{"event_store", "projections"}, health.Components())
+}
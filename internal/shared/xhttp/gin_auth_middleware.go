@@ -0,0 +1,87 @@
+package xhttp
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+)
+
+// APIKeyHeader is the header used to send API keys.
+const APIKeyHeader = "X-API-Key"
+
+// AuthOption configures the authentication middleware.
+type AuthOption func(*authOptions)
+
+type authOptions struct {
+	bearer xauth.Authenticator
+	apiKey xauth.Authenticator
+}
+
+// WithBearerAuthenticator verifies the bearer tokens sent in the Authorization header.
+func WithBearerAuthenticator(a xauth.Authenticator) AuthOption {
+	return func(o *authOptions) {
+		o.bearer = a
+	}
+}
+
+// WithAPIKeyAuthenticator verifies the API keys sent in the X-API-Key header.
+func WithAPIKeyAuthenticator(a xauth.Authenticator) AuthOption {
+	return func(o *authOptions) {
+		o.apiKey = a
+	}
+}
+
+// GinAuthentication rejects the requests that carry no valid credentials.
+// The authenticated principal is attached to the request context,
+// and its subject to the event metadata as the user ID.
+func GinAuthentication(opts ...AuthOption) gin.HandlerFunc {
+	var o authOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c *gin.Context) {
+		authenticator, credential := o.credential(c)
+		if authenticator == nil {
+			c.Header("WWW-Authenticate", `Bearer realm="finantrack"`)
+			AbortWithError(c, xauth.ErrUnauthenticated)
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		principal, err := authenticator.Authenticate(ctx, credential)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="finantrack", error="invalid_token"`)
+			AbortWithError(c, err)
+			return
+		}
+
+		md, _ := xevent.MetadataFromContext(ctx)
+		md.UserID = principal.Subject
+
+		ctx = xauth.WithPrincipal(ctx, principal)
+		ctx = xevent.WithMetadata(ctx, md)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// credential returns the credential sent in the request along with
+// the authenticator that verifies it. API keys take precedence over bearer tokens.
+func (o authOptions) credential(c *gin.Context) (xauth.Authenticator, string) {
+	if key := c.GetHeader(APIKeyHeader); key != "" && o.apiKey != nil {
+		return o.apiKey, key
+	}
+
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") && token != "" && o.bearer != nil {
+		return o.bearer, strings.TrimSpace(token)
+	}
+
+	return nil, ""
+}
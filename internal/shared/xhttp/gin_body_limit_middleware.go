@@ -0,0 +1,26 @@
+package xhttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBodyBytes is the default size limit of the request bodies, large enough
+// for the bank statements of several years.
+const DefaultMaxBodyBytes int64 = 10 << 20
+
+// GinBodyLimit rejects the requests with a body over maxBytes.
+// Requests declaring a larger Content-Length are rejected before their body is read,
+// otherwise reading past the limit fails and the error is translated into a 413 problem.
+func GinBodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			AbortWithError(c, ErrRequestTooLarge)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
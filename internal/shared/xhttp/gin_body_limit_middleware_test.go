@@ -0,0 +1,124 @@
+package xhttp_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+func TestGinBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newUpload := func(t *testing.T, content string) (io.Reader, string) {
+		t.Helper()
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("file", "statement.csv")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+		return &body, form.FormDataContentType()
+	}
+
+	var specs = []struct {
+		name           string
+		body           func(t *testing.T) (io.Reader, string)
+		chunked        bool
+		idempotent     bool
+		expectedStatus int
+	}{
+		{
+			name: "accepts a body under the limit",
+			body: func(*testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"name":"savings"}`), "application/json"
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "rejects a declared body over the limit",
+			body: func(*testing.T) (io.Reader, string) {
+				return strings.NewReader(strings.Repeat("a", 128)), "application/json"
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "rejects a streamed body over the limit",
+			body: func(*testing.T) (io.Reader, string) {
+				return strings.NewReader(strings.Repeat("a", 128)), "application/json"
+			},
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "rejects an uploaded file over the limit",
+			body: func(t *testing.T) (io.Reader, string) {
+				return newUpload(t, strings.Repeat("a", 128))
+			},
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "rejects an idempotent request over the limit",
+			body: func(*testing.T) (io.Reader, string) {
+				return strings.NewReader(strings.Repeat("a", 128)), "application/json"
+			},
+			chunked:        true,
+			idempotent:     true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(GinErrorTranslator(NewErrorTranslator()))
+			r.Use(GinBodyLimit(64))
+			r.Use(GinIdempotency(NewInMemoryIdempotencyStore(time.Minute)))
+			r.POST("/assets", func(c *gin.Context) {
+				if strings.HasPrefix(c.ContentType(), "multipart/") {
+					if _, err := c.FormFile("file"); err != nil {
+						AbortWithError(c, InvalidRequest(err))
+						return
+					}
+				} else if _, err := io.ReadAll(c.Request.Body); err != nil {
+					AbortWithError(c, InvalidRequest(err))
+					return
+				}
+				c.Status(http.StatusNoContent)
+			})
+
+			body, contentType := spec.body(t)
+			req := httptest.NewRequest(http.MethodPost, "/assets", body)
+			req.Header.Set("Content-Type", contentType)
+			if spec.chunked {
+				req.ContentLength = -1
+			}
+			if spec.idempotent {
+				req.Header.Set(IdempotencyKeyHeader, "key-1")
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, spec.expectedStatus, w.Code)
+			if spec.expectedStatus == http.StatusRequestEntityTooLarge {
+				var problem Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				assert.Equal(t, CodeRequestTooLarge, problem.Code)
+			}
+		})
+	}
+}
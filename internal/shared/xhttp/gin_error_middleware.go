@@ -0,0 +1,32 @@
+package xhttp
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// AbortWithError records the error in the context and stops the request chain.
+// The response is written by the error middleware registered with WithErrorTranslation.
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// GinErrorTranslator writes the last error recorded by the handlers
+// as a problem details response, unless a response was already written.
+func GinErrorTranslator(translator *ErrorTranslator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		err := c.Errors.Last()
+		if err == nil || c.Writer.Written() {
+			return
+		}
+
+		problem := translator.Translate(err.Err)
+		problem.Instance = c.Request.URL.Path
+
+		// gin keeps the content type when it is already set
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
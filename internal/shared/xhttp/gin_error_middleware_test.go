@@ -0,0 +1,88 @@
+package xhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+func TestGinErrorTranslator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var (
+		errNotFound = errors.New("resource not found")
+		errConflict = errors.New("resource already exists")
+	)
+
+	var specs = []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+	}{
+		{
+			name:           "mapped error",
+			err:            errNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+			expectedDetail: "resource not found",
+		},
+		{
+			name:           "wrapped mapped error",
+			err:            fmt.Errorf("failed to save: %w", errConflict),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "conflict",
+			expectedDetail: "failed to save: resource already exists",
+		},
+		{
+			name:           "invalid request",
+			err:            InvalidRequest(errors.New("limit must be positive")),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeInvalidRequest,
+			expectedDetail: "invalid request: limit must be positive",
+		},
+		{
+			name:           "unmapped error",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   CodeInternalError,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(GinErrorTranslator(NewErrorTranslator(
+				MapError(errNotFound, http.StatusNotFound, "not_found"),
+				MapError(errConflict, http.StatusConflict, "conflict"),
+			)))
+			router.GET("/resources", func(c *gin.Context) {
+				AbortWithError(c, spec.err)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/resources", nil))
+
+			assert.Equal(t, spec.expectedStatus, rec.Code)
+			assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, spec.expectedStatus, problem.Status)
+			assert.Equal(t, spec.expectedCode, problem.Code)
+			assert.Equal(t, spec.expectedDetail, problem.Detail)
+			assert.Equal(t, http.StatusText(spec.expectedStatus), problem.Title)
+			assert.Equal(t, "/resources", problem.Instance)
+		})
+	}
+}
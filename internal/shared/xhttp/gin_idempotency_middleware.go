@@ -0,0 +1,178 @@
+package xhttp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+)
+
+const (
+	// IdempotencyKeyHeader is the header used to send the idempotency key of a request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on the responses replayed from a previous request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// GinIdempotency makes the mutating requests sent with an Idempotency-Key header safe to retry.
+// The first response of a key is recorded and replayed on retries with the same request,
+// while the reuse of a key with a different request is rejected.
+// Only successful responses are recorded, so failed requests are executed again on retry.
+// Keys are scoped to the authenticated principal, if any.
+func GinIdempotency(store IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		if principal, ok := xauth.PrincipalFromContext(ctx); ok {
+			key = principal.Subject + ":" + key
+		}
+
+		// the body is kept for the handlers, its size is bounded by GinBodyLimit
+		var body bytes.Buffer
+		if _, err := io.Copy(&body, c.Request.Body); err != nil {
+			AbortWithError(c, InvalidRequest(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body.Bytes()))
+		fingerprint := requestFingerprint(c.Request, body.Bytes())
+
+		record, reserved, err := store.Reserve(ctx, IdempotencyRecord{
+			Key:         key,
+			Fingerprint: fingerprint,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			AbortWithError(c, fmt.Errorf("failed to reserve idempotency key: %w", err))
+			return
+		}
+
+		if !reserved {
+			replay(c, record, fingerprint)
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		if len(c.Errors) > 0 || writer.Status() >= http.StatusInternalServerError {
+			_ = store.Release(ctx, record.Key)
+			return
+		}
+
+		record.Completed = true
+		record.Status = writer.Status()
+		record.ContentType = writer.Header().Get("Content-Type")
+		record.Body = writer.body.Bytes()
+		if err = store.Complete(ctx, record); err != nil {
+			_ = c.Error(fmt.Errorf("failed to record idempotent response: %w", err))
+		}
+	}
+}
+
+// replay writes the response recorded for the key, if the request matches the recorded one.
+func replay(c *gin.Context, record IdempotencyRecord, fingerprint string) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		AbortWithError(c, ErrIdempotencyKeyReused)
+	case !record.Completed:
+		AbortWithError(c, ErrIdempotencyKeyInProgress)
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(record.Status, record.ContentType, record.Body)
+		c.Abort()
+	}
+}
+
+// requestFingerprint identifies a request by its method, path, query, tenant and body.
+// The multipart bodies are identified by their parts, as their boundary changes
+// between the retries of most clients.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	for _, field := range []string{r.Method, r.URL.Path, r.URL.Query().Encode(), r.Header.Get("X-Household-ID")} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+
+	if parts, ok := multipartFingerprint(r.Header.Get("Content-Type"), body); ok {
+		h.Write(parts)
+	} else {
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// multipartFingerprint hashes the names, file names, content types and contents of the parts
+// of a multipart body. It returns false when the body is not multipart or is malformed.
+func multipartFingerprint(contentType string, body []byte) ([]byte, bool) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return nil, false
+	}
+
+	h := sha256.New()
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return h.Sum(nil), true
+		}
+		if err != nil {
+			return nil, false
+		}
+
+		for _, field := range []string{part.FormName(), part.FileName(), part.Header.Get("Content-Type")} {
+			h.Write([]byte(field))
+			h.Write([]byte{0})
+		}
+
+		content := sha256.New()
+		if _, err = io.Copy(content, part); err != nil {
+			return nil, false
+		}
+		h.Write(content.Sum(nil))
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// recordingWriter is a gin.ResponseWriter that keeps a copy of the response body.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,200 @@
+package xhttp_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+// multipartBody returns a multipart body uploading the statement with the given boundary, and its content type.
+func multipartBody(t *testing.T, boundary, statement string) (string, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	require.NoError(t, w.SetBoundary(boundary))
+	require.NoError(t, w.WriteField("source", "csv"))
+	part, err := w.CreateFormFile("file", "statement.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(statement))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return body.String(), w.FormDataContentType()
+}
+
+func TestGinIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		method      string
+		target      string
+		key         string
+		body        string
+		contentType string
+	}
+
+	var (
+		upload, uploadType           = multipartBody(t, "boundary-1", "2024-01-01,-2.5,Coffee")
+		retry, retryType             = multipartBody(t, "boundary-2", "2024-01-01,-2.5,Coffee")
+		otherUpload, otherUploadType = multipartBody(t, "boundary-3", "2024-01-02,-900,Rent")
+	)
+
+	var specs = []struct {
+		name           string
+		requests       []request
+		expectedStatus int
+		expectedCalls  int
+		expectReplayed bool
+	}{
+		{
+			name: "replays the response of a retried request",
+			requests: []request{
+				{method: http.MethodPost, key: "key-1", body: `{"name":"savings"}`},
+				{method: http.MethodPost, key: "key-1", body: `{"name":"savings"}`},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCalls:  1,
+			expectReplayed: true,
+		},
+		{
+			name: "rejects a reused key with a different payload",
+			requests: []request{
+				{method: http.MethodPost, key: "key-1", body: `{"name":"savings"}`},
+				{method: http.MethodPost, key: "key-1", body: `{"name":"checking"}`},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCalls:  1,
+		},
+		{
+			name: "rejects a reused key with a different query",
+			requests: []request{
+				{method: http.MethodPost, target: "/assets?dryRun=true", key: "key-1", body: `{"name":"savings"}`},
+				{method: http.MethodPost, target: "/assets", key: "key-1", body: `{"name":"savings"}`},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCalls:  1,
+		},
+		{
+			name: "replays a retried upload sent with another multipart boundary",
+			requests: []request{
+				{method: http.MethodPost, key: "key-1", body: upload, contentType: uploadType},
+				{method: http.MethodPost, key: "key-1", body: retry, contentType: retryType},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCalls:  1,
+			expectReplayed: true,
+		},
+		{
+			name: "rejects a reused key with a different upload",
+			requests: []request{
+				{method: http.MethodPost, key: "key-1", body: upload, contentType: uploadType},
+				{method: http.MethodPost, key: "key-1", body: otherUpload, contentType: otherUploadType},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCalls:  1,
+		},
+		{
+			name: "executes requests without key",
+			requests: []request{
+				{method: http.MethodPost, body: `{"name":"savings"}`},
+				{method: http.MethodPost, body: `{"name":"savings"}`},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCalls:  2,
+		},
+		{
+			name: "ignores safe methods",
+			requests: []request{
+				{method: http.MethodGet, key: "key-1"},
+				{method: http.MethodGet, key: "key-1"},
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCalls:  2,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			calls := 0
+			r := gin.New()
+			r.Use(GinErrorTranslator(NewErrorTranslator()))
+			r.Use(GinIdempotency(NewInMemoryIdempotencyStore(time.Minute)))
+			r.Handle(http.MethodPost, "/assets", func(c *gin.Context) {
+				calls++
+				c.JSON(http.StatusCreated, gin.H{"calls": calls})
+			})
+			r.Handle(http.MethodGet, "/assets", func(c *gin.Context) {
+				calls++
+				c.JSON(http.StatusCreated, gin.H{"calls": calls})
+			})
+
+			var (
+				w     *httptest.ResponseRecorder
+				first string
+			)
+			for i, req := range spec.requests {
+				target := req.target
+				if target == "" {
+					target = "/assets"
+				}
+
+				httpReq := httptest.NewRequest(req.method, target, strings.NewReader(req.body))
+				if req.key != "" {
+					httpReq.Header.Set(IdempotencyKeyHeader, req.key)
+				}
+				if req.contentType != "" {
+					httpReq.Header.Set("Content-Type", req.contentType)
+				}
+
+				w = httptest.NewRecorder()
+				r.ServeHTTP(w, httpReq)
+				if i == 0 {
+					first = w.Body.String()
+				}
+			}
+
+			assert.Equal(t, spec.expectedStatus, w.Code)
+			assert.Equal(t, spec.expectedCalls, calls)
+			if spec.expectReplayed {
+				assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+				assert.Equal(t, first, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGinIdempotency_ReleasesFailedRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	r := gin.New()
+	r.Use(GinErrorTranslator(NewErrorTranslator()))
+	r.Use(GinIdempotency(NewInMemoryIdempotencyStore(time.Minute)))
+	r.POST("/assets", func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			AbortWithError(c, InvalidRequest(assert.AnError))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/assets", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 2, calls)
+}
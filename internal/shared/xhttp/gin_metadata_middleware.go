@@ -0,0 +1,48 @@
+package xhttp
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+)
+
+const (
+	// RequestIDHeader is the header used to propagate the request ID.
+	RequestIDHeader = "X-Request-ID"
+
+	// CorrelationIDHeader is the header used to propagate the correlation ID.
+	CorrelationIDHeader = "X-Correlation-ID"
+)
+
+// GinRequestMetadata attaches the event metadata of the request to its context,
+// so it can flow through the command bus down to the event store.
+// The request and correlation IDs are taken from the request headers or generated
+// when missing, and echoed back in the response headers.
+func GinRequestMetadata(source string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = requestID
+		}
+
+		ctx := c.Request.Context()
+
+		md, _ := xevent.MetadataFromContext(ctx)
+		md.RequestID = requestID
+		md.CorrelationID = correlationID
+		md.Source = source
+		md.TraceID = getTraceIDFromContext(ctx)
+
+		c.Request = c.Request.WithContext(xevent.WithMetadata(ctx, md))
+		c.Header(RequestIDHeader, requestID)
+		c.Header(CorrelationIDHeader, correlationID)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package xhttp
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xfrr/finantrack/internal/shared/xmetrics"
+)
+
+// MetricsPath is the path the metrics are exposed on.
+const MetricsPath = "/metrics"
+
+// unmatchedRoute labels the requests that matched no route, so unknown paths
+// do not create new series.
+const unmatchedRoute = "unmatched"
+
+// GinMetrics records the rate, errors and duration of the requests per route.
+// The probes and the scrapes of the metrics are left out.
+func GinMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if isProbe(route) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		if route == "" {
+			route = unmatchedRoute
+		}
+		xmetrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
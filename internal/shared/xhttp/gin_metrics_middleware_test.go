@@ -0,0 +1,50 @@
+package xhttp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/finantrack/internal/shared/xmetrics"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+func TestGinMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(GinMetrics())
+	router.GET(MetricsPath, gin.WrapH(xmetrics.Handler()))
+	router.GET("/metrics-test/assets/:id", func(c *gin.Context) {
+		if c.Param("id") == "broken" {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/metrics-test/assets/1", "/metrics-test/assets/2", "/metrics-test/assets/broken", "/metrics-test/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	metrics := string(body)
+	assert.Contains(t, metrics, `finantrack_http_requests_total{method="GET",route="/metrics-test/assets/:id",status="200"} 2`)
+	assert.Contains(t, metrics, `finantrack_http_requests_total{method="GET",route="/metrics-test/assets/:id",status="500"} 1`)
+	assert.Contains(t, metrics, `finantrack_http_request_errors_total{method="GET",route="/metrics-test/assets/:id"} 1`)
+	assert.Contains(t, metrics, `finantrack_http_request_duration_seconds_count{method="GET",route="/metrics-test/assets/:id"} 3`)
+	assert.Contains(t, metrics, `finantrack_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, metrics, `route="/metrics"`)
+}
@@ -0,0 +1,55 @@
+package xhttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+)
+
+// Paths of the probes of the orchestrators.
+const (
+	// HealthPath is the legacy liveness probe, kept for the existing deployments.
+	HealthPath = "/health"
+
+	// LivezPath reports whether the process is alive, without checking its dependencies,
+	// so an unreachable database does not get the service restarted.
+	LivezPath = "/livez"
+
+	// ReadyzPath reports whether the service can serve requests, checking its dependencies.
+	ReadyzPath = "/readyz"
+)
+
+// isProbe reports whether the path is the one of a probe or of the metrics scrapes,
+// which are neither traced nor measured.
+func isProbe(path string) bool {
+	switch path {
+	case HealthPath, LivezPath, ReadyzPath, MetricsPath:
+		return true
+	default:
+		return false
+	}
+}
+
+// GinLiveness responds that the process is up as long as it can handle requests.
+func GinLiveness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, xhealth.Report{Status: xhealth.StatusUp})
+	}
+}
+
+// GinReadiness runs the health checks and responds with the status of every component,
+// with a 503 status code when the service is down. A degraded service is still ready.
+func GinReadiness(health *xhealth.Health) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := health.Check(c.Request.Context())
+
+		status := http.StatusOK
+		if report.Status == xhealth.StatusDown {
+			status = http.StatusServiceUnavailable
+		}
+
+		c.JSON(status, report)
+	}
+}
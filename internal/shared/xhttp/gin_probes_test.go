@@ -0,0 +1,71 @@
+package xhttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+func TestGinProbes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var eventStoreErr, projectionsErr error
+	health := xhealth.New()
+	health.Register("event_store", xhealth.CheckerFunc(func(context.Context) error {
+		return eventStoreErr
+	}))
+	health.RegisterOptional("projections", xhealth.CheckerFunc(func(context.Context) error {
+		return projectionsErr
+	}))
+
+	router := gin.New()
+	router.GET(LivezPath, GinLiveness())
+	router.GET(ReadyzPath, GinReadiness(health))
+
+	probe := func(path string) (int, xhealth.Report) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var report xhealth.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		return rec.Code, report
+	}
+
+	code, report := probe(ReadyzPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, xhealth.StatusUp, report.Status)
+	assert.Equal(t, xhealth.StatusUp, report.Components["event_store"].Status)
+
+	// the service is still ready without its optional components
+	projectionsErr = errors.New("2 pending migrations")
+
+	code, report = probe(ReadyzPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, xhealth.StatusDegraded, report.Status)
+	assert.Equal(t, xhealth.StatusDown, report.Components["projections"].Status)
+	assert.True(t, report.Components["projections"].Optional)
+
+	eventStoreErr = errors.New("connection refused")
+
+	code, report = probe(ReadyzPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, xhealth.StatusDown, report.Status)
+	assert.Equal(t, "connection refused", report.Components["event_store"].Error)
+
+	// the liveness does not depend on the components
+	code, report = probe(LivezPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, xhealth.StatusUp, report.Status)
+	assert.Empty(t, report.Components)
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+	"github.com/xfrr/finantrack/internal/shared/xmetrics"
 )
 
 const (
@@ -27,17 +30,27 @@ type Option func(*Server)
 
 func WithHealthCheck() Option {
 	return func(s *Server) {
-		s.router.GET("/health", func(c *gin.Context) {
+		s.router.GET(HealthPath, func(c *gin.Context) {
 			c.JSON(200, gin.H{"status": "ok"})
 		})
 	}
 }
 
+// WithProbes exposes the liveness probe on /livez and the readiness probe on /readyz,
+// which runs the checks of the given health. Both are outside of the API base path
+// and respond with the JSON status of the service.
+func WithProbes(health *xhealth.Health) Option {
+	return func(s *Server) {
+		s.router.GET(LivezPath, GinLiveness())
+		s.router.GET(ReadyzPath, GinReadiness(health))
+	}
+}
+
 func WithOpenTracing(serviceName string) Option {
 	return func(s *Server) {
 		s.Use(otelgin.Middleware(serviceName,
 			otelgin.WithFilter(func(r *http.Request) bool {
-				return r.URL.Path != "/health"
+				return !isProbe(r.URL.Path)
 			}),
 			otelgin.WithPropagators(
 				propagation.NewCompositeTextMapPropagator(
@@ -48,12 +61,68 @@ func WithOpenTracing(serviceName string) Option {
 	}
 }
 
+// WithMetrics exposes the Prometheus metrics of the process on /metrics and records
+// the rate, errors and duration of the requests per route.
+// It must be registered before WithErrorTranslation so the final status is recorded.
+func WithMetrics() Option {
+	return func(s *Server) {
+		s.router.GET(MetricsPath, gin.WrapH(xmetrics.Handler()))
+		s.Use(GinMetrics())
+	}
+}
+
+// WithRequestMetadata attaches the request, correlation and trace IDs
+// of every request to its context as event metadata.
+// It must be registered after WithOpenTracing to capture the trace ID.
+func WithRequestMetadata(source string) Option {
+	return func(s *Server) {
+		s.Use(GinRequestMetadata(source))
+	}
+}
+
+// WithZeroLogger logs every request and attaches a logger to its context.
+// It must be registered after WithRequestMetadata to capture the request ID.
 func WithZeroLogger(logger *zerolog.Logger) Option {
 	return func(s *Server) {
 		s.Use(GinRequestZeroLogger(logger))
 	}
 }
 
+// WithErrorTranslation writes the errors recorded by the handlers as
+// problem details responses, using the given mappings to choose their status and code.
+// It must be registered after WithZeroLogger so the final status is logged.
+func WithErrorTranslation(mappings ...ErrorMapping) Option {
+	return func(s *Server) {
+		s.Use(GinErrorTranslator(NewErrorTranslator(mappings...)))
+	}
+}
+
+// WithAuthentication requires valid credentials on the routes registered after it.
+// It must be registered after WithErrorTranslation so the rejections are
+// written as problem details, and before WithHandlers.
+func WithAuthentication(opts ...AuthOption) Option {
+	return func(s *Server) {
+		s.Use(GinAuthentication(opts...))
+	}
+}
+
+// WithMaxBodyBytes rejects the requests with a body over maxBytes with a 413 status.
+// It must be registered after WithErrorTranslation, and before WithIdempotency,
+// which reads the whole body of the requests.
+func WithMaxBodyBytes(maxBytes int64) Option {
+	return func(s *Server) {
+		s.Use(GinBodyLimit(maxBytes))
+	}
+}
+
+// WithIdempotency makes the mutating requests sent with an Idempotency-Key header safe to retry.
+// It must be registered after WithAuthentication, so the keys are scoped to the principal.
+func WithIdempotency(store IdempotencyStore) Option {
+	return func(s *Server) {
+		s.Use(GinIdempotency(store))
+	}
+}
+
 func WithHandlers(handlers ...Handler) Option {
 	return func(s *Server) {
 		for _, h := range handlers {
@@ -66,6 +135,20 @@ func WithHandlers(handlers ...Handler) Option {
 	}
 }
 
+// WithScopedHandlers registers handlers whose requests go through
+// the given middlewares first, such as the resolution of the request tenant.
+func WithScopedHandlers(middlewares []gin.HandlerFunc, handlers ...Handler) Option {
+	return func(s *Server) {
+		for _, h := range handlers {
+			s.Handle(
+				h.Method(),
+				path.Join(s.basePath, h.Path()),
+				append(middlewares[:len(middlewares):len(middlewares)], h.Handle)...,
+			)
+		}
+	}
+}
+
 type Server struct {
 	basePath string
 
@@ -74,8 +157,8 @@ type Server struct {
 }
 
 // Handle adds a new route to the GinServer.
-func (s *Server) Handle(method, path string, handler gin.HandlerFunc) {
-	s.router.Handle(method, path, handler)
+func (s *Server) Handle(method, path string, handlers ...gin.HandlerFunc) {
+	s.router.Handle(method, path, handlers...)
 }
 
 // AddMiddleware adds a new middleware to the GinServer.
@@ -8,12 +8,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xlog"
 )
 
+// GinRequestZeroLogger attaches a logger with the trace and request IDs of the request
+// to its context, so the handlers and the commands log through it, and logs the outcome
+// of every request. It must be registered after WithRequestMetadata to capture the request ID.
 func GinRequestZeroLogger(logger *zerolog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// extract traceparent from context using otel package
-		traceID := getTraceIDFromContext(c.Request.Context())
+		ctx := xlog.WithRequestLogger(c.Request.Context(), *logger)
+		c.Request = c.Request.WithContext(ctx)
+		requestLogger := xlog.FromContext(ctx)
 
 		// Start timer
 		start := time.Now()
@@ -32,13 +38,13 @@ func GinRequestZeroLogger(logger *zerolog.Logger) gin.HandlerFunc {
 
 		switch {
 		case status >= http.StatusInternalServerError:
-			zevent = logger.Error().Stack().Err(c.Errors.Last())
+			zevent = requestLogger.Error().Stack().Err(c.Errors.Last())
 			msg = "http request failed"
 		case status >= http.StatusBadRequest:
-			zevent = logger.Warn().Err(c.Errors.Last())
+			zevent = requestLogger.Warn().Err(c.Errors.Last())
 			msg = "http request failed"
 		default:
-			zevent = logger.Debug()
+			zevent = requestLogger.Debug()
 			msg = "http request received"
 		}
 
@@ -46,7 +52,6 @@ func GinRequestZeroLogger(logger *zerolog.Logger) gin.HandlerFunc {
 			Str("method", c.Request.Method).
 			Str("path", c.Request.URL.Path).
 			Int("status", c.Writer.Status()).
-			Str("trace_id", traceID).
 			Dur("latency_ms", stop).
 			Msg(msg)
 	}
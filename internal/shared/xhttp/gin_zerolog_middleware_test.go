@@ -0,0 +1,53 @@
+package xhttp_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+func TestGinRequestZeroLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	logger := xlog.NewZerologger("test", "test", xlog.Config{Format: xlog.FormatJSON, Level: "debug", Output: &out})
+
+	router := gin.New()
+	router.Use(GinRequestMetadata("test"), GinRequestZeroLogger(&logger))
+	router.GET("/assets", func(c *gin.Context) {
+		xlog.FromContext(c.Request.Context()).Info().Msg("handled")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/assets", nil)
+	req.Header.Set(RequestIDHeader, "request-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var handled, request map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &handled))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &request))
+
+	// the lines of the handlers carry the fields of the request
+	assert.Equal(t, "handled", handled["message"])
+	assert.Equal(t, "request-1", handled["request_id"])
+	assert.Equal(t, "test", handled["service"])
+
+	assert.Equal(t, "http request received", request["message"])
+	assert.Equal(t, "request-1", request["request_id"])
+	assert.Equal(t, "/assets", request["path"])
+	assert.EqualValues(t, http.StatusOK, request["status"])
+}
@@ -0,0 +1,154 @@
+package xhttp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is the time the responses are kept to be replayed.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+var (
+	// ErrIdempotencyKeyReused represents the error when an idempotency key
+	// is reused with a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+
+	// ErrIdempotencyKeyInProgress represents the error when a request
+	// with the same idempotency key is still being processed.
+	ErrIdempotencyKeyInProgress = errors.New("a request with the same idempotency key is in progress")
+)
+
+// IdempotencyRecord represents a request processed with an idempotency key
+// along with the response to replay on retries.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	Completed   bool
+	Status      int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+// IdempotencyStore defines the interface for keeping the idempotency records.
+type IdempotencyStore interface {
+	// Reserve records the key as in progress unless it already exists.
+	// It returns the existing record and false when the key was already reserved.
+	Reserve(ctx context.Context, record IdempotencyRecord) (IdempotencyRecord, bool, error)
+
+	// Complete stores the response of the reserved key.
+	Complete(ctx context.Context, record IdempotencyRecord) error
+
+	// Release removes the key so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+var _ IdempotencyStore = (*InMemoryIdempotencyStore)(nil)
+
+// DefaultIdempotencyMaxRecords is the number of records kept by the in-memory store by default.
+const DefaultIdempotencyMaxRecords = 10000
+
+// idempotencySweepInterval is the minimum time between two sweeps of the expired records.
+const idempotencySweepInterval = time.Minute
+
+// InMemoryIdempotencyStore is the in-memory implementation of IdempotencyStore.
+// The expired records are swept on reservation, at most once per minute, and
+// the oldest records are evicted when the store is full, so its size is bounded.
+type InMemoryIdempotencyStore struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxRecords int
+	lastSweep  time.Time
+	records    map[string]IdempotencyRecord
+}
+
+// InMemoryIdempotencyOption configures the in-memory idempotency store.
+type InMemoryIdempotencyOption func(*InMemoryIdempotencyStore)
+
+// WithMaxIdempotencyRecords sets the maximum number of records kept, DefaultIdempotencyMaxRecords by default.
+func WithMaxIdempotencyRecords(n int) InMemoryIdempotencyOption {
+	return func(s *InMemoryIdempotencyStore) {
+		if n > 0 {
+			s.maxRecords = n
+		}
+	}
+}
+
+// NewInMemoryIdempotencyStore creates a new instance of InMemoryIdempotencyStore
+// that keeps the records for the given time.
+func NewInMemoryIdempotencyStore(ttl time.Duration, opts ...InMemoryIdempotencyOption) *InMemoryIdempotencyStore {
+	s := &InMemoryIdempotencyStore{
+		ttl:        ttl,
+		maxRecords: DefaultIdempotencyMaxRecords,
+		records:    make(map[string]IdempotencyRecord),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Reserve records the key as in progress unless it already exists.
+func (s *InMemoryIdempotencyStore) Reserve(_ context.Context, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	existing, ok := s.records[record.Key]
+	if ok && now.Sub(existing.CreatedAt) < s.ttl {
+		return existing, false, nil
+	}
+
+	s.evict(now)
+	s.records[record.Key] = record
+	return record, true, nil
+}
+
+// Complete stores the response of the reserved key.
+// A record evicted while its request was in progress is not stored again.
+func (s *InMemoryIdempotencyStore) Complete(_ context.Context, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[record.Key]; ok {
+		s.records[record.Key] = record
+	}
+	return nil
+}
+
+// Release removes the key so the request can be retried.
+func (s *InMemoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// evict removes the expired records, and the oldest ones while the store is full,
+// leaving room for a new record.
+func (s *InMemoryIdempotencyStore) evict(now time.Time) {
+	if now.Sub(s.lastSweep) >= idempotencySweepInterval || len(s.records) >= s.maxRecords {
+		for key, r := range s.records {
+			if now.Sub(r.CreatedAt) >= s.ttl {
+				delete(s.records, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	for len(s.records) >= s.maxRecords {
+		var (
+			oldest   string
+			oldestAt time.Time
+		)
+		for key, r := range s.records {
+			if oldestAt.IsZero() || r.CreatedAt.Before(oldestAt) {
+				oldest, oldestAt = key, r.CreatedAt
+			}
+		}
+		delete(s.records, oldest)
+	}
+}
@@ -0,0 +1,57 @@
+package xhttp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+func TestInMemoryIdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+
+	reserve := func(t *testing.T, store *InMemoryIdempotencyStore, key string, createdAt time.Time) bool {
+		t.Helper()
+		_, reserved, err := store.Reserve(ctx, IdempotencyRecord{Key: key, CreatedAt: createdAt})
+		require.NoError(t, err)
+		return reserved
+	}
+
+	t.Run("keeps the records until they expire", func(t *testing.T) {
+		store := NewInMemoryIdempotencyStore(time.Minute)
+
+		assert.True(t, reserve(t, store, "key-1", time.Now()))
+		assert.False(t, reserve(t, store, "key-1", time.Now()))
+
+		assert.True(t, reserve(t, store, "key-2", time.Now().Add(-time.Hour)))
+		assert.True(t, reserve(t, store, "key-2", time.Now()))
+	})
+
+	t.Run("evicts the oldest records when full", func(t *testing.T) {
+		store := NewInMemoryIdempotencyStore(time.Hour, WithMaxIdempotencyRecords(2))
+		now := time.Now()
+
+		assert.True(t, reserve(t, store, "key-1", now.Add(-3*time.Second)))
+		assert.True(t, reserve(t, store, "key-2", now.Add(-2*time.Second)))
+		assert.True(t, reserve(t, store, "key-3", now.Add(-time.Second)))
+
+		// key-1 was evicted to make room for key-3
+		assert.False(t, reserve(t, store, "key-3", now))
+		assert.True(t, reserve(t, store, "key-1", now))
+		assert.False(t, reserve(t, store, "key-1", now))
+	})
+
+	t.Run("does not complete an evicted record", func(t *testing.T) {
+		store := NewInMemoryIdempotencyStore(time.Hour, WithMaxIdempotencyRecords(1))
+
+		assert.True(t, reserve(t, store, "key-1", time.Now().Add(-time.Second)))
+		assert.True(t, reserve(t, store, "key-2", time.Now()))
+
+		require.NoError(t, store.Complete(ctx, IdempotencyRecord{Key: "key-1", Completed: true, CreatedAt: time.Now()}))
+		assert.True(t, reserve(t, store, "key-1", time.Now()))
+	})
+}
@@ -0,0 +1,119 @@
+package xhttp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+)
+
+// DefaultIdempotencyCollectionName is the default collection name for idempotency records.
+const DefaultIdempotencyCollectionName = "idempotency_keys"
+
+var _ IdempotencyStore = (*MongoIdempotencyStore)(nil)
+
+// MongoIdempotencyStore is the MongoDB implementation of IdempotencyStore.
+// Expired records are removed by a TTL index.
+type MongoIdempotencyStore struct {
+	client *xmongo.Client
+}
+
+// MongoIdempotencyStoreMigrations returns the migrations of the idempotency keys collection,
+// in the order they are applied. The TTL index expires the records after the given time.
+func MongoIdempotencyStoreMigrations(ttl time.Duration) []xmongo.Migration {
+	return []xmongo.Migration{
+		xmongo.NewIndexMigration("idempotency_keys/0001_create_created_at_ttl_index", DefaultIdempotencyCollectionName,
+			bson.D{{Key: "created_at", Value: 1}}, options.Index().SetExpireAfterSeconds(int32(ttl.Seconds()))),
+	}
+}
+
+// NewMongoIdempotencyStore creates a new instance of MongoIdempotencyStore.
+// The TTL index is created by MongoIdempotencyStoreMigrations.
+func NewMongoIdempotencyStore(client *xmongo.Client) *MongoIdempotencyStore {
+	return &MongoIdempotencyStore{
+		client: client,
+	}
+}
+
+// Reserve records the key as in progress unless it already exists.
+func (s *MongoIdempotencyStore) Reserve(ctx context.Context, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	_, err := s.collection().InsertOne(ctx, newIdempotencyDTO(record))
+	if err == nil {
+		return record, true, nil
+	}
+
+	if !mongo.IsDuplicateKeyError(err) {
+		return IdempotencyRecord{}, false, err
+	}
+
+	var dto idempotencyDTO
+	err = s.collection().FindOne(ctx, bson.D{{Key: "_id", Value: record.Key}}).Decode(&dto)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// the record expired or was released in between, so try again
+		return s.Reserve(ctx, record)
+	}
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+
+	return dto.toRecord(), false, nil
+}
+
+// Complete stores the response of the reserved key.
+func (s *MongoIdempotencyStore) Complete(ctx context.Context, record IdempotencyRecord) error {
+	_, err := s.collection().ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: record.Key}},
+		newIdempotencyDTO(record),
+	)
+	return err
+}
+
+// Release removes the key so the request can be retried.
+func (s *MongoIdempotencyStore) Release(ctx context.Context, key string) error {
+	_, err := s.collection().DeleteOne(ctx, bson.D{{Key: "_id", Value: key}})
+	return err
+}
+
+func (s *MongoIdempotencyStore) collection() *mongo.Collection {
+	return s.client.Collection(DefaultIdempotencyCollectionName)
+}
+
+// idempotencyDTO represents the structure of an idempotency record stored in MongoDB.
+type idempotencyDTO struct {
+	Key         string    `bson:"_id"`
+	Fingerprint string    `bson:"fingerprint"`
+	Completed   bool      `bson:"completed"`
+	Status      int       `bson:"status,omitempty"`
+	ContentType string    `bson:"content_type,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+func newIdempotencyDTO(record IdempotencyRecord) idempotencyDTO {
+	return idempotencyDTO{
+		Key:         record.Key,
+		Fingerprint: record.Fingerprint,
+		Completed:   record.Completed,
+		Status:      record.Status,
+		ContentType: record.ContentType,
+		Body:        record.Body,
+		CreatedAt:   record.CreatedAt,
+	}
+}
+
+func (dto idempotencyDTO) toRecord() IdempotencyRecord {
+	return IdempotencyRecord{
+		Key:         dto.Key,
+		Fingerprint: dto.Fingerprint,
+		Completed:   dto.Completed,
+		Status:      dto.Status,
+		ContentType: dto.ContentType,
+		Body:        dto.Body,
+		CreatedAt:   dto.CreatedAt,
+	}
+}
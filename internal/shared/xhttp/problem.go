@@ -0,0 +1,126 @@
+package xhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+)
+
+// ProblemContentType is the media type of the problem details responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+const (
+	// CodeInvalidRequest is the problem code of malformed requests.
+	CodeInvalidRequest = "invalid_request"
+
+	// CodeUnauthenticated is the problem code of requests without valid credentials.
+	CodeUnauthenticated = "unauthenticated"
+
+	// CodeInvalidCredentials is the problem code of requests with credentials that cannot be verified.
+	CodeInvalidCredentials = "invalid_credentials"
+
+	// CodeRequestTooLarge is the problem code of requests with a body over the size limit.
+	CodeRequestTooLarge = "request_too_large"
+
+	// CodeIdempotencyKeyReused is the problem code of requests that reuse an idempotency key.
+	CodeIdempotencyKeyReused = "idempotency_key_reused"
+
+	// CodeIdempotencyKeyInProgress is the problem code of retries of requests still in progress.
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+
+	// CodeInternalError is the problem code of unexpected errors.
+	CodeInternalError = "internal_error"
+)
+
+var (
+	// ErrInvalidRequest represents the error when the request cannot be parsed.
+	ErrInvalidRequest = errors.New("invalid request")
+
+	// ErrRequestTooLarge represents the error when the request body is over the size limit.
+	ErrRequestTooLarge = errors.New("request too large")
+)
+
+// Problem represents a problem details response as defined by RFC 7807.
+// Code is an extension member with a stable identifier clients can switch on.
+type Problem struct {
+	Type     string `json:"type" example:"about:blank"`
+	Title    string `json:"title" example:"Not Found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"asset not found"`
+	Instance string `json:"instance,omitempty" example:"/api/v1/assets/00000000-0000-0000-0000-000000000000"`
+	Code     string `json:"code" example:"asset_not_found"`
+}
+
+// ErrorMapping maps an error to the status and code of the problem returned to the client.
+type ErrorMapping struct {
+	Err    error
+	Status int
+	Code   string
+}
+
+// MapError creates a new ErrorMapping for the given error.
+func MapError(err error, status int, code string) ErrorMapping {
+	return ErrorMapping{
+		Err:    err,
+		Status: status,
+		Code:   code,
+	}
+}
+
+// ErrorTranslator translates errors into problem details.
+// Errors are matched with errors.Is in the order the mappings were given,
+// and unmatched errors are translated into an internal error
+// without exposing their message.
+type ErrorTranslator struct {
+	mappings []ErrorMapping
+}
+
+// NewErrorTranslator creates a new ErrorTranslator with the given mappings.
+// Malformed and too large requests, authentication failures and
+// idempotency key conflicts are always mapped to their status.
+func NewErrorTranslator(mappings ...ErrorMapping) *ErrorTranslator {
+	return &ErrorTranslator{
+		mappings: append(mappings,
+			MapError(ErrRequestTooLarge, http.StatusRequestEntityTooLarge, CodeRequestTooLarge),
+			MapError(ErrInvalidRequest, http.StatusBadRequest, CodeInvalidRequest),
+			MapError(xauth.ErrUnauthenticated, http.StatusUnauthorized, CodeUnauthenticated),
+			MapError(xauth.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials),
+			MapError(ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, CodeIdempotencyKeyReused),
+			MapError(ErrIdempotencyKeyInProgress, http.StatusConflict, CodeIdempotencyKeyInProgress),
+		),
+	}
+}
+
+// Translate returns the problem details of the given error.
+func (t *ErrorTranslator) Translate(err error) Problem {
+	// the body limit is hit by whoever reads the body, which may wrap it in any error
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = fmt.Errorf("%w: %w", ErrRequestTooLarge, maxBytesErr)
+	}
+
+	for _, m := range t.mappings {
+		if errors.Is(err, m.Err) {
+			return newProblem(m.Status, m.Code, err.Error())
+		}
+	}
+
+	return newProblem(http.StatusInternalServerError, CodeInternalError, "")
+}
+
+// InvalidRequest wraps the given error as an ErrInvalidRequest.
+func InvalidRequest(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+}
+
+func newProblem(status int, code, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
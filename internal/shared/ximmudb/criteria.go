@@ -0,0 +1,221 @@
+package ximmudb
+
+import (
+	"strings"
+	"time"
+)
+
+// Criteria represents a criteria to be used in a query to get events from the storage.
+type Criteria interface {
+	// ToSQL returns the criteria as a SQL condition and its positional arguments.
+	ToSQL() (string, []any)
+}
+
+// CriteriaBuilder is a function that builds a Criteria.
+type CriteriaBuilder func() Criteria
+
+// fieldCriteria is a Criteria to get events by the value of a column.
+type fieldCriteria struct {
+	column string
+	value  any
+}
+
+// ToSQL returns the criteria as a SQL condition.
+func (c *fieldCriteria) ToSQL() (string, []any) {
+	return c.column + " = ?", []any{c.value}
+}
+
+// WithEventIDCriteria returns a CriteriaBuilder that builds a Criteria to get events by ID.
+func WithEventIDCriteria(id string) CriteriaBuilder {
+	return func() Criteria {
+		return &fieldCriteria{column: "id", value: id}
+	}
+}
+
+// WithAggregateIDCriteria returns a CriteriaBuilder that builds a Criteria to get events by aggregate ID.
+func WithAggregateIDCriteria(aggregateID string) CriteriaBuilder {
+	return func() Criteria {
+		return &fieldCriteria{column: "aggregate_id", value: aggregateID}
+	}
+}
+
+// WithTenantIDCriteria returns a CriteriaBuilder that builds a Criteria to get events by tenant ID.
+func WithTenantIDCriteria(tenantID string) CriteriaBuilder {
+	return func() Criteria {
+		return &fieldCriteria{column: "tenant_id", value: tenantID}
+	}
+}
+
+// WithAggregateTypeCriteria returns a CriteriaBuilder that builds a Criteria to get events by aggregate type.
+func WithAggregateTypeCriteria(aggregateType string) CriteriaBuilder {
+	return func() Criteria {
+		return &fieldCriteria{column: "aggregate_name", value: aggregateType}
+	}
+}
+
+// WithEventTypeCriteria returns a CriteriaBuilder that builds a Criteria to get events by type.
+func WithEventTypeCriteria(eventType string) CriteriaBuilder {
+	return func() Criteria {
+		return &fieldCriteria{column: "type", value: eventType}
+	}
+}
+
+// WithParentIDCriteria returns a CriteriaBuilder that builds a Criteria to get events by the ID
+// of the parent aggregate of their payload. See xevent.Child.
+func WithParentIDCriteria(parentID string) CriteriaBuilder {
+	return func() Criteria {
+		return &fieldCriteria{column: "parent_id", value: parentID}
+	}
+}
+
+// rangeCriteria is a Criteria to get events by a range of values of a column.
+type rangeCriteria struct {
+	column string
+	from   any
+	to     any
+}
+
+// ToSQL returns the criteria as a SQL condition.
+func (c *rangeCriteria) ToSQL() (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	if c.from != nil {
+		conds = append(conds, c.column+" >= ?")
+		args = append(args, c.from)
+	}
+	if c.to != nil {
+		conds = append(conds, c.column+" <= ?")
+		args = append(args, c.to)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+// WithAggregateVersionRangeCriteria returns a CriteriaBuilder that builds a Criteria to get events
+// whose aggregate version is between from and to, both inclusive.
+// A zero value on any of the bounds leaves that side of the range open.
+func WithAggregateVersionRangeCriteria(from, to int) CriteriaBuilder {
+	return func() Criteria {
+		c := &rangeCriteria{column: "aggregate_version"}
+		if from > 0 {
+			c.from = from
+		}
+		if to > 0 {
+			c.to = to
+		}
+		return c
+	}
+}
+
+// WithTimestampRangeCriteria returns a CriteriaBuilder that builds a Criteria to get events
+// whose timestamp is between from and to, both inclusive.
+// A zero time on any of the bounds leaves that side of the range open.
+func WithTimestampRangeCriteria(from, to time.Time) CriteriaBuilder {
+	return func() Criteria {
+		c := &rangeCriteria{column: "created_at"}
+		if !from.IsZero() {
+			c.from = from.UTC()
+		}
+		if !to.IsZero() {
+			c.to = to.UTC()
+		}
+		return c
+	}
+}
+
+// WithEventDateRangeCriteria returns a CriteriaBuilder that builds a Criteria to get events
+// whose payload date is between from and to, both inclusive. See xevent.Dated.
+// A zero time on any of the bounds leaves that side of the range open,
+// but the events without date never match.
+func WithEventDateRangeCriteria(from, to time.Time) CriteriaBuilder {
+	return func() Criteria {
+		return &datedCriteria{rangeCriteria{column: "event_date", from: timeBound(from), to: timeBound(to)}}
+	}
+}
+
+// datedCriteria is a rangeCriteria that excludes the events without date.
+type datedCriteria struct {
+	rangeCriteria
+}
+
+// ToSQL returns the criteria as a SQL condition.
+func (c *datedCriteria) ToSQL() (string, []any) {
+	cond, args := c.rangeCriteria.ToSQL()
+	if cond == "" {
+		return c.column + " IS NOT NULL", nil
+	}
+	return cond, args
+}
+
+// timeBound returns the bound of a time range, nil when the time is zero.
+func timeBound(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
+
+// sqlCriteria is a Criteria given as a SQL condition without arguments.
+type sqlCriteria string
+
+// ToSQL returns the criteria as a SQL condition.
+func (c sqlCriteria) ToSQL() (string, []any) {
+	return string(c), nil
+}
+
+// All returns a CriteriaBuilder that builds a Criteria that matches every event.
+func All() CriteriaBuilder {
+	return func() Criteria {
+		return &logicalCriteria{}
+	}
+}
+
+// And is a CriteriaBuilder that builds a Criteria that is the result of the logical AND operation between Criteria.
+func And(crs ...Criteria) CriteriaBuilder {
+	return func() Criteria {
+		return &logicalCriteria{operator: " AND ", criterias: crs}
+	}
+}
+
+// Or is a CriteriaBuilder that builds a Criteria that is the result of the logical OR operation between Criteria.
+func Or(crs ...Criteria) CriteriaBuilder {
+	return func() Criteria {
+		return &logicalCriteria{operator: " OR ", criterias: crs}
+	}
+}
+
+// logicalCriteria is a Criteria that joins several Criteria with a logical operator.
+type logicalCriteria struct {
+	operator  string
+	criterias []Criteria
+}
+
+// ToSQL returns the criteria as a SQL condition.
+func (c *logicalCriteria) ToSQL() (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	for _, cr := range c.criterias {
+		cond, crArgs := cr.ToSQL()
+		if cond == "" {
+			// An empty condition matches every event, which makes
+			// any OR operation match every event too.
+			if c.operator == " OR " {
+				return "", nil
+			}
+			continue
+		}
+		conds = append(conds, "("+cond+")")
+		args = append(args, crArgs...)
+	}
+
+	return strings.Join(conds, c.operator), args
+}
@@ -0,0 +1,96 @@
+package ximmudb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+func TestCriteria_ToSQL(t *testing.T) {
+	var (
+		from = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		to   = time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	var specs = []struct {
+		name         string
+		criteria     Criteria
+		expectedCond string
+		expectedArgs []any
+	}{
+		{
+			name:         "all events",
+			criteria:     All()(),
+			expectedCond: "",
+		},
+		{
+			name:         "events by aggregate id",
+			criteria:     WithAggregateIDCriteria("aggregate-id")(),
+			expectedCond: "aggregate_id = ?",
+			expectedArgs: []any{"aggregate-id"},
+		},
+		{
+			name:         "events by aggregate version range",
+			criteria:     WithAggregateVersionRangeCriteria(2, 5)(),
+			expectedCond: "aggregate_version >= ? AND aggregate_version <= ?",
+			expectedArgs: []any{2, 5},
+		},
+		{
+			name:         "events until a timestamp",
+			criteria:     WithTimestampRangeCriteria(time.Time{}, to)(),
+			expectedCond: "created_at <= ?",
+			expectedArgs: []any{to},
+		},
+		{
+			name: "events by aggregate id and timestamp range",
+			criteria: And(
+				WithAggregateIDCriteria("aggregate-id")(),
+				WithTimestampRangeCriteria(from, to)(),
+			)(),
+			expectedCond: "(aggregate_id = ?) AND (created_at >= ? AND created_at <= ?)",
+			expectedArgs: []any{"aggregate-id", from, to},
+		},
+		{
+			name: "events by aggregate id and unbounded range",
+			criteria: And(
+				WithAggregateIDCriteria("aggregate-id")(),
+				WithAggregateVersionRangeCriteria(0, 0)(),
+			)(),
+			expectedCond: "(aggregate_id = ?)",
+			expectedArgs: []any{"aggregate-id"},
+		},
+		{
+			name: "events by parent id and event date range",
+			criteria: And(
+				WithParentIDCriteria("parent-id")(),
+				WithEventDateRangeCriteria(from, to)(),
+			)(),
+			expectedCond: "(parent_id = ?) AND (event_date >= ? AND event_date <= ?)",
+			expectedArgs: []any{"parent-id", from, to},
+		},
+		{
+			name:         "events with any event date",
+			criteria:     WithEventDateRangeCriteria(time.Time{}, time.Time{})(),
+			expectedCond: "event_date IS NOT NULL",
+		},
+		{
+			name: "events by event type or unbounded range",
+			criteria: Or(
+				WithEventTypeCriteria("event-type")(),
+				WithAggregateVersionRangeCriteria(0, 0)(),
+			)(),
+			expectedCond: "",
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			cond, args := spec.criteria.ToSQL()
+			assert.Equal(t, spec.expectedCond, cond)
+			assert.Equal(t, spec.expectedArgs, args)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package ximmudb
+
+import (
+	"strings"
+
+	immusql "github.com/codenotary/immudb/embedded/sql"
+)
+
+// IsColumnAlreadyExists checks if the error was caused by adding a column
+// that already exists. Errors coming from the server lose their type,
+// so the message is compared instead.
+func IsColumnAlreadyExists(err error) bool {
+	return err != nil && strings.Contains(err.Error(), immusql.ErrColumnAlreadyExists.Error())
+}
+
+// IsTableNotFound checks if the error was caused by querying a table that does not exist.
+func IsTableNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), immusql.ErrTableDoesNotExist.Error())
+}
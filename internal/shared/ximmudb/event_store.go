@@ -0,0 +1,450 @@
+package ximmudb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"github.com/xfrr/go-cqrsify/event"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xmetrics"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+)
+
+const (
+	// DefaultTableName is the default table name for events.
+	DefaultTableName = "events"
+
+	// DefaultTimeout is the default timeout for write operations.
+	DefaultTimeout = 5 * time.Second
+)
+
+// Event represents an event that will be saved in the storage.
+type Event = aggregate.Change
+
+// EventStore defines the interface for saving and retrieving events.
+type EventStore interface {
+	Save(ctx context.Context, events ...Event) error
+	Get(ctx context.Context, criteria Criteria, opts ...QueryOption) ([]Event, error)
+	Stream(ctx context.Context, criteria Criteria, opts ...QueryOption) iter.Seq2[Event, error]
+	ExistsByAggregateID(ctx context.Context, aggregateID uuid.UUID) (bool, error)
+	AssignTenant(ctx context.Context, aggregateID uuid.UUID, tenantID string) error
+}
+
+var _ EventStore = (*ImmuEventStore)(nil)
+
+// ImmuEventStore is the immudb implementation of EventStore.
+type ImmuEventStore struct {
+	db                     *sql.DB
+	payloadFactoryRegistry xevent.Registry
+}
+
+// NewImmuEventStore creates a new instance of ImmuEventStore.
+// The events table must be created beforehand through migrations.
+// The registry is used to resolve the payload type for each event type.
+func NewImmuEventStore(db *sql.DB, registry xevent.Registry) *ImmuEventStore {
+	return &ImmuEventStore{
+		db:                     db,
+		payloadFactoryRegistry: registry,
+	}
+}
+
+// Save saves the events in the storage within a single transaction.
+func (s *ImmuEventStore) Save(ctx context.Context, events ...Event) (err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendImmuDB, "save", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "ImmuEventStore.Save", trace.WithAttributes(xtracing.ChangesAttributes(events)...))
+	defer xtracing.End(span, &err)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	var metadata []byte
+	if md, ok := xevent.MetadataFromContext(ctx); ok && !md.IsZero() {
+		var err error
+		metadata, err = json.Marshal(md)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+	}
+
+	tenantID, _ := xtenant.FromContext(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf(`
+		INSERT INTO %s (id, type, aggregate_id, aggregate_name, aggregate_version, tenant_id, created_at, payload, metadata, event_date, parent_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		DefaultTableName,
+	)
+
+	for _, e := range events {
+		var dto *eventDTO
+		dto, err = s.eventToDTO(e)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to convert event to DTO: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, stmt,
+			dto.ID,
+			dto.Type,
+			dto.AggregateID,
+			dto.AggregateName,
+			dto.AggregateVersion,
+			tenantID,
+			dto.Timestamp,
+			string(dto.Payload),
+			string(metadata),
+			dto.EventDate,
+			dto.ParentID,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to insert event: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Get retrieves events from the storage that match the given criteria.
+// Use Stream to read large amounts of events without loading them in memory.
+func (s *ImmuEventStore) Get(ctx context.Context, criteria Criteria, opts ...QueryOption) (events []Event, err error) {
+	ctx, span := tracer.Start(ctx, "ImmuEventStore.Get")
+	defer func() {
+		span.SetAttributes(xtracing.EventCountKey.Int(len(events)))
+		xtracing.End(span, &err)
+	}()
+
+	for e, serr := range xmetrics.ObserveEventStream(xmetrics.BackendImmuDB, "get", s.stream(ctx, criteria, opts...)) {
+		if serr != nil {
+			return nil, serr
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// Stream returns an iterator over the events from the storage that match the given criteria.
+// Rows are scanned and decoded one by one as the iteration advances.
+// The iteration stops after yielding the first error.
+func (s *ImmuEventStore) Stream(ctx context.Context, criteria Criteria, opts ...QueryOption) iter.Seq2[Event, error] {
+	return xmetrics.ObserveEventStream(xmetrics.BackendImmuDB, "stream", xtracing.EventStream(ctx, tracer, "ImmuEventStore.Stream",
+		func(ctx context.Context) iter.Seq2[Event, error] {
+			return s.stream(ctx, criteria, opts...)
+		},
+	))
+}
+
+// stream returns the iterator of Stream without recording its metrics and span.
+func (s *ImmuEventStore) stream(ctx context.Context, criteria Criteria, opts ...QueryOption) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		stmt := fmt.Sprintf(`
+			SELECT id, type, aggregate_id, aggregate_name, aggregate_version, created_at, payload, metadata
+			FROM %s`,
+			DefaultTableName,
+		)
+
+		where, args := criteria.ToSQL()
+		if where != "" {
+			stmt += " WHERE " + where
+		}
+		stmt += newQueryOptions(opts...).toSQL()
+
+		rows, err := s.db.QueryContext(ctx, stmt, args...)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to query events: %w", err))
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var dto eventDTO
+			if err = dto.scan(rows); err != nil {
+				yield(nil, fmt.Errorf("failed to scan event: %w", err))
+				return
+			}
+
+			var e Event
+			e, err = s.createEventFromDTO(dto)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to convert DTO to event: %w", err))
+				return
+			}
+
+			if !yield(e, nil) {
+				return
+			}
+		}
+
+		if err = rows.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to iterate events: %w", err))
+		}
+	}
+}
+
+// ExistsByAggregateID checks if an event exists for the given aggregate ID.
+func (s *ImmuEventStore) ExistsByAggregateID(ctx context.Context, aggregateID uuid.UUID) (_ bool, err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendImmuDB, "exists", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "ImmuEventStore.ExistsByAggregateID", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(aggregateID.String()),
+	))
+	defer xtracing.End(span, &err)
+
+	stmt := fmt.Sprintf(`SELECT id FROM %s WHERE aggregate_id = ? LIMIT 1`, DefaultTableName)
+
+	rows, err := s.db.QueryContext(ctx, stmt, aggregateID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer rows.Close()
+
+	return rows.Next(), rows.Err()
+}
+
+// AssignTenant sets the tenant of the events of the aggregate saved without one,
+// before the tenants were introduced. The events of other tenants are left untouched.
+func (s *ImmuEventStore) AssignTenant(ctx context.Context, aggregateID uuid.UUID, tenantID string) (err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendImmuDB, "assign_tenant", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "ImmuEventStore.AssignTenant", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(aggregateID.String()),
+	))
+	defer xtracing.End(span, &err)
+
+	stmt := fmt.Sprintf(`UPDATE %s SET tenant_id = ? WHERE aggregate_id = ? AND (tenant_id IS NULL OR tenant_id = '')`, DefaultTableName)
+
+	if _, err = s.db.ExecContext(ctx, stmt, tenantID, aggregateID.String()); err != nil {
+		return fmt.Errorf("failed to assign the tenant of the events: %w", err)
+	}
+	return nil
+}
+
+// Reindex replays all the events into the index of their date and their parent, see xevent.Dated and
+// xevent.Child, e.g. after the date or the parent of a type of event changed. It returns the number
+// of events whose index was written.
+func (s *ImmuEventStore) Reindex(ctx context.Context) (int, error) {
+	// the events indexed are indexed again, which resets the index of those having no longer
+	// a date nor a parent, and then the events not indexed having one now
+	reindexed, err := s.index(ctx, indexedCriteria, true)
+	if err != nil {
+		return reindexed, err
+	}
+
+	indexed, err := s.index(ctx, notIndexedCriteria, false)
+	return reindexed + indexed, err
+}
+
+var (
+	indexedCriteria    = sqlCriteria("event_date IS NOT NULL OR parent_id IS NOT NULL")
+	notIndexedCriteria = sqlCriteria("event_date IS NULL AND parent_id IS NULL")
+)
+
+// index writes the date and the parent of the payloads of the events matching the criteria.
+// The events having neither are skipped, unless reset is set. The events are read before
+// they are updated, as immudb cannot update the rows of a table while they are read.
+func (s *ImmuEventStore) index(ctx context.Context, criteria Criteria, reset bool) (int, error) {
+	var indexes []*eventDTO
+	for e, err := range s.stream(ctx, criteria) {
+		if err != nil {
+			return 0, err
+		}
+
+		dto, err := s.eventToDTO(e)
+		if err != nil {
+			return 0, err
+		}
+		if reset || dto.EventDate.Valid || dto.ParentID.Valid {
+			indexes = append(indexes, dto)
+		}
+	}
+
+	stmt := fmt.Sprintf(`UPDATE %s SET event_date = ?, parent_id = ? WHERE id = ?`, DefaultTableName)
+	for i, dto := range indexes {
+		if _, err := s.db.ExecContext(ctx, stmt, dto.EventDate, dto.ParentID, dto.ID); err != nil {
+			return i, fmt.Errorf("failed to index event %s: %w", dto.ID, err)
+		}
+	}
+	return len(indexes), nil
+}
+
+// Registry returns the payload factory registry.
+func (s *ImmuEventStore) Registry() xevent.Registry {
+	return s.payloadFactoryRegistry
+}
+
+// eventToDTO converts an Event into an eventDTO for storage.
+func (s *ImmuEventStore) eventToDTO(e Event) (*eventDTO, error) {
+	eventID, ok := e.ID().(uuid.UUID)
+	if !ok {
+		return nil, fmt.Errorf("event must have UUID IDs, got %v", e.ID())
+	}
+
+	if e.Aggregate() == nil {
+		return nil, errors.New("event must have an aggregate reference")
+	}
+
+	aggregateID, ok := e.Aggregate().ID.(uuid.UUID)
+	if !ok {
+		return nil, fmt.Errorf("aggregate ID must be a UUID, got %v", e.Aggregate().ID)
+	}
+
+	if eventID == uuid.Nil {
+		eventID = uuid.New()
+	}
+
+	var payload []byte
+	if e.Payload() != nil {
+		var err error
+		payload, err = json.Marshal(e.Payload())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+
+	dto := &eventDTO{
+		ID:               eventID.String(),
+		Type:             e.Reason(),
+		AggregateID:      aggregateID.String(),
+		AggregateName:    e.Aggregate().Name,
+		AggregateVersion: e.Aggregate().Version,
+		Timestamp:        e.Time().UTC(),
+		Payload:          payload,
+	}
+	if date, ok := xevent.DateOf(e.Payload()); ok {
+		dto.EventDate = sql.NullTime{Time: date.UTC(), Valid: true}
+	}
+	if parentID, ok := xevent.ParentOf(e.Payload()); ok {
+		dto.ParentID = sql.NullString{String: parentID, Valid: true}
+	}
+	return dto, nil
+}
+
+// createEventFromDTO converts an eventDTO back to an Event.
+func (s *ImmuEventStore) createEventFromDTO(dto eventDTO) (Event, error) {
+	eventID, err := uuid.Parse(dto.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse event ID: %w", err)
+	}
+
+	aggregateID, err := uuid.Parse(dto.AggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse aggregate ID: %w", err)
+	}
+
+	// Get the payload factory function based on the event type.
+	payloadFactory, err := s.payloadFactoryRegistry.GetFactory(dto.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new instance of the payload type and unmarshal the data into it.
+	payload := payloadFactory()
+	if len(dto.Payload) > 0 {
+		if err = json.Unmarshal(dto.Payload, payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+	}
+
+	// Reconstruct the event.
+	e := event.New[any](
+		eventID,
+		dto.Type,
+		payload,
+		event.WithAggregate(
+			aggregateID,
+			dto.AggregateName,
+			dto.AggregateVersion,
+		),
+		event.WithTime(dto.Timestamp),
+	)
+
+	if len(dto.Metadata) == 0 {
+		return e, nil
+	}
+
+	var md xevent.Metadata
+	if err = json.Unmarshal(dto.Metadata, &md); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
+	return &storedEvent{Event: e, metadata: md}, nil
+}
+
+// eventDTO represents the structure of an event stored in immudb.
+type eventDTO struct {
+	ID               string         `db:"id"`
+	Type             string         `db:"type"`
+	AggregateID      string         `db:"aggregate_id"`
+	AggregateName    string         `db:"aggregate_name"`
+	AggregateVersion int            `db:"aggregate_version"`
+	Timestamp        time.Time      `db:"created_at"`
+	Payload          []byte         `db:"payload"`
+	Metadata         []byte         `db:"metadata"`
+	EventDate        sql.NullTime   `db:"event_date"`
+	ParentID         sql.NullString `db:"parent_id"`
+}
+
+// scan reads the current row into the DTO.
+// Columns added by later migrations may be NULL on older rows.
+func (dto *eventDTO) scan(rows *sql.Rows) error {
+	var (
+		eventType        sql.NullString
+		aggregateVersion sql.NullInt64
+		payload          sql.NullString
+		metadata         sql.NullString
+	)
+
+	err := rows.Scan(
+		&dto.ID,
+		&eventType,
+		&dto.AggregateID,
+		&dto.AggregateName,
+		&aggregateVersion,
+		&dto.Timestamp,
+		&payload,
+		&metadata,
+	)
+	if err != nil {
+		return err
+	}
+
+	dto.Type = eventType.String
+	dto.AggregateVersion = int(aggregateVersion.Int64)
+	dto.Payload = []byte(payload.String)
+	dto.Metadata = []byte(metadata.String)
+	return nil
+}
+
+// storedEvent is an Event read from the storage along with its metadata.
+type storedEvent struct {
+	Event
+	metadata xevent.Metadata
+}
+
+// Metadata returns the metadata stored with the event.
+func (e *storedEvent) Metadata() xevent.Metadata {
+	return e.metadata
+}
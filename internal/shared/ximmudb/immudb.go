@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	immudb "github.com/codenotary/immudb/pkg/client"
 	"github.com/codenotary/immudb/pkg/stdlib"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Config struct {
@@ -30,8 +32,19 @@ func NewImmuDBClient(ctx context.Context, cfg Config) (*sql.DB, error) {
 		WithPassword(cfg.Pass).
 		WithDatabase(cfg.DB)
 
-	// Create a new standard database/sql client and stablish pool connection
-	dbClient := stdlib.OpenDB(iopts)
+	// Create a new standard database/sql client, tracing every SQL call as a span,
+	// and stablish pool connection
+	dbClient, err := otelsql.Open("immudb", stdlib.RegisterConnConfig(iopts),
+		otelsql.WithAttributes(attribute.String("db.system", "immudb")),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			DisableErrSkip:       true,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
 	dbClient.SetConnMaxLifetime(time.Duration(cfg.MaxLife) * time.Minute)
 	dbClient.SetMaxOpenConns(cfg.MaxOpenConns)
 	dbClient.SetMaxIdleConns(cfg.MaxIdleCons)
@@ -1,18 +1,141 @@
 package ximmudb
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
 
-// Migration represents a immudb SQL migration.
-type Migration interface {
-	Up(db *sql.DB) error
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xmigrate"
+)
+
+// MigrationsTableName is the table of the records of the migrations applied.
+const MigrationsTableName = "schema_migrations"
+
+// Migration represents a versioned immudb SQL migration.
+type Migration = xmigrate.Migration[*sql.DB]
+
+// NewMigration returns the migration running the SQL statements in order.
+// The statements adding a column that already exists are skipped, as immudb cannot add
+// a column only when it is missing, so the migrations are idempotent.
+func NewMigration(id string, statements ...string) Migration {
+	return sqlMigration{id: id, statements: statements}
+}
+
+type sqlMigration struct {
+	id         string
+	statements []string
+}
+
+func (m sqlMigration) ID() string {
+	return m.id
+}
+
+// Definition returns the statements with their whitespace collapsed,
+// so reformatting them does not change the checksum.
+func (m sqlMigration) Definition() string {
+	statements := make([]string, 0, len(m.statements))
+	for _, stmt := range m.statements {
+		statements = append(statements, strings.Join(strings.Fields(stmt), " "))
+	}
+	return strings.Join(statements, "\n")
 }
 
-// Migrate applies the migrations in order.
-func Migrate(db *sql.DB, migrations []Migration) error {
-	for _, m := range migrations {
-		if err := m.Up(db); err != nil {
+func (m sqlMigration) Up(ctx context.Context, db *sql.DB) error {
+	for _, stmt := range m.statements {
+		_, err := db.ExecContext(ctx, stmt)
+		if err != nil && !IsColumnAlreadyExists(err) {
 			return err
 		}
 	}
 	return nil
 }
+
+// NewEventIndexMigration returns the migration indexing the date and the parent of the payloads of the
+// events saved before the event_date and parent_id columns existed, see xevent.Dated and xevent.Child.
+// The payloads are decoded with the registry, so the types of every event stored must be registered.
+// The events already indexed are skipped, so the migration is idempotent.
+func NewEventIndexMigration(id string, registry xevent.Registry) Migration {
+	return eventIndexMigration{id: id, registry: registry}
+}
+
+type eventIndexMigration struct {
+	id       string
+	registry xevent.Registry
+}
+
+func (m eventIndexMigration) ID() string {
+	return m.id
+}
+
+// Definition returns the table and the columns indexed.
+func (m eventIndexMigration) Definition() string {
+	return fmt.Sprintf("index %s event_date parent_id", DefaultTableName)
+}
+
+func (m eventIndexMigration) Up(ctx context.Context, db *sql.DB) error {
+	_, err := NewImmuEventStore(db, m.registry).index(ctx, notIndexedCriteria, false)
+	return err
+}
+
+// Migrate applies the pending migrations in order, recording them in the migrations table.
+// It refuses to run when the migrations applied drifted, see xmigrate.Run.
+func Migrate(ctx context.Context, db *sql.DB, migrations []Migration, opts ...xmigrate.Option) (xmigrate.Plan, error) {
+	return xmigrate.Run(ctx, db, NewMigrationStore(db), migrations, opts...)
+}
+
+var _ xmigrate.Store = (*MigrationStore)(nil)
+
+// MigrationStore records the migrations applied in the migrations table.
+type MigrationStore struct {
+	db *sql.DB
+}
+
+// NewMigrationStore creates a new instance of MigrationStore.
+func NewMigrationStore(db *sql.DB) *MigrationStore {
+	return &MigrationStore{db: db}
+}
+
+// Init creates the migrations table when it does not exist.
+func (s *MigrationStore) Init(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id VARCHAR[100],
+			checksum VARCHAR[64],
+			applied_at TIMESTAMP,
+			PRIMARY KEY id
+		);`, MigrationsTableName))
+	return err
+}
+
+// Applied returns the records of the migrations applied, none when the table does not exist.
+func (s *MigrationStore) Applied(ctx context.Context) ([]xmigrate.Record, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`SELECT id, checksum, applied_at FROM %s`, MigrationsTableName))
+	if IsTableNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []xmigrate.Record
+	for rows.Next() {
+		var r xmigrate.Record
+		if err = rows.Scan(&r.ID, &r.Checksum, &r.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// Save records the migration as applied.
+func (s *MigrationStore) Save(ctx context.Context, record xmigrate.Record) error {
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf(`UPSERT INTO %s (id, checksum, applied_at) VALUES (?, ?, ?);`, MigrationsTableName),
+		record.ID, record.Checksum, record.AppliedAt,
+	)
+	return err
+}
@@ -0,0 +1,91 @@
+package ximmudb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField represents an event column that can be used to sort the results.
+type SortField string
+
+const (
+	// SortByTimestamp sorts the events by their timestamp.
+	SortByTimestamp SortField = "created_at"
+
+	// SortByAggregateVersion sorts the events by their aggregate version.
+	SortByAggregateVersion SortField = "aggregate_version"
+
+	// SortByAggregateID sorts the events by their aggregate ID.
+	SortByAggregateID SortField = "aggregate_id"
+
+	// SortByType sorts the events by their type.
+	SortByType SortField = "type"
+)
+
+// SortOrder represents the direction in which the results are sorted.
+type SortOrder string
+
+const (
+	// Ascending sorts the results from the lowest to the highest value.
+	Ascending SortOrder = "ASC"
+
+	// Descending sorts the results from the highest to the lowest value.
+	Descending SortOrder = "DESC"
+)
+
+// QueryOptions holds the options used to query events from the storage.
+type QueryOptions struct {
+	sort   []string
+	limit  int64
+	offset int64
+}
+
+// QueryOption configures the QueryOptions.
+type QueryOption func(*QueryOptions)
+
+// WithSort sorts the results by the given field and order.
+// It can be passed multiple times to sort by several fields.
+func WithSort(field SortField, order SortOrder) QueryOption {
+	return func(o *QueryOptions) {
+		o.sort = append(o.sort, string(field)+" "+string(order))
+	}
+}
+
+// WithLimit limits the number of returned events.
+func WithLimit(limit int64) QueryOption {
+	return func(o *QueryOptions) {
+		o.limit = limit
+	}
+}
+
+// WithSkip skips the given number of events.
+func WithSkip(skip int64) QueryOption {
+	return func(o *QueryOptions) {
+		o.offset = skip
+	}
+}
+
+// newQueryOptions applies the given options over the default ones.
+func newQueryOptions(opts ...QueryOption) QueryOptions {
+	var o QueryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// toSQL returns the ORDER BY, LIMIT and OFFSET clauses of the query.
+func (o QueryOptions) toSQL() string {
+	var b strings.Builder
+	if len(o.sort) > 0 {
+		b.WriteString(" ORDER BY ")
+		b.WriteString(strings.Join(o.sort, ", "))
+	}
+	if o.limit > 0 {
+		fmt.Fprintf(&b, " LIMIT %d", o.limit)
+	}
+	if o.offset > 0 {
+		fmt.Fprintf(&b, " OFFSET %d", o.offset)
+	}
+	return b.String()
+}
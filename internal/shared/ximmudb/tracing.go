@@ -0,0 +1,8 @@
+package ximmudb
+
+import "github.com/xfrr/finantrack/internal/shared/xtracing"
+
+// tracerName is the name of the tracer of the package.
+const tracerName = "github.com/xfrr/finantrack/internal/shared/ximmudb"
+
+var tracer = xtracing.Tracer(tracerName)
@@ -0,0 +1,36 @@
+package xlog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+)
+
+// WithLogger returns a copy of the context carrying the logger.
+func WithLogger(ctx context.Context, logger zerolog.Logger) context.Context {
+	return logger.WithContext(ctx)
+}
+
+// FromContext returns the logger carried by the context,
+// a disabled logger when there is none, so it is always safe to use.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
+
+// WithRequestLogger returns a copy of the context carrying a logger derived from the given one,
+// with the trace and request IDs of the context in every line, so the lines of a request can be correlated.
+func WithRequestLogger(ctx context.Context, logger zerolog.Logger) context.Context {
+	lctx := logger.With()
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		lctx = lctx.Str("trace_id", spanCtx.TraceID().String())
+	}
+	if md, ok := xevent.MetadataFromContext(ctx); ok && md.RequestID != "" {
+		lctx = lctx.Str("request_id", md.RequestID)
+	}
+
+	return WithLogger(ctx, lctx.Logger())
+}
@@ -1,20 +1,114 @@
 package xlog
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/rs/zerolog"
 )
 
-func NewZerologger(serviceName, environment string) zerolog.Logger {
+// Format is the encoding of the log lines.
+type Format string
+
+const (
+	// FormatJSON writes a JSON object per line, for the log collectors.
+	FormatJSON Format = "json"
+
+	// FormatConsole writes colored human readable lines, for development.
+	FormatConsole Format = "console"
+)
+
+// ErrUnsupportedFormat is returned when the log format is unknown.
+var ErrUnsupportedFormat = errors.New("unsupported log format")
+
+// ParseFormat returns the format with the given name, case insensitive.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range []Format{FormatJSON, FormatConsole} {
+		if strings.EqualFold(name, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, name)
+}
+
+// ErrUnsupportedLevel is returned when the log level is unknown.
+var ErrUnsupportedLevel = errors.New("unsupported log level")
+
+// ParseLevel returns the level with the given name, case insensitive, e.g. "debug" or "warn".
+func ParseLevel(name string) (zerolog.Level, error) {
+	level, err := zerolog.ParseLevel(strings.ToLower(name))
+	if err != nil || name == "" {
+		return zerolog.NoLevel, fmt.Errorf("%w: %q", ErrUnsupportedLevel, name)
+	}
+	return level, nil
+}
+
+// Config is the configuration of the logger. The zero value logs the debug
+// lines to the console in development, and the info lines as JSON otherwise.
+type Config struct {
+	Format Format
+
+	// Level is the name of the minimum level of the lines written, e.g. "info".
+	Level string
+
+	// Output is where the lines are written, os.Stderr when nil.
+	Output io.Writer `json:"-"`
+}
+
+// Validate checks the format and the level of the configuration, when set.
+func (c Config) Validate() error {
+	if c.Format != "" {
+		if _, err := ParseFormat(string(c.Format)); err != nil {
+			return err
+		}
+	}
+	if c.Level != "" {
+		if _, err := ParseLevel(c.Level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewZerologger returns the logger of the service, with the service name and environment in every line.
+// The format and level that are not set or invalid fall back to the defaults of the environment.
+func NewZerologger(serviceName, environment string, cfg Config) zerolog.Logger {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	zerolog.SetGlobalLevel(zerolog.DebugLevel)
 
-	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).
+	development := environment == "development"
+
+	format, err := ParseFormat(string(cfg.Format))
+	if err != nil {
+		format = FormatJSON
+		if development {
+			format = FormatConsole
+		}
+	}
+
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		level = zerolog.InfoLevel
+		if development {
+			level = zerolog.DebugLevel
+		}
+	}
+
+	out := cfg.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	if format == FormatConsole {
+		out = zerolog.ConsoleWriter{Out: out}
+	}
+
+	return zerolog.New(out).
+		Level(level).
 		With().
 		Str("service", serviceName).
 		Str("environment", environment).
 		Timestamp().
 		Logger()
-	return logger
 }
@@ -0,0 +1,85 @@
+package xlog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+
+	. "github.com/xfrr/finantrack/internal/shared/xlog"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, zerolog.WarnLevel, level)
+
+	_, err = ParseLevel("")
+	assert.ErrorIs(t, err, ErrUnsupportedLevel)
+
+	_, err = ParseLevel("verbose")
+	assert.ErrorIs(t, err, ErrUnsupportedLevel)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{Format: FormatConsole, Level: "error"}.Validate())
+	assert.ErrorIs(t, Config{Format: "xml"}.Validate(), ErrUnsupportedFormat)
+	assert.ErrorIs(t, Config{Level: "verbose"}.Validate(), ErrUnsupportedLevel)
+}
+
+func TestNewZerologger(t *testing.T) {
+	var specs = []struct {
+		name        string
+		environment string
+		cfg         Config
+		json        bool
+		debug       bool
+	}{
+		{name: "development defaults", environment: "development", json: false, debug: true},
+		{name: "production defaults", environment: "production", json: true, debug: false},
+		{name: "configured", environment: "development", cfg: Config{Format: FormatJSON, Level: "warn"}, json: true, debug: false},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			var out bytes.Buffer
+			spec.cfg.Output = &out
+
+			logger := NewZerologger("test", spec.environment, spec.cfg)
+			logger.Debug().Msg("debug")
+			logger.Error().Msg("error")
+
+			assert.Equal(t, spec.debug, bytes.Contains(out.Bytes(), []byte("debug")))
+			assert.Contains(t, out.String(), "error")
+			assert.Equal(t, spec.json, json.Valid(bytes.Split(out.Bytes(), []byte("\n"))[0]))
+		})
+	}
+}
+
+func TestWithRequestLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewZerologger("test", "test", Config{Format: FormatJSON, Output: &out})
+
+	// without a logger in the context, nothing is logged
+	FromContext(context.Background()).Error().Msg("dropped")
+	assert.Empty(t, out.String())
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	ctx = xevent.WithMetadata(ctx, xevent.Metadata{RequestID: "request-1"})
+
+	FromContext(WithRequestLogger(ctx, logger)).Info().Msg("handled")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "request-1", line["request_id"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), line["trace_id"])
+}
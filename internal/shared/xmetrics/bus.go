@@ -0,0 +1,33 @@
+package xmetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	busRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "bus",
+		Name:      "request_errors_total",
+		Help:      "Number of commands and queries that failed, by name.",
+	}, []string{"kind", "name"})
+
+	busRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "bus",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the commands and queries, by name. The count is the number of requests dispatched.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind", "name"})
+)
+
+// ObserveBusRequest records a request dispatched to a bus and handled since the given time.
+// The kind is the kind of request, i.e. "command" or "query", and the name its CommandName or QueryName.
+func ObserveBusRequest(kind, name string, start time.Time, err error) {
+	busRequestDuration.WithLabelValues(kind, name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		busRequestErrors.WithLabelValues(kind, name).Inc()
+	}
+}
@@ -0,0 +1,58 @@
+package xmetrics
+
+import (
+	"iter"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Backends of the event stores.
+const (
+	BackendMongoDB = "mongodb"
+	BackendImmuDB  = "immudb"
+)
+
+var (
+	eventStoreErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "event_store",
+		Name:      "operation_errors_total",
+		Help:      "Number of event store operations that failed, by backend and operation.",
+	}, []string{"backend", "operation"})
+
+	eventStoreDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "event_store",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of the event store operations, by backend and operation.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"backend", "operation"})
+)
+
+// ObserveEventStore records an operation of the event store of a backend started at the given time.
+// It is meant to be deferred with a pointer to the named error of the operation.
+func ObserveEventStore(backend, operation string, start time.Time, err *error) {
+	eventStoreDuration.WithLabelValues(backend, operation).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil {
+		eventStoreErrors.WithLabelValues(backend, operation).Inc()
+	}
+}
+
+// ObserveEventStream records a streaming operation of the event store of a backend,
+// from the start of the iteration until it ends, failing on the first error yielded.
+func ObserveEventStream[T any](backend, operation string, seq iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var err error
+		defer ObserveEventStore(backend, operation, time.Now(), &err)
+
+		for v, e := range seq {
+			if e != nil {
+				err = e
+			}
+			if !yield(v, e) {
+				return
+			}
+		}
+	}
+}
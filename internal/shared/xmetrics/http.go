@@ -0,0 +1,43 @@
+package xmetrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests handled, by route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_errors_total",
+		Help:      "Number of HTTP requests that failed with a server error, by route.",
+	}, []string{"method", "route"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the HTTP requests, by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// ObserveHTTPRequest records a request handled by the given route, the path template
+// it matched, such as "/api/v1/assets/:id". Only the 5xx responses are counted as errors.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+
+	if status >= 500 {
+		httpRequestErrors.WithLabelValues(method, route).Inc()
+	}
+}
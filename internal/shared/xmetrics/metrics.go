@@ -0,0 +1,42 @@
+// Package xmetrics records the Prometheus metrics of the services: the rate, errors and
+// duration of the HTTP requests, of the commands and queries dispatched to the buses
+// and of the event store operations.
+//
+// The metrics are registered in Registry, which xhttp.WithMetrics serves on /metrics.
+package xmetrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of the metrics.
+const Namespace = "finantrack"
+
+// Registry is the registry of the metrics of the process, along with the Go runtime
+// and process metrics.
+var Registry = newRegistry()
+
+func newRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestErrors,
+		httpRequestDuration,
+		busRequestErrors,
+		busRequestDuration,
+		eventStoreErrors,
+		eventStoreDuration,
+	)
+	return registry
+}
+
+// Handler returns the handler exposing the metrics of the registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
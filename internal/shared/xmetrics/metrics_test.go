@@ -0,0 +1,74 @@
+package xmetrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xmetrics"
+)
+
+func scrape(t *testing.T) string {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestObserveBusRequest(t *testing.T) {
+	start := time.Now()
+	ObserveBusRequest("command", "TestCommand", start, nil)
+	ObserveBusRequest("command", "TestCommand", start, errors.New("invalid"))
+
+	metrics := scrape(t)
+	assert.Contains(t, metrics, `finantrack_bus_request_duration_seconds_count{kind="command",name="TestCommand"} 2`)
+	assert.Contains(t, metrics, `finantrack_bus_request_errors_total{kind="command",name="TestCommand"} 1`)
+}
+
+func TestObserveEventStream(t *testing.T) {
+	seq := func(values []int, err error) func(func(int, error) bool) {
+		return func(yield func(int, error) bool) {
+			for _, v := range values {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if err != nil {
+				yield(0, err)
+			}
+		}
+	}
+
+	var got []int
+	for v, err := range ObserveEventStream("test", "stream", seq([]int{1, 2, 3}, nil)) {
+		require.NoError(t, err)
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	// stopping the iteration early is not an error
+	for range ObserveEventStream("test", "stream", seq([]int{1, 2, 3}, nil)) {
+		break
+	}
+
+	for _, err := range ObserveEventStream("test", "stream", seq([]int{1}, errors.New("connection reset"))) {
+		if err != nil {
+			break
+		}
+	}
+
+	metrics := scrape(t)
+	assert.Contains(t, metrics, `finantrack_event_store_operation_duration_seconds_count{backend="test",operation="stream"} 3`)
+	assert.Contains(t, metrics, `finantrack_event_store_operation_errors_total{backend="test",operation="stream"} 1`)
+	assert.True(t, strings.Contains(metrics, "go_goroutines"), "runtime metrics are exposed")
+}
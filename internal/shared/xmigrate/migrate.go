@@ -0,0 +1,175 @@
+// Package xmigrate applies versioned migrations to a database, recording the ID and the checksum
+// of every migration applied so each one runs once, and refusing to run when the migrations
+// applied to the database drifted from the ones of the service.
+//
+// The database engines implement the migrations and the store of their records,
+// see ximmudb and xmongo.
+package xmigrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrDrift is returned when a migration applied to the database is unknown or was changed.
+	ErrDrift = errors.New("migrations drifted from the ones applied to the database")
+
+	// ErrDuplicateID is returned when two migrations have the same ID.
+	ErrDuplicateID = errors.New("duplicate migration ID")
+)
+
+// Migration is a versioned change of the schema of a database of type DB.
+// The migrations must be idempotent, as a migration interrupted before
+// it is recorded runs again.
+type Migration[DB any] interface {
+	// ID identifies the migration among the ones applied to the database.
+	ID() string
+
+	// Definition describes the changes of the migration, e.g. its SQL statements.
+	// The checksum of the migration is computed from it, so a migration changed
+	// after it was applied is detected.
+	Definition() string
+
+	// Up applies the migration.
+	Up(ctx context.Context, db DB) error
+}
+
+// Checksum returns the SHA-256 checksum of the definition of the migration, hex encoded.
+func Checksum[DB any](m Migration[DB]) string {
+	sum := sha256.Sum256([]byte(m.Definition()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Record is a migration applied to a database.
+type Record struct {
+	ID        string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Store keeps the records of the migrations applied to a database.
+type Store interface {
+	// Init creates the storage of the records when it does not exist.
+	Init(ctx context.Context) error
+
+	// Applied returns the records of the migrations applied, none when the storage does not exist.
+	Applied(ctx context.Context) ([]Record, error)
+
+	// Save records the migration as applied.
+	Save(ctx context.Context, record Record) error
+}
+
+// Step is a migration to apply.
+type Step struct {
+	ID       string
+	Checksum string
+}
+
+// Plan is the outcome of comparing the migrations with the ones applied to a database.
+type Plan struct {
+	// Applied are the records of the migrations already applied.
+	Applied []Record
+
+	// Pending are the migrations to apply, in order.
+	Pending []Step
+}
+
+// Option configures a run of the migrations.
+type Option func(*options)
+
+type options struct {
+	dryRun bool
+}
+
+// WithDryRun returns the plan of the run without applying any migration or writing to the database.
+func WithDryRun() Option {
+	return func(o *options) {
+		o.dryRun = true
+	}
+}
+
+// NewPlan compares the migrations, in the order they are applied, with the ones applied to the database.
+// It fails with ErrDrift when a migration applied is unknown or its checksum changed.
+func NewPlan[DB any](ctx context.Context, store Store, migrations []Migration[DB]) (Plan, error) {
+	checksums := make(map[string]string, len(migrations))
+	for _, m := range migrations {
+		if _, ok := checksums[m.ID()]; ok {
+			return Plan{}, fmt.Errorf("%w: %s", ErrDuplicateID, m.ID())
+		}
+		checksums[m.ID()] = Checksum(m)
+	}
+
+	applied, err := store.Applied(ctx)
+	if err != nil {
+		return Plan{}, fmt.Errorf("failed to read the applied migrations: %w", err)
+	}
+
+	var (
+		drift     []error
+		isApplied = make(map[string]struct{}, len(applied))
+	)
+	for _, r := range applied {
+		isApplied[r.ID] = struct{}{}
+
+		checksum, ok := checksums[r.ID]
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Errorf("migration %s is applied but unknown", r.ID))
+		case checksum != r.Checksum:
+			drift = append(drift, fmt.Errorf("migration %s changed after it was applied: checksum %s, applied %s",
+				r.ID, checksum, r.Checksum))
+		}
+	}
+	if len(drift) > 0 {
+		return Plan{}, fmt.Errorf("%w: %w", ErrDrift, errors.Join(drift...))
+	}
+
+	plan := Plan{Applied: applied}
+	for _, m := range migrations {
+		if _, ok := isApplied[m.ID()]; !ok {
+			plan.Pending = append(plan.Pending, Step{ID: m.ID(), Checksum: checksums[m.ID()]})
+		}
+	}
+	return plan, nil
+}
+
+// Run applies the pending migrations of the plan in order, recording each one as soon as
+// it is applied, and returns the plan. It stops at the first migration that fails.
+func Run[DB any](ctx context.Context, db DB, store Store, migrations []Migration[DB], opts ...Option) (Plan, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	plan, err := NewPlan(ctx, store, migrations)
+	if err != nil || o.dryRun || len(plan.Pending) == 0 {
+		return plan, err
+	}
+
+	if err = store.Init(ctx); err != nil {
+		return plan, fmt.Errorf("failed to create the migrations storage: %w", err)
+	}
+
+	byID := make(map[string]Migration[DB], len(migrations))
+	for _, m := range migrations {
+		byID[m.ID()] = m
+	}
+
+	for _, step := range plan.Pending {
+		if err = byID[step.ID].Up(ctx, db); err != nil {
+			return plan, fmt.Errorf("failed to apply migration %s: %w", step.ID, err)
+		}
+
+		err = store.Save(ctx, Record{ID: step.ID, Checksum: step.Checksum, AppliedAt: time.Now().UTC()})
+		if err != nil {
+			return plan, fmt.Errorf("failed to record migration %s: %w", step.ID, err)
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,167 @@
+package xmigrate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xmigrate"
+)
+
+// database records the migrations run against it.
+type database struct {
+	ran []string
+}
+
+type mockMigration struct {
+	id         string
+	definition string
+	err        error
+}
+
+func (m mockMigration) ID() string         { return m.id }
+func (m mockMigration) Definition() string { return m.definition }
+
+func (m mockMigration) Up(_ context.Context, db *database) error {
+	if m.err != nil {
+		return m.err
+	}
+	db.ran = append(db.ran, m.id)
+	return nil
+}
+
+type mockStore struct {
+	records []Record
+	inits   int
+}
+
+func (s *mockStore) Init(context.Context) error {
+	s.inits++
+	return nil
+}
+
+func (s *mockStore) Applied(context.Context) ([]Record, error) {
+	return s.records, nil
+}
+
+func (s *mockStore) Save(_ context.Context, record Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func migrations(ms ...mockMigration) []Migration[*database] {
+	result := make([]Migration[*database], 0, len(ms))
+	for _, m := range ms {
+		result = append(result, m)
+	}
+	return result
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	db := &database{}
+	store := &mockStore{}
+	ms := migrations(
+		mockMigration{id: "0001", definition: "create a"},
+		mockMigration{id: "0002", definition: "create b"},
+	)
+
+	plan, err := Run(ctx, db, store, ms)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Applied)
+	assert.Equal(t, []Step{
+		{ID: "0001", Checksum: Checksum(ms[0])},
+		{ID: "0002", Checksum: Checksum(ms[1])},
+	}, plan.Pending)
+	assert.Equal(t, []string{"0001", "0002"}, db.ran)
+	require.Len(t, store.records, 2)
+	assert.Equal(t, Checksum(ms[1]), store.records[1].Checksum)
+
+	// running again applies only the new migrations
+	ms = append(ms, mockMigration{id: "0003", definition: "create c"})
+	plan, err = Run(ctx, db, store, ms)
+	require.NoError(t, err)
+	assert.Len(t, plan.Applied, 2)
+	assert.Equal(t, []Step{{ID: "0003", Checksum: Checksum(ms[2])}}, plan.Pending)
+	assert.Equal(t, []string{"0001", "0002", "0003"}, db.ran)
+
+	plan, err = Run(ctx, db, store, ms)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Pending)
+	assert.Len(t, db.ran, 3)
+	assert.Equal(t, 2, store.inits)
+}
+
+func TestRun_DryRun(t *testing.T) {
+	db := &database{}
+	store := &mockStore{}
+
+	plan, err := Run(context.Background(), db, store, migrations(mockMigration{id: "0001", definition: "create a"}), WithDryRun())
+	require.NoError(t, err)
+	assert.Len(t, plan.Pending, 1)
+	assert.Empty(t, db.ran)
+	assert.Empty(t, store.records)
+	assert.Zero(t, store.inits)
+}
+
+func TestRun_Errors(t *testing.T) {
+	var (
+		a     = mockMigration{id: "0001", definition: "create a"}
+		b     = mockMigration{id: "0002", definition: "create b"}
+		fails = errors.New("fails")
+	)
+
+	var specs = []struct {
+		name       string
+		applied    []Record
+		migrations []Migration[*database]
+		is         error
+		contains   string
+		ran        []string
+	}{
+		{
+			name:       "changed checksum",
+			applied:    []Record{{ID: "0001", Checksum: "changed"}},
+			migrations: migrations(a, b),
+			is:         ErrDrift,
+			contains:   "migration 0001 changed after it was applied",
+		},
+		{
+			name:       "unknown migration",
+			applied:    []Record{{ID: "0001", Checksum: Checksum[*database](a)}, {ID: "0000", Checksum: "x"}},
+			migrations: migrations(a, b),
+			is:         ErrDrift,
+			contains:   "migration 0000 is applied but unknown",
+		},
+		{
+			name:       "duplicate ID",
+			migrations: migrations(a, mockMigration{id: "0001", definition: "create c"}),
+			is:         ErrDuplicateID,
+		},
+		{
+			name:       "failed migration",
+			migrations: migrations(a, mockMigration{id: "0002", err: fails}, mockMigration{id: "0003"}),
+			is:         fails,
+			contains:   "failed to apply migration 0002",
+			ran:        []string{"0001"},
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			db := &database{}
+			store := &mockStore{records: spec.applied}
+
+			_, err := Run(context.Background(), db, store, spec.migrations)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, spec.is)
+			if spec.contains != "" {
+				assert.ErrorContains(t, err, spec.contains)
+			}
+			assert.Equal(t, spec.ran, db.ran)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 const (
@@ -21,7 +22,9 @@ type Client struct {
 
 // NewClient returns a new MongoDB client with a connection to the specified URI and database.
 func NewClient(ctx context.Context, uri string, database string) (*Client, error) {
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	client, err := mongo.Connect(ctx, options.Client().
+		ApplyURI(uri).
+		SetMonitor(newCommandMonitor()))
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to MongoDB: %w", err)
 	}
@@ -45,3 +48,13 @@ func (c *Client) Close(ctx context.Context) error {
 
 	return nil
 }
+
+// Ping checks that the primary of the MongoDB deployment is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	err := c.Client().Ping(ctx, readpref.Primary())
+	if err != nil {
+		return fmt.Errorf("error pinging MongoDB: %w", err)
+	}
+
+	return nil
+}
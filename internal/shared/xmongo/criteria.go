@@ -1,6 +1,10 @@
 package xmongo
 
-import "go.mongodb.org/mongo-driver/bson"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
 
 // Criteria represents a criteria to be used in a query to get events from the storage.
 type Criteria interface {
@@ -45,6 +49,23 @@ func (c *aggregateIDCriteria) ToBSON() bson.D {
 	return bson.D{{Key: "aggregate_id", Value: c.aggregateID}}
 }
 
+// tenantIDCriteria is a Criteria to get events by tenant ID.
+type tenantIDCriteria struct {
+	tenantID string
+}
+
+// WithTenantIDCriteria returns a CriteriaBuilder that builds a Criteria to get events by tenant ID.
+func WithTenantIDCriteria(tenantID string) CriteriaBuilder {
+	return func() Criteria {
+		return &tenantIDCriteria{tenantID: tenantID}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *tenantIDCriteria) ToBSON() bson.D {
+	return bson.D{{Key: "tenant_id", Value: c.tenantID}}
+}
+
 // aggregateTypeCriteria is a Criteria to get events by aggregate type.
 type aggregateTypeCriteria struct {
 	aggregateType string
@@ -96,22 +117,151 @@ func (c *typeCriteria) ToBSON() bson.D {
 	return bson.D{{Key: "type", Value: c.eventType}}
 }
 
-// TODO: Implement versionCriteria
 // versionCriteria is a Criteria to get events by version.
-// type versionCriteria struct {
-// 	version int
-// }
-
-// func WithEventVersionCriteria(version int) CriteriaBuilder {
-// 	return func() Criteria {
-// 		return &versionCriteria{version: version}
-// 	}
-// }
-
-// // ToBSON returns the criteria as a BSON document.
-// func (c *versionCriteria) ToBSON() bson.D {
-// 	return bson.D{{Key: "version", Value: c.version}}
-// }
+type versionCriteria struct {
+	version int
+}
+
+// WithEventVersionCriteria returns a CriteriaBuilder that builds a Criteria to get events by version.
+func WithEventVersionCriteria(version int) CriteriaBuilder {
+	return func() Criteria {
+		return &versionCriteria{version: version}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *versionCriteria) ToBSON() bson.D {
+	return bson.D{{Key: "version", Value: c.version}}
+}
+
+// aggregateVersionRangeCriteria is a Criteria to get events by an aggregate version range.
+type aggregateVersionRangeCriteria struct {
+	from int
+	to   int
+}
+
+// WithAggregateVersionRangeCriteria returns a CriteriaBuilder that builds a Criteria to get events
+// whose aggregate version is between from and to, both inclusive.
+// A zero value on any of the bounds leaves that side of the range open.
+func WithAggregateVersionRangeCriteria(from, to int) CriteriaBuilder {
+	return func() Criteria {
+		return &aggregateVersionRangeCriteria{from: from, to: to}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *aggregateVersionRangeCriteria) ToBSON() bson.D {
+	var rng bson.D
+	if c.from > 0 {
+		rng = append(rng, bson.E{Key: "$gte", Value: c.from})
+	}
+	if c.to > 0 {
+		rng = append(rng, bson.E{Key: "$lte", Value: c.to})
+	}
+	if len(rng) == 0 {
+		return bson.D{}
+	}
+	return bson.D{{Key: "aggregate_version", Value: rng}}
+}
+
+// timestampRangeCriteria is a Criteria to get events by a timestamp range.
+type timestampRangeCriteria struct {
+	from time.Time
+	to   time.Time
+}
+
+// WithTimestampRangeCriteria returns a CriteriaBuilder that builds a Criteria to get events
+// whose timestamp is between from and to, both inclusive.
+// A zero time on any of the bounds leaves that side of the range open.
+func WithTimestampRangeCriteria(from, to time.Time) CriteriaBuilder {
+	return func() Criteria {
+		return &timestampRangeCriteria{from: from, to: to}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *timestampRangeCriteria) ToBSON() bson.D {
+	var rng bson.D
+	if !c.from.IsZero() {
+		rng = append(rng, bson.E{Key: "$gte", Value: c.from})
+	}
+	if !c.to.IsZero() {
+		rng = append(rng, bson.E{Key: "$lte", Value: c.to})
+	}
+	if len(rng) == 0 {
+		return bson.D{}
+	}
+	return bson.D{{Key: "timestamp", Value: rng}}
+}
+
+// parentIDCriteria is a Criteria to get events by the parent aggregate of their payload.
+type parentIDCriteria struct {
+	parentID string
+}
+
+// WithParentIDCriteria returns a CriteriaBuilder that builds a Criteria to get events by the ID
+// of the parent aggregate of their payload. See xevent.Child.
+func WithParentIDCriteria(parentID string) CriteriaBuilder {
+	return func() Criteria {
+		return &parentIDCriteria{parentID: parentID}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *parentIDCriteria) ToBSON() bson.D {
+	return bson.D{{Key: "parent_id", Value: c.parentID}}
+}
+
+// eventDateRangeCriteria is a Criteria to get events by a range of the date of their payload.
+type eventDateRangeCriteria struct {
+	from time.Time
+	to   time.Time
+}
+
+// WithEventDateRangeCriteria returns a CriteriaBuilder that builds a Criteria to get events
+// whose payload date is between from and to, both inclusive. See xevent.Dated.
+// A zero time on any of the bounds leaves that side of the range open,
+// but the events without date never match.
+func WithEventDateRangeCriteria(from, to time.Time) CriteriaBuilder {
+	return func() Criteria {
+		return &eventDateRangeCriteria{from: from, to: to}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *eventDateRangeCriteria) ToBSON() bson.D {
+	rng := bson.D{{Key: "$exists", Value: true}}
+	if !c.from.IsZero() {
+		rng = append(rng, bson.E{Key: "$gte", Value: c.from})
+	}
+	if !c.to.IsZero() {
+		rng = append(rng, bson.E{Key: "$lte", Value: c.to})
+	}
+	return bson.D{{Key: "event_date", Value: rng}}
+}
+
+// bsonCriteria is a Criteria given as a BSON document.
+type bsonCriteria bson.D
+
+// ToBSON returns the criteria as a BSON document.
+func (c bsonCriteria) ToBSON() bson.D {
+	return bson.D(c)
+}
+
+// allCriteria is a Criteria that matches every event.
+type allCriteria struct{}
+
+// All returns a CriteriaBuilder that builds a Criteria that matches every event.
+func All() CriteriaBuilder {
+	return func() Criteria {
+		return &allCriteria{}
+	}
+}
+
+// ToBSON returns the criteria as a BSON document.
+func (c *allCriteria) ToBSON() bson.D {
+	return bson.D{}
+}
 
 // And is a CriteriaBuilder that builds a Criteria that is the result of the logical AND operation between two Criteria.
 func And(crs ...Criteria) CriteriaBuilder {
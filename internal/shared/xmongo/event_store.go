@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xmetrics"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
 	"github.com/xfrr/go-cqrsify/aggregate"
 	"github.com/xfrr/go-cqrsify/event"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // DefaultCollectionName is the default collection name for events.
@@ -24,8 +28,11 @@ type Event = aggregate.Change
 // EventStore defines the interface for saving and retrieving events.
 type EventStore interface {
 	Save(ctx context.Context, events ...Event) error
-	Get(ctx context.Context, criteria Criteria) ([]Event, error)
+	Get(ctx context.Context, criteria Criteria, opts ...QueryOption) ([]Event, error)
+	GetPage(ctx context.Context, criteria Criteria, opts ...QueryOption) (Page, error)
+	Stream(ctx context.Context, criteria Criteria, opts ...QueryOption) iter.Seq2[Event, error]
 	ExistsByAggregateID(ctx context.Context, aggregateID uuid.UUID) (bool, error)
+	AssignTenant(ctx context.Context, aggregateID uuid.UUID, tenantID string) error
 }
 
 // MongoEventStore is the MongoDB implementation of EventStore.
@@ -35,42 +42,42 @@ type MongoEventStore struct {
 }
 
 // NewMongoEventStore creates a new instance of MongoEventStore.
-// It also creates the necessary indexes for the events collection.
 // The registry is used to resolve the payload type for each event type.
-func NewMongoEventStore(
-	ctx context.Context,
-	client *Client,
-	registry xevent.Registry,
-) (*MongoEventStore, error) {
-	// Create indexes
-	mes := &MongoEventStore{
+// The indexes of the events collection are created by EventStoreMigrations.
+func NewMongoEventStore(client *Client, registry xevent.Registry) *MongoEventStore {
+	return &MongoEventStore{
 		client:                 client,
 		payloadFactoryRegistry: registry,
 	}
-
-	// Create indexes
-	err := mes.createIndexes(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	return mes, nil
 }
 
 // Save saves the events in the storage.
-func (s *MongoEventStore) Save(ctx context.Context, events ...Event) error {
+func (s *MongoEventStore) Save(ctx context.Context, events ...Event) (err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendMongoDB, "save", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "MongoEventStore.Save", trace.WithAttributes(xtracing.ChangesAttributes(events)...))
+	defer xtracing.End(span, &err)
+
 	var dtos []interface{}
 
+	md, hasMetadata := xevent.MetadataFromContext(ctx)
+	tenantID, _ := xtenant.FromContext(ctx)
+
 	for _, e := range events {
 		dto, err := s.eventToDTO(e)
 		if err != nil {
 			return fmt.Errorf("failed to convert event to DTO: %w", err)
 		}
 
+		if hasMetadata && !md.IsZero() {
+			dto.Metadata = &md
+		}
+		dto.TenantID = tenantID
+
 		dtos = append(dtos, dto)
 	}
 
-	_, err := s.client.
+	_, err = s.client.
 		Collection(DefaultCollectionName).
 		InsertMany(ctx, dtos)
 	if err != nil {
@@ -81,35 +88,179 @@ func (s *MongoEventStore) Save(ctx context.Context, events ...Event) error {
 }
 
 // Get retrieves events from the storage that match the given criteria.
-func (s *MongoEventStore) Get(ctx context.Context, criteria Criteria) ([]Event, error) {
+// The results can be sorted, limited and skipped using the query options.
+// Use Stream to read large amounts of events without loading them in memory.
+func (s *MongoEventStore) Get(ctx context.Context, criteria Criteria, opts ...QueryOption) (events []Event, err error) {
+	ctx, span := tracer.Start(ctx, "MongoEventStore.Get")
+	defer func() {
+		span.SetAttributes(xtracing.EventCountKey.Int(len(events)))
+		xtracing.End(span, &err)
+	}()
+
+	for e, serr := range xmetrics.ObserveEventStream(xmetrics.BackendMongoDB, "get", s.stream(ctx, criteria, opts...)) {
+		if serr != nil {
+			return nil, serr
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// Stream returns an iterator over the events from the storage that match the given criteria.
+// Events are decoded one by one as the iteration advances and fetched from the server
+// in batches, which size can be tuned using WithBatchSize.
+// The iteration stops after yielding the first error.
+func (s *MongoEventStore) Stream(ctx context.Context, criteria Criteria, opts ...QueryOption) iter.Seq2[Event, error] {
+	return xmetrics.ObserveEventStream(xmetrics.BackendMongoDB, "stream", xtracing.EventStream(ctx, tracer, "MongoEventStore.Stream",
+		func(ctx context.Context) iter.Seq2[Event, error] {
+			return s.stream(ctx, criteria, opts...)
+		},
+	))
+}
+
+// stream returns the iterator of Stream without recording its metrics and span.
+func (s *MongoEventStore) stream(ctx context.Context, criteria Criteria, opts ...QueryOption) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		qopts := newQueryOptions(opts...)
+
+		cursor, err := s.client.
+			Collection(DefaultCollectionName).
+			Find(ctx, criteria.ToBSON(), qopts.findOptions())
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to find events: %w", err))
+			return
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var dto eventDTO
+			if err = cursor.Decode(&dto); err != nil {
+				yield(nil, fmt.Errorf("failed to decode event: %w", err))
+				return
+			}
+
+			var e Event
+			e, err = s.createEventFromDTO(dto)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to convert DTO to event: %w", err))
+				return
+			}
+
+			if !yield(e, nil) {
+				return
+			}
+		}
+
+		if err = cursor.Err(); err != nil {
+			yield(nil, fmt.Errorf("failed to iterate events: %w", err))
+		}
+	}
+}
+
+// GetPage retrieves a page of events from the storage that match the given criteria.
+// The events are sorted by timestamp unless a sort is given, and the page size
+// defaults to DefaultPageSize. Use the returned cursor with WithCursor to get the next page.
+func (s *MongoEventStore) GetPage(ctx context.Context, criteria Criteria, opts ...QueryOption) (_ Page, err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendMongoDB, "get_page", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "MongoEventStore.GetPage")
+	defer xtracing.End(span, &err)
+
+	qopts := newQueryOptions(opts...)
+	if qopts.limit <= 0 {
+		qopts.limit = DefaultPageSize
+	}
+
+	sort := qopts.keysetSort()
+
+	filter := criteria.ToBSON()
+	if qopts.cursor != "" {
+		values, err := decodeCursor(qopts.cursor, len(sort))
+		if err != nil {
+			return Page{}, err
+		}
+		filter = bson.D{{Key: "$and", Value: bson.A{filter, cursorFilter(sort, values)}}}
+	}
+
+	// Fetch one extra event to know if there is a next page.
+	fopts := options.Find().
+		SetSort(sort).
+		SetLimit(qopts.limit + 1)
+	if qopts.skip > 0 {
+		fopts.SetSkip(qopts.skip)
+	}
+
 	cursor, err := s.client.
 		Collection(DefaultCollectionName).
-		Find(ctx, criteria.ToBSON())
+		Find(ctx, filter, fopts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find events: %w", err)
+		return Page{}, fmt.Errorf("failed to find events: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var dtos []eventDTO
-	if err = cursor.All(ctx, &dtos); err != nil {
-		return nil, fmt.Errorf("failed to decode events: %w", err)
-	}
+	var (
+		page Page
+		last bson.Raw
+	)
+	for cursor.Next(ctx) {
+		if int64(len(page.Events)) == qopts.limit {
+			page.NextCursor, err = s.cursorFrom(last, sort)
+			if err != nil {
+				return Page{}, err
+			}
+			break
+		}
+
+		var dto eventDTO
+		if err = cursor.Decode(&dto); err != nil {
+			return Page{}, fmt.Errorf("failed to decode event: %w", err)
+		}
 
-	var events []Event
-	for _, dto := range dtos {
 		var e Event
 		e, err = s.createEventFromDTO(dto)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert DTO to event: %w", err)
+			return Page{}, fmt.Errorf("failed to convert DTO to event: %w", err)
 		}
-		events = append(events, e)
+
+		page.Events = append(page.Events, e)
+		last = append(last[:0], cursor.Current...)
+	}
+	if err = cursor.Err(); err != nil {
+		return Page{}, fmt.Errorf("failed to iterate events: %w", err)
 	}
 
-	return events, nil
+	return page, nil
+}
+
+// cursorFrom builds the cursor pointing after the given document.
+func (s *MongoEventStore) cursorFrom(doc bson.Raw, sort bson.D) (string, error) {
+	values := make(bson.A, len(sort))
+	for i, e := range sort {
+		rv, err := doc.LookupErr(e.Key)
+		if err != nil {
+			return "", fmt.Errorf("failed to build cursor: %w", err)
+		}
+
+		var v interface{}
+		if err = rv.Unmarshal(&v); err != nil {
+			return "", fmt.Errorf("failed to build cursor: %w", err)
+		}
+		values[i] = v
+	}
+
+	return encodeCursor(values)
 }
 
 // ExistsByAggregateID checks if an event exists for the given aggregate ID.
-func (s *MongoEventStore) ExistsByAggregateID(ctx context.Context, aggregateID uuid.UUID) (bool, error) {
+func (s *MongoEventStore) ExistsByAggregateID(ctx context.Context, aggregateID uuid.UUID) (_ bool, err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendMongoDB, "exists", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "MongoEventStore.ExistsByAggregateID", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(aggregateID.String()),
+	))
+	defer xtracing.End(span, &err)
+
 	count, err := s.client.
 		Collection(DefaultCollectionName).
 		CountDocuments(ctx, bson.M{"aggregate_id": aggregateID.String()})
@@ -120,6 +271,106 @@ func (s *MongoEventStore) ExistsByAggregateID(ctx context.Context, aggregateID u
 	return count > 0, nil
 }
 
+// AssignTenant sets the tenant of the events of the aggregate saved without one,
+// before the tenants were introduced. The events of other tenants are left untouched.
+func (s *MongoEventStore) AssignTenant(ctx context.Context, aggregateID uuid.UUID, tenantID string) (err error) {
+	defer xmetrics.ObserveEventStore(xmetrics.BackendMongoDB, "assign_tenant", time.Now(), &err)
+
+	ctx, span := tracer.Start(ctx, "MongoEventStore.AssignTenant", trace.WithAttributes(
+		xtracing.AggregateIDKey.String(aggregateID.String()),
+	))
+	defer xtracing.End(span, &err)
+
+	// the tenant is omitted when empty, a null matches the missing field
+	_, err = s.client.
+		Collection(DefaultCollectionName).
+		UpdateMany(ctx,
+			bson.M{"aggregate_id": aggregateID.String(), "tenant_id": bson.M{"$in": bson.A{nil, ""}}},
+			bson.M{"$set": bson.M{"tenant_id": tenantID}},
+		)
+	if err != nil {
+		return fmt.Errorf("failed to assign the tenant of the events: %w", err)
+	}
+	return nil
+}
+
+// Reindex replays all the events into the index of their date and their parent, see xevent.Dated and
+// xevent.Child, e.g. after the date or the parent of a type of event changed. It returns the number
+// of events whose index was written.
+func (s *MongoEventStore) Reindex(ctx context.Context) (int, error) {
+	// the events indexed are indexed again, which resets the index of those having no longer
+	// a date nor a parent, and then the events not indexed having one now
+	reindexed, err := s.index(ctx, indexedCriteria, true)
+	if err != nil {
+		return reindexed, err
+	}
+
+	indexed, err := s.index(ctx, notIndexedCriteria, false)
+	return reindexed + indexed, err
+}
+
+var (
+	indexedCriteria = bsonCriteria{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "event_date", Value: bson.D{{Key: "$exists", Value: true}}}},
+		bson.D{{Key: "parent_id", Value: bson.D{{Key: "$exists", Value: true}}}},
+	}}}
+	notIndexedCriteria = bsonCriteria{
+		{Key: "event_date", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "parent_id", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+)
+
+// index writes the date and the parent of the payloads of the events matching the criteria,
+// removing the fields of those they have not. The events having neither are skipped, unless
+// reset is set. The events are read before they are updated, so the updates do not move the cursor.
+func (s *MongoEventStore) index(ctx context.Context, criteria Criteria, reset bool) (int, error) {
+	var indexes []*eventDTO
+	for e, err := range s.stream(ctx, criteria) {
+		if err != nil {
+			return 0, err
+		}
+
+		dto, err := s.eventToDTO(e)
+		if err != nil {
+			return 0, err
+		}
+		if reset || dto.EventDate != nil || dto.ParentID != "" {
+			indexes = append(indexes, dto)
+		}
+	}
+
+	for i, dto := range indexes {
+		set, unset := bson.D{}, bson.D{}
+		if dto.EventDate != nil {
+			set = append(set, bson.E{Key: "event_date", Value: dto.EventDate})
+		} else {
+			unset = append(unset, bson.E{Key: "event_date", Value: ""})
+		}
+		if dto.ParentID != "" {
+			set = append(set, bson.E{Key: "parent_id", Value: dto.ParentID})
+		} else {
+			unset = append(unset, bson.E{Key: "parent_id", Value: ""})
+		}
+
+		var update bson.D
+		if len(set) > 0 {
+			update = append(update, bson.E{Key: "$set", Value: set})
+		}
+		if len(unset) > 0 {
+			update = append(update, bson.E{Key: "$unset", Value: unset})
+		}
+
+		_, err := s.client.Collection(DefaultCollectionName).UpdateOne(ctx,
+			bson.D{{Key: "_id", Value: dto.ID}},
+			update,
+		)
+		if err != nil {
+			return i, fmt.Errorf("failed to index event %s: %w", dto.ID, err)
+		}
+	}
+	return len(indexes), nil
+}
+
 // Registry returns the payload factory registry.
 func (s *MongoEventStore) Registry() xevent.Registry {
 	return s.payloadFactoryRegistry
@@ -154,7 +405,7 @@ func (s *MongoEventStore) eventToDTO(e Event) (*eventDTO, error) {
 	// Assign the payload.
 	payload := ev.Payload()
 
-	return &eventDTO{
+	dto := &eventDTO{
 		ID:               eventIDStr,
 		Type:             e.Reason(),
 		AggregateID:      aggregateIDStr,
@@ -163,7 +414,15 @@ func (s *MongoEventStore) eventToDTO(e Event) (*eventDTO, error) {
 		Data:             payload,
 		Timestamp:        e.Time(),
 		Version:          1,
-	}, nil
+	}
+
+	// Index the date and the parent of the payload, so the events can be queried by them.
+	if date, ok := xevent.DateOf(payload); ok {
+		dto.EventDate = &date
+	}
+	dto.ParentID, _ = xevent.ParentOf(payload)
+
+	return dto, nil
 }
 
 // createEventFromDTO converts an eventDTO back to an Event.
@@ -198,7 +457,7 @@ func (s *MongoEventStore) createEventFromDTO(dto eventDTO) (Event, error) {
 	}
 
 	// Reconstruct the event.
-	return event.New[any](
+	e := event.New[any](
 		eventID,
 		dto.Type,
 		payload,
@@ -208,68 +467,38 @@ func (s *MongoEventStore) createEventFromDTO(dto eventDTO) (Event, error) {
 			dto.AggregateVersion,
 		),
 		event.WithTime(dto.Timestamp),
-	), nil
-}
-
-// createIndexes creates the necessary indexes for the events collection.
-func (s *MongoEventStore) createIndexes(ctx context.Context) error {
-	// Create an index for the aggregate ID.
-	err := s.createIndex(ctx, bson.D{{Key: "aggregate_id", Value: 1}}, false)
-	if err != nil {
-		return fmt.Errorf("failed to create index for aggregate ID: %w", err)
-	}
-
-	// Create a compound index for the aggregate ID and version.
-	err = s.createIndex(ctx, bson.D{
-		{Key: "aggregate_id", Value: 1},
-		{Key: "aggregate_version", Value: 1},
-	}, true)
-	if err != nil {
-		return fmt.Errorf("failed to create index for aggregate ID and version: %w", err)
-	}
-
-	// Create an index for the event type.
-	err = s.createIndex(ctx, bson.D{{Key: "type", Value: 1}}, false)
-	if err != nil {
-		return fmt.Errorf("failed to create index for event type: %w", err)
-	}
+	)
 
-	// Create an index for the timestamp.
-	err = s.createIndex(ctx, bson.D{{Key: "timestamp", Value: 1}}, false)
-	if err != nil {
-		return fmt.Errorf("failed to create index for timestamp: %w", err)
+	if dto.Metadata == nil {
+		return e, nil
 	}
 
-	return nil
+	return &storedEvent{Event: e, metadata: *dto.Metadata}, nil
 }
 
-// createIndex creates an index in the events collection.
-func (s *MongoEventStore) createIndex(ctx context.Context, keys bson.D, unique bool) error {
-	index := mongo.IndexModel{
-		Keys:    keys,
-		Options: options.Index().SetUnique(unique),
-	}
-
-	_, err := s.client.
-		Collection(DefaultCollectionName).
-		Indexes().
-		CreateOne(ctx, index)
-	if err != nil {
-		return err
-	}
+// eventDTO represents the structure of an event stored in MongoDB.
+type eventDTO struct {
+	ID               string           `bson:"_id"`
+	Type             string           `bson:"type"`
+	AggregateID      string           `bson:"aggregate_id"`
+	AggregateType    string           `bson:"aggregate_type"`
+	AggregateVersion int              `bson:"aggregate_version"`
+	TenantID         string           `bson:"tenant_id,omitempty"`
+	Data             interface{}      `bson:"data,omitempty"`
+	Metadata         *xevent.Metadata `bson:"metadata,omitempty"`
+	Timestamp        time.Time        `bson:"timestamp"`
+	Version          int              `bson:"version"`
+	EventDate        *time.Time       `bson:"event_date,omitempty"`
+	ParentID         string           `bson:"parent_id,omitempty"`
+}
 
-	return nil
+// storedEvent is an Event read from the storage along with its metadata.
+type storedEvent struct {
+	Event
+	metadata xevent.Metadata
 }
 
-// eventDTO represents the structure of an event stored in MongoDB.
-type eventDTO struct {
-	ID               string      `bson:"_id"`
-	Type             string      `bson:"type"`
-	AggregateID      string      `bson:"aggregate_id"`
-	AggregateType    string      `bson:"aggregate_type"`
-	AggregateVersion int         `bson:"aggregate_version"`
-	Data             interface{} `bson:"data,omitempty"`
-	Metadata         interface{} `bson:"metadata,omitempty"`
-	Timestamp        time.Time   `bson:"timestamp"`
-	Version          int         `bson:"version"`
+// Metadata returns the metadata stored with the event.
+func (e *storedEvent) Metadata() xevent.Metadata {
+	return e.metadata
 }
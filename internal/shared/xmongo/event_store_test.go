@@ -42,11 +42,13 @@ func TestEventStore_Save(t *testing.T) {
 		return &mockEventPayload{}
 	})
 
-	sut, err := NewMongoEventStore(ctx, client, registry)
+	_, err = Migrate(ctx, client, EventStoreMigrations(registry))
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	sut := NewMongoEventStore(client, registry)
+
 	t.Run("save event", func(t *testing.T) {
 		// Clean up the database after the test.
 		defer cleanUp(ctx, t, client)
@@ -145,11 +147,13 @@ func TestEventStore_Get(t *testing.T) {
 
 	cleanUp(ctx, t, client)
 	registry := xevent.NewPayloadRegistry()
-	sut, err := NewMongoEventStore(ctx, client, registry)
+	_, err = Migrate(ctx, client, EventStoreMigrations(registry))
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	sut := NewMongoEventStore(client, registry)
+
 	mockEvents := generateMockEvents(ctx, t, sut)
 	defer cleanUp(ctx, t, client)
 
@@ -222,6 +226,35 @@ func TestEventStore_Get(t *testing.T) {
 			)(),
 			expected: []*EventMock{mockEvents[2]},
 		},
+		{
+			name:     "get all events by aggregate version range",
+			criteria: WithAggregateVersionRangeCriteria(2, 3)(),
+			expected: []*EventMock{mockEvents[1], mockEvents[2]},
+		},
+		{
+			name:     "get all events from an aggregate version",
+			criteria: WithAggregateVersionRangeCriteria(4, 0)(),
+			expected: []*EventMock{mockEvents[3], mockEvents[4]},
+		},
+		{
+			name: "get all events by timestamp and aggregate version range",
+			criteria: And(
+				WithTimestampRangeCriteria(
+					time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				)(),
+				WithAggregateVersionRangeCriteria(5, 0)(),
+			)(),
+			expected: []*EventMock{mockEvents[4]},
+		},
+		{
+			name: "get no events out of the timestamp range",
+			criteria: WithTimestampRangeCriteria(
+				time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Time{},
+			)(),
+			expected: []*EventMock{},
+		},
 		{
 			name: "get all events by aggregate id or type and not version",
 			criteria: And(
@@ -261,6 +294,144 @@ func TestEventStore_Get(t *testing.T) {
 	}
 }
 
+func TestEventStore_GetPage(t *testing.T) {
+	var (
+		uri = xos.GetEnvWithDefault("FINANTRACK_TEST_MONGO_URI", "mongodb://localhost:27017")
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := NewClient(ctx, uri, databaseName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cleanUp(ctx, t, client)
+	registry := xevent.NewPayloadRegistry()
+	_, err = Migrate(ctx, client, EventStoreMigrations(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sut := NewMongoEventStore(client, registry)
+
+	mockEvents := generateMockEvents(ctx, t, sut)
+	defer cleanUp(ctx, t, client)
+
+	criteria := WithAggregateVersionRangeCriteria(2, 0)()
+
+	t.Run("get events page by page in descending version order", func(t *testing.T) {
+		var (
+			cursor string
+			got    []string
+		)
+
+		for {
+			page, err := sut.GetPage(ctx, criteria,
+				WithSort(SortByAggregateVersion, Descending),
+				WithLimit(3),
+				WithCursor(cursor),
+			)
+			require.NoError(t, err)
+
+			for _, e := range page.Events {
+				got = append(got, e.ID().(uuid.UUID).String())
+			}
+
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+
+		expected := []string{
+			mockEvents[4].ID().(uuid.UUID).String(),
+			mockEvents[3].ID().(uuid.UUID).String(),
+			mockEvents[2].ID().(uuid.UUID).String(),
+			mockEvents[1].ID().(uuid.UUID).String(),
+		}
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("get events with limit and skip", func(t *testing.T) {
+		events, err := sut.Get(ctx, criteria,
+			WithSort(SortByAggregateVersion, Ascending),
+			WithSkip(1),
+			WithLimit(2),
+		)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, mockEvents[2].ID(), events[0].ID())
+		assert.Equal(t, mockEvents[3].ID(), events[1].ID())
+	})
+
+	t.Run("get page with invalid cursor should return error", func(t *testing.T) {
+		_, err := sut.GetPage(ctx, criteria, WithCursor("not-a-cursor"))
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestEventStore_Stream(t *testing.T) {
+	var (
+		uri = xos.GetEnvWithDefault("FINANTRACK_TEST_MONGO_URI", "mongodb://localhost:27017")
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := NewClient(ctx, uri, databaseName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cleanUp(ctx, t, client)
+	registry := xevent.NewPayloadRegistry()
+	_, err = Migrate(ctx, client, EventStoreMigrations(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sut := NewMongoEventStore(client, registry)
+
+	mockEvents := generateMockEvents(ctx, t, sut)
+	defer cleanUp(ctx, t, client)
+
+	criteria := WithAggregateVersionRangeCriteria(2, 0)()
+
+	t.Run("stream all events in small batches", func(t *testing.T) {
+		var got []any
+		for e, err := range sut.Stream(ctx, criteria,
+			WithSort(SortByAggregateVersion, Ascending),
+			WithBatchSize(1),
+		) {
+			require.NoError(t, err)
+			got = append(got, e.ID())
+		}
+
+		expected := []any{
+			mockEvents[1].ID(),
+			mockEvents[2].ID(),
+			mockEvents[3].ID(),
+			mockEvents[4].ID(),
+		}
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("stop streaming when the consumer breaks", func(t *testing.T) {
+		var count int
+		for _, err := range sut.Stream(ctx, criteria) {
+			require.NoError(t, err)
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count)
+	})
+}
+
 func cleanUp(ctx context.Context, t *testing.T, client *Client) {
 	err := client.Drop(ctx)
 	if err != nil {
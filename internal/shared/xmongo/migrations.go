@@ -0,0 +1,190 @@
+package xmongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xmigrate"
+)
+
+// MigrationsCollectionName is the collection of the records of the migrations applied.
+const MigrationsCollectionName = "schema_migrations"
+
+// Migration represents a versioned MongoDB migration.
+type Migration = xmigrate.Migration[*Client]
+
+// NewIndexMigration returns the migration creating the index of the collection. Creating an index
+// that already exists with the same keys and options does nothing, so the migration is idempotent.
+func NewIndexMigration(id, collection string, keys bson.D, opts *options.IndexOptions) Migration {
+	return indexMigration{id: id, collection: collection, keys: keys, opts: opts}
+}
+
+type indexMigration struct {
+	id         string
+	collection string
+	keys       bson.D
+	opts       *options.IndexOptions
+}
+
+func (m indexMigration) ID() string {
+	return m.id
+}
+
+// Definition returns the collection, the keys, the name and the options of the index that define it,
+// the uniqueness, the sparseness and the TTL, so the checksum does not depend on how the driver
+// serializes the options.
+func (m indexMigration) Definition() string {
+	keys, err := bson.MarshalExtJSON(m.keys, true, false)
+	if err != nil {
+		keys = []byte(fmt.Sprint(m.keys))
+	}
+
+	var (
+		opts = m.opts
+		def  = fmt.Sprintf("createIndex %s %s", m.collection, keys)
+	)
+	if opts == nil {
+		opts = options.Index()
+	}
+	if opts.Name != nil {
+		def += " name=" + *opts.Name
+	}
+	if opts.Unique != nil && *opts.Unique {
+		def += " unique"
+	}
+	if opts.Sparse != nil && *opts.Sparse {
+		def += " sparse"
+	}
+	if opts.ExpireAfterSeconds != nil {
+		def += fmt.Sprintf(" ttl=%ds", *opts.ExpireAfterSeconds)
+	}
+	return def
+}
+
+func (m indexMigration) Up(ctx context.Context, c *Client) error {
+	_, err := c.Collection(m.collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    m.keys,
+		Options: m.opts,
+	})
+	return err
+}
+
+// NewEventIndexMigration returns the migration indexing the date and the parent of the payloads of the
+// events saved before the event_date and parent_id fields existed, see xevent.Dated and xevent.Child.
+// The payloads are decoded with the registry, so the types of every event stored must be registered.
+// The events already indexed are skipped, so the migration is idempotent.
+func NewEventIndexMigration(id string, registry xevent.Registry) Migration {
+	return eventIndexMigration{id: id, registry: registry}
+}
+
+type eventIndexMigration struct {
+	id       string
+	registry xevent.Registry
+}
+
+func (m eventIndexMigration) ID() string {
+	return m.id
+}
+
+// Definition returns the collection and the fields indexed.
+func (m eventIndexMigration) Definition() string {
+	return fmt.Sprintf("index %s event_date parent_id", DefaultCollectionName)
+}
+
+func (m eventIndexMigration) Up(ctx context.Context, c *Client) error {
+	_, err := NewMongoEventStore(c, m.registry).index(ctx, notIndexedCriteria, false)
+	return err
+}
+
+// EventStoreMigrations returns the migrations of the events collection, in the order they are applied.
+// The applied migrations must not be changed, the changes go in new ones appended to the list.
+// The registry decodes the payloads of the events stored, see NewEventIndexMigration.
+func EventStoreMigrations(registry xevent.Registry) []Migration {
+	return []Migration{
+		NewIndexMigration("events/0001_create_aggregate_id_index", DefaultCollectionName,
+			bson.D{{Key: "aggregate_id", Value: 1}}, options.Index().SetUnique(false)),
+		// the versions of an aggregate are unique, so concurrent changes conflict
+		NewIndexMigration("events/0002_create_aggregate_id_version_index", DefaultCollectionName,
+			bson.D{{Key: "aggregate_id", Value: 1}, {Key: "aggregate_version", Value: 1}}, options.Index().SetUnique(true)),
+		NewIndexMigration("events/0003_create_tenant_id_index", DefaultCollectionName,
+			bson.D{{Key: "tenant_id", Value: 1}}, options.Index().SetUnique(false)),
+		NewIndexMigration("events/0004_create_type_index", DefaultCollectionName,
+			bson.D{{Key: "type", Value: 1}}, options.Index().SetUnique(false)),
+		NewIndexMigration("events/0005_create_timestamp_index", DefaultCollectionName,
+			bson.D{{Key: "timestamp", Value: 1}}, options.Index().SetUnique(false)),
+		NewIndexMigration("events/0006_create_parent_id_index", DefaultCollectionName,
+			bson.D{{Key: "parent_id", Value: 1}}, options.Index().SetUnique(false).SetSparse(true)),
+		NewIndexMigration("events/0007_create_event_date_index", DefaultCollectionName,
+			bson.D{{Key: "event_date", Value: 1}}, options.Index().SetUnique(false).SetSparse(true)),
+		NewEventIndexMigration("events/0008_index_event_date_and_parent_id", registry),
+	}
+}
+
+// Migrate applies the pending migrations in order, recording them in the migrations collection.
+// It refuses to run when the migrations applied drifted, see xmigrate.Run.
+func Migrate(ctx context.Context, client *Client, migrations []Migration, opts ...xmigrate.Option) (xmigrate.Plan, error) {
+	return xmigrate.Run(ctx, client, NewMigrationStore(client), migrations, opts...)
+}
+
+var _ xmigrate.Store = (*MigrationStore)(nil)
+
+// MigrationStore records the migrations applied in the migrations collection.
+type MigrationStore struct {
+	client *Client
+}
+
+// NewMigrationStore creates a new instance of MigrationStore.
+func NewMigrationStore(client *Client) *MigrationStore {
+	return &MigrationStore{client: client}
+}
+
+// Init does nothing, as the collection is created with the first record.
+func (s *MigrationStore) Init(context.Context) error {
+	return nil
+}
+
+// Applied returns the records of the migrations applied, in the order they were applied.
+func (s *MigrationStore) Applied(ctx context.Context) ([]xmigrate.Record, error) {
+	cursor, err := s.collection().Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "applied_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []xmigrate.Record
+	for cursor.Next(ctx) {
+		var dto migrationDTO
+		if err = cursor.Decode(&dto); err != nil {
+			return nil, err
+		}
+		records = append(records, xmigrate.Record{ID: dto.ID, Checksum: dto.Checksum, AppliedAt: dto.AppliedAt})
+	}
+	return records, cursor.Err()
+}
+
+// Save records the migration as applied, replacing the record of a previous run.
+func (s *MigrationStore) Save(ctx context.Context, record xmigrate.Record) error {
+	_, err := s.collection().ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: record.ID}},
+		migrationDTO{ID: record.ID, Checksum: record.Checksum, AppliedAt: record.AppliedAt},
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *MigrationStore) collection() *mongo.Collection {
+	return s.client.Collection(MigrationsCollectionName)
+}
+
+// migrationDTO represents the structure of a migration record stored in MongoDB.
+type migrationDTO struct {
+	ID        string    `bson:"_id"`
+	Checksum  string    `bson:"checksum"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
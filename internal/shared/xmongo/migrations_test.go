@@ -0,0 +1,49 @@
+package xmongo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	. "github.com/xfrr/finantrack/internal/shared/xmongo"
+)
+
+func TestIndexMigration_Definition(t *testing.T) {
+	keys := bson.D{{Key: "aggregate_id", Value: 1}, {Key: "aggregate_version", Value: 1}}
+
+	var specs = []struct {
+		name     string
+		opts     *options.IndexOptions
+		expected string
+	}{
+		{
+			name:     "index without options",
+			opts:     nil,
+			expected: `createIndex events {"aggregate_id":{"$numberInt":"1"},"aggregate_version":{"$numberInt":"1"}}`,
+		},
+		{
+			name:     "index not unique is defined as without options",
+			opts:     options.Index().SetUnique(false),
+			expected: `createIndex events {"aggregate_id":{"$numberInt":"1"},"aggregate_version":{"$numberInt":"1"}}`,
+		},
+		{
+			name:     "named unique index",
+			opts:     options.Index().SetName("aggregate_version").SetUnique(true),
+			expected: `createIndex events {"aggregate_id":{"$numberInt":"1"},"aggregate_version":{"$numberInt":"1"}} name=aggregate_version unique`,
+		},
+		{
+			name:     "ttl index",
+			opts:     options.Index().SetExpireAfterSeconds(3600).SetBackground(true),
+			expected: `createIndex events {"aggregate_id":{"$numberInt":"1"},"aggregate_version":{"$numberInt":"1"}} ttl=3600s`,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			sut := NewIndexMigration("events/0001", DefaultCollectionName, keys, spec.opts)
+			assert.Equal(t, spec.expected, sut.Definition())
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package xmongo
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DefaultPageSize is the number of events returned per page when no limit is given.
+const DefaultPageSize = 100
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+// or does not match the requested sort order.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// SortField represents an event field that can be used to sort the results.
+type SortField string
+
+const (
+	// SortByTimestamp sorts the events by their timestamp.
+	SortByTimestamp SortField = "timestamp"
+
+	// SortByAggregateVersion sorts the events by their aggregate version.
+	SortByAggregateVersion SortField = "aggregate_version"
+
+	// SortByAggregateID sorts the events by their aggregate ID.
+	SortByAggregateID SortField = "aggregate_id"
+
+	// SortByType sorts the events by their type.
+	SortByType SortField = "type"
+
+	// sortByID sorts the events by their ID. It is always used as the last
+	// sort key to make the order, and therefore the cursors, deterministic.
+	sortByID SortField = "_id"
+)
+
+// SortOrder represents the direction in which the results are sorted.
+type SortOrder int
+
+const (
+	// Ascending sorts the results from the lowest to the highest value.
+	Ascending SortOrder = 1
+
+	// Descending sorts the results from the highest to the lowest value.
+	Descending SortOrder = -1
+)
+
+// QueryOptions holds the options used to query events from the storage.
+type QueryOptions struct {
+	sort      bson.D
+	limit     int64
+	skip      int64
+	cursor    string
+	batchSize int32
+}
+
+// QueryOption configures the QueryOptions.
+type QueryOption func(*QueryOptions)
+
+// WithSort sorts the results by the given field and order.
+// It can be passed multiple times to sort by several fields.
+func WithSort(field SortField, order SortOrder) QueryOption {
+	return func(o *QueryOptions) {
+		o.sort = append(o.sort, bson.E{Key: string(field), Value: int(order)})
+	}
+}
+
+// WithLimit limits the number of returned events.
+func WithLimit(limit int64) QueryOption {
+	return func(o *QueryOptions) {
+		o.limit = limit
+	}
+}
+
+// WithSkip skips the given number of events.
+func WithSkip(skip int64) QueryOption {
+	return func(o *QueryOptions) {
+		o.skip = skip
+	}
+}
+
+// WithCursor makes GetPage return the events placed after the given cursor.
+// The cursor must come from a Page obtained with the same sort order.
+func WithCursor(cursor string) QueryOption {
+	return func(o *QueryOptions) {
+		o.cursor = cursor
+	}
+}
+
+// WithBatchSize sets the number of events fetched from the server on each round trip
+// while streaming, bounding the memory used by the iteration.
+func WithBatchSize(size int32) QueryOption {
+	return func(o *QueryOptions) {
+		o.batchSize = size
+	}
+}
+
+// Page represents a page of events.
+type Page struct {
+	// Events are the events in the page.
+	Events []Event
+
+	// NextCursor is the cursor to get the next page.
+	// It is empty when there are no more events.
+	NextCursor string
+}
+
+// newQueryOptions applies the given options over the default ones.
+func newQueryOptions(opts ...QueryOption) QueryOptions {
+	var o QueryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// findOptions returns the MongoDB find options for the query.
+func (o QueryOptions) findOptions() *options.FindOptions {
+	fopts := options.Find()
+	if len(o.sort) > 0 {
+		fopts.SetSort(o.sort)
+	}
+	if o.limit > 0 {
+		fopts.SetLimit(o.limit)
+	}
+	if o.skip > 0 {
+		fopts.SetSkip(o.skip)
+	}
+	if o.batchSize > 0 {
+		fopts.SetBatchSize(o.batchSize)
+	}
+	return fopts
+}
+
+// keysetSort returns the sort used for cursor pagination.
+// It defaults to the timestamp when no sort was given and always
+// ends with the event ID as tie-breaker.
+func (o QueryOptions) keysetSort() bson.D {
+	sort := o.sort
+	if len(sort) == 0 {
+		sort = bson.D{{Key: string(SortByTimestamp), Value: int(Ascending)}}
+	}
+
+	for _, e := range sort {
+		if e.Key == string(sortByID) {
+			return sort
+		}
+	}
+
+	last := sort[len(sort)-1]
+	return append(sort[:len(sort):len(sort)], bson.E{Key: string(sortByID), Value: last.Value})
+}
+
+// cursorFilter returns the filter that selects the documents placed
+// after the given key values according to the sort.
+func cursorFilter(sort bson.D, values bson.A) bson.D {
+	or := make(bson.A, 0, len(sort))
+	for i, e := range sort {
+		op := "$gt"
+		if e.Value == int(Descending) {
+			op = "$lt"
+		}
+
+		and := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			and = append(and, bson.E{Key: sort[j].Key, Value: values[j]})
+		}
+		and = append(and, bson.E{Key: e.Key, Value: bson.D{{Key: op, Value: values[i]}}})
+
+		or = append(or, and)
+	}
+	return bson.D{{Key: "$or", Value: or}}
+}
+
+// encodeCursor encodes the sort key values of a document as an opaque cursor.
+func encodeCursor(values bson.A) (string, error) {
+	raw, err := bson.Marshal(bson.D{{Key: "k", Value: values}})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor decodes the sort key values encoded in the cursor.
+func decodeCursor(cursor string, keys int) (bson.A, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var doc struct {
+		K bson.A `bson:"k"`
+	}
+	if err = bson.Unmarshal(raw, &doc); err != nil || len(doc.K) != keys {
+		return nil, ErrInvalidCursor
+	}
+	return doc.K, nil
+}
@@ -0,0 +1,67 @@
+package xmongo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+)
+
+// tracerName is the name of the tracer of the package.
+const tracerName = "github.com/xfrr/finantrack/internal/shared/xmongo"
+
+var tracer = xtracing.Tracer(tracerName)
+
+// newCommandMonitor returns a monitor tracing every command sent to MongoDB as a client span,
+// a child of the span of the operation that sent it. Failed commands are recorded as errors.
+// The commands sent outside of a trace, such as the pings of the health checks, are not traced.
+func newCommandMonitor() *event.CommandMonitor {
+	var spans sync.Map
+
+	key := func(connectionID string, requestID int64) string {
+		return connectionID + "/" + strconv.FormatInt(requestID, 10)
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				return
+			}
+
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", evt.DatabaseName),
+				attribute.String("db.operation", evt.CommandName),
+			}
+
+			name := evt.CommandName
+			if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+				name = collection + "." + evt.CommandName
+				attrs = append(attrs, attribute.String("db.mongodb.collection", collection))
+			}
+
+			_, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			spans.Store(key(evt.ConnectionID, evt.RequestID), span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			if span, ok := spans.LoadAndDelete(key(evt.ConnectionID, evt.RequestID)); ok {
+				span.(trace.Span).End()
+			}
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			if span, ok := spans.LoadAndDelete(key(evt.ConnectionID, evt.RequestID)); ok {
+				err := errors.New(evt.Failure)
+				xtracing.End(span.(trace.Span), &err)
+			}
+		},
+	}
+}
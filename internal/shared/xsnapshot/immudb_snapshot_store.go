@@ -0,0 +1,108 @@
+package xsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+)
+
+// DefaultSnapshotsTableName is the default table name for snapshots.
+const DefaultSnapshotsTableName = "snapshots"
+
+var _ Store = (*ImmuSnapshotStore)(nil)
+
+// ImmuSnapshotStore is the immudb implementation of Store.
+// The snapshots table must be created beforehand with the CreateSnapshotsTable migration.
+type ImmuSnapshotStore struct {
+	db *sql.DB
+}
+
+// NewImmuSnapshotStore creates a new instance of ImmuSnapshotStore.
+func NewImmuSnapshotStore(db *sql.DB) *ImmuSnapshotStore {
+	return &ImmuSnapshotStore{
+		db: db,
+	}
+}
+
+// Save saves the snapshot, replacing the previous snapshot of the aggregate.
+// Previous snapshots are kept in the immudb history.
+func (s *ImmuSnapshotStore) Save(ctx context.Context, snapshot Snapshot) error {
+	ctx, cancel := context.WithTimeout(ctx, ximmudb.DefaultTimeout)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		UPSERT INTO %s (aggregate_id, aggregate_type, version, tenant_id, state, created_at)
+		VALUES (?, ?, ?, ?, ?, ?);`,
+		DefaultSnapshotsTableName,
+	),
+		snapshot.AggregateID,
+		snapshot.AggregateType,
+		snapshot.Version,
+		snapshot.TenantID,
+		string(snapshot.State),
+		snapshot.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the snapshot of the aggregate, ErrSnapshotNotFound when it has none.
+func (s *ImmuSnapshotStore) Get(ctx context.Context, aggregateID string) (Snapshot, error) {
+	var (
+		snapshot Snapshot
+		tenantID sql.NullString
+		state    sql.NullString
+	)
+
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT aggregate_id, aggregate_type, version, tenant_id, state, created_at
+		FROM %s WHERE aggregate_id = ? LIMIT 1`,
+		DefaultSnapshotsTableName,
+	), aggregateID).Scan(
+		&snapshot.AggregateID,
+		&snapshot.AggregateType,
+		&snapshot.Version,
+		&tenantID,
+		&state,
+		&snapshot.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Snapshot{}, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	snapshot.TenantID = tenantID.String
+	snapshot.State = []byte(state.String)
+	return snapshot, nil
+}
+
+// ImmuSnapshotStoreMigrations returns the migrations of the snapshots table, in the order they are applied.
+func ImmuSnapshotStoreMigrations() []ximmudb.Migration {
+	return []ximmudb.Migration{
+		NewCreateSnapshotsTable(),
+	}
+}
+
+// NewCreateSnapshotsTable creates the migration creating the snapshots table.
+func NewCreateSnapshotsTable() ximmudb.Migration {
+	return ximmudb.NewMigration("snapshots/0001_create_snapshots_table",
+		fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				aggregate_id VARCHAR[100],
+				aggregate_type VARCHAR[100],
+				version INTEGER,
+				tenant_id VARCHAR[100],
+				state VARCHAR[4096],
+				created_at TIMESTAMP,
+				PRIMARY KEY aggregate_id
+			);`, DefaultSnapshotsTableName),
+	)
+}
@@ -0,0 +1,90 @@
+package xsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+)
+
+// DefaultSnapshotsCollectionName is the default collection name for snapshots.
+const DefaultSnapshotsCollectionName = "snapshots"
+
+var _ Store = (*MongoSnapshotStore)(nil)
+
+// MongoSnapshotStore is the MongoDB implementation of Store.
+// The snapshots are identified by their aggregate, so the collection needs no index.
+type MongoSnapshotStore struct {
+	client *xmongo.Client
+}
+
+// NewMongoSnapshotStore creates a new instance of MongoSnapshotStore.
+func NewMongoSnapshotStore(client *xmongo.Client) *MongoSnapshotStore {
+	return &MongoSnapshotStore{
+		client: client,
+	}
+}
+
+// Save saves the snapshot, replacing the previous snapshot of the aggregate.
+func (s *MongoSnapshotStore) Save(ctx context.Context, snapshot Snapshot) error {
+	dto := snapshotDTO{
+		AggregateID:   snapshot.AggregateID,
+		AggregateType: snapshot.AggregateType,
+		Version:       snapshot.Version,
+		TenantID:      snapshot.TenantID,
+		State:         string(snapshot.State),
+		CreatedAt:     snapshot.CreatedAt,
+	}
+
+	_, err := s.collection().ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: snapshot.AggregateID}},
+		dto,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the snapshot of the aggregate, ErrSnapshotNotFound when it has none.
+func (s *MongoSnapshotStore) Get(ctx context.Context, aggregateID string) (Snapshot, error) {
+	var dto snapshotDTO
+	err := s.collection().FindOne(ctx, bson.D{{Key: "_id", Value: aggregateID}}).Decode(&dto)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Snapshot{}, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	return Snapshot{
+		AggregateID:   dto.AggregateID,
+		AggregateType: dto.AggregateType,
+		Version:       dto.Version,
+		TenantID:      dto.TenantID,
+		State:         []byte(dto.State),
+		CreatedAt:     dto.CreatedAt,
+	}, nil
+}
+
+func (s *MongoSnapshotStore) collection() *mongo.Collection {
+	return s.client.Collection(DefaultSnapshotsCollectionName)
+}
+
+// snapshotDTO represents the structure of a snapshot stored in MongoDB.
+type snapshotDTO struct {
+	AggregateID   string    `bson:"_id"`
+	AggregateType string    `bson:"aggregate_type"`
+	Version       int       `bson:"version"`
+	TenantID      string    `bson:"tenant_id,omitempty"`
+	State         string    `bson:"state"`
+	CreatedAt     time.Time `bson:"created_at"`
+}
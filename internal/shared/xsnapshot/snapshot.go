@@ -0,0 +1,61 @@
+// Package xsnapshot stores the snapshots of the aggregates: their state at a version of their events,
+// so they are restored from it and the events after it instead of replaying all their events.
+// The snapshots are derived from the events, so they can be rebuilt from them at any time.
+//
+// The database engines implement the Store: MongoSnapshotStore and ImmuSnapshotStore.
+package xsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSnapshotNotFound is returned when the aggregate has no snapshot.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// Snapshot is the state of an aggregate after the event of the given version.
+type Snapshot struct {
+	AggregateID   string
+	AggregateType string
+	Version       int
+	TenantID      string
+	// State is the JSON encoded state of the aggregate.
+	State     []byte
+	CreatedAt time.Time
+}
+
+// New returns the snapshot of the aggregate with the given state, encoded as JSON.
+func New(aggregateID, aggregateType string, version int, tenantID string, state any) (Snapshot, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to marshal the state of aggregate %s: %w", aggregateID, err)
+	}
+
+	return Snapshot{
+		AggregateID:   aggregateID,
+		AggregateType: aggregateType,
+		Version:       version,
+		TenantID:      tenantID,
+		State:         data,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// Decode decodes the state of the snapshot into the value pointed by state.
+func (s Snapshot) Decode(state any) error {
+	if err := json.Unmarshal(s.State, state); err != nil {
+		return fmt.Errorf("failed to unmarshal the state of aggregate %s: %w", s.AggregateID, err)
+	}
+	return nil
+}
+
+// Store defines the interface for saving and retrieving the snapshots of the aggregates.
+type Store interface {
+	// Save saves the snapshot, replacing the previous snapshot of the aggregate.
+	Save(ctx context.Context, snapshot Snapshot) error
+	// Get returns the snapshot of the aggregate, ErrSnapshotNotFound when it has none.
+	Get(ctx context.Context, aggregateID string) (Snapshot, error)
+}
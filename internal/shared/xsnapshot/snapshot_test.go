@@ -0,0 +1,32 @@
+package xsnapshot_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xsnapshot"
+)
+
+func TestSnapshot(t *testing.T) {
+	type state struct {
+		Name   string  `json:"name"`
+		Amount float64 `json:"amount"`
+	}
+
+	sut, err := New("aggregate-id", "asset", 3, "household", state{Name: "Savings", Amount: 42.5})
+	require.NoError(t, err)
+	assert.Equal(t, "aggregate-id", sut.AggregateID)
+	assert.Equal(t, "asset", sut.AggregateType)
+	assert.Equal(t, 3, sut.Version)
+	assert.Equal(t, "household", sut.TenantID)
+	assert.False(t, sut.CreatedAt.IsZero())
+
+	var decoded state
+	require.NoError(t, sut.Decode(&decoded))
+	assert.Equal(t, state{Name: "Savings", Amount: 42.5}, decoded)
+
+	sut.State = []byte("{")
+	assert.Error(t, sut.Decode(&decoded))
+}
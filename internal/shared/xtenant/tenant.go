@@ -0,0 +1,27 @@
+package xtenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTenantRequired represents the error when an operation
+// that must be scoped to a tenant is performed without one.
+var ErrTenantRequired = errors.New("tenant is required")
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of the context scoped to the given tenant.
+// Repositories use it to scope every read and write, and event stores
+// to persist the tenant along with the events.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// FromContext returns the tenant the context is scoped to, if any.
+// A context without tenant grants system wide access and must only
+// be used by trusted callers, such as the administration tools.
+func FromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
@@ -2,49 +2,141 @@ package xtracing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
-func NewOtelTracerProvider(ctx context.Context, serviceName, collectorURL string) (trace.Tracer, func(), error) {
-	var closer func()
+// Exporter is the destination of the spans.
+type Exporter string
+
+const (
+	// ExporterOTLPGRPC sends the spans to an OpenTelemetry collector over gRPC.
+	ExporterOTLPGRPC Exporter = "otlp-grpc"
+
+	// ExporterOTLPHTTP sends the spans to an OpenTelemetry collector over HTTP.
+	ExporterOTLPHTTP Exporter = "otlp-http"
+
+	// ExporterStdout writes the spans to the standard output, for debugging.
+	ExporterStdout Exporter = "stdout"
+
+	// ExporterNone disables tracing.
+	ExporterNone Exporter = "none"
+)
+
+// ErrUnsupportedExporter is returned when the exporter of the configuration is unknown.
+var ErrUnsupportedExporter = errors.New("unsupported tracing exporter")
+
+// ParseExporter returns the exporter with the given name, case insensitive.
+// An empty name is ExporterNone.
+func ParseExporter(name string) (Exporter, error) {
+	if name == "" {
+		return ExporterNone, nil
+	}
+
+	for _, e := range []Exporter{ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterStdout, ExporterNone} {
+		if strings.EqualFold(name, string(e)) {
+			return e, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedExporter, name)
+}
+
+// Config is the configuration of the tracer provider.
+type Config struct {
+	// Exporter is the destination of the spans, ExporterNone when empty.
+	Exporter Exporter
+
+	// Endpoint is the host and port of the collector of the OTLP exporters,
+	// localhost:4317 for gRPC and localhost:4318 for HTTP when empty.
+	Endpoint string
+
+	// SampleRatio is the ratio of the traces started by the service that are sampled,
+	// from 0 to 1. The traces started by the callers are sampled as they decided.
+	SampleRatio float64
+
+	// ResourceAttributes are added to the attributes describing the service,
+	// e.g. deployment.environment.
+	ResourceAttributes map[string]string
+}
+
+// Validate checks the exporter and the sample ratio of the configuration.
+func (c Config) Validate() error {
+	if _, err := ParseExporter(string(c.Exporter)); err != nil {
+		return err
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1, got %v", c.SampleRatio)
+	}
+	return nil
+}
+
+// ParseResourceAttributes parses a comma separated list of key=value pairs,
+// the format of OTEL_RESOURCE_ATTRIBUTES.
+func ParseResourceAttributes(s string) (map[string]string, error) {
+	attrs := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid resource attribute %q, must be key=value", pair)
+		}
+		attrs[key] = strings.TrimSpace(value)
+	}
+	return attrs, nil
+}
+
+// NewOtelTracerProvider sets up the global tracer provider with the exporter of the configuration
+// and returns the tracer of the service along with the function flushing and stopping the provider.
+// With ExporterNone, the tracer and the stop function do nothing, so they are always safe to use.
+func NewOtelTracerProvider(ctx context.Context, serviceName string, cfg Config) (trace.Tracer, func() error, error) {
+	if err := cfg.Validate(); err != nil {
+		return NoopTracer(), noopStop, err
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil || exporter == nil {
+		return NoopTracer(), noopStop, err
+	}
+
+	attrs := []attribute.KeyValue{
+		// the service name used to display traces in backends
+		semconv.ServiceNameKey.String(serviceName),
+	}
+	for k, v := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
 
 	resources, err := resource.New(ctx,
 		resource.WithFromEnv(),
 		resource.WithProcess(),
 		resource.WithTelemetrySDK(),
 		resource.WithHost(),
-		resource.WithAttributes(
-			// the service name used to display traces in backends
-			semconv.ServiceNameKey.String(serviceName),
-		),
-	)
-	if err != nil {
-		return nil, closer, err
-	}
-
-	traceClient := otlptracegrpc.NewClient(
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint(collectorURL))
-
-	exporter, err := otlptrace.New(
-		context.Background(),
-		traceClient,
+		resource.WithAttributes(attrs...),
 	)
 	if err != nil {
-		return nil, closer, err
+		return NoopTracer(), noopStop, errors.Join(err, exporter.Shutdown(ctx))
 	}
 
 	tracerProvider := sdktrace.NewTracerProvider(
-		// sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(resources),
 	)
@@ -52,12 +144,48 @@ func NewOtelTracerProvider(ctx context.Context, serviceName, collectorURL string
 	otel.SetTracerProvider(tracerProvider)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-	closer = func() {
-		err = tracerProvider.Shutdown(ctx)
-		if err != nil {
-			panic(err)
-		}
+	stop := func() error {
+		// the context of the service is usually done by the time it stops
+		return tracerProvider.Shutdown(context.WithoutCancel(ctx))
 	}
 
-	return tracerProvider.Tracer(serviceName), closer, nil
+	return tracerProvider.Tracer(serviceName), stop, nil
+}
+
+// NoopTracer returns a tracer whose spans do nothing.
+func NoopTracer() trace.Tracer {
+	return noop.NewTracerProvider().Tracer("")
+}
+
+func noopStop() error {
+	return nil
+}
+
+// newExporter creates the exporter of the configuration, nil with ExporterNone.
+// The OTLP exporters connect lazily, so a missing collector does not fail here.
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	// the name of the exporter is case insensitive, e.g. OTLP-GRPC
+	exporter, err := ParseExporter(string(cfg.Exporter))
+	if err != nil {
+		return nil, err
+	}
+
+	switch exporter {
+	case ExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithInsecure()}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithInsecure()}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	case ExporterStdout:
+		return stdouttrace.New()
+	default:
+		return nil, nil
+	}
 }
@@ -0,0 +1,109 @@
+package xtracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/xfrr/finantrack/internal/shared/xtracing"
+)
+
+func TestParseExporter(t *testing.T) {
+	var specs = []struct {
+		name     string
+		expected Exporter
+		wantErr  bool
+	}{
+		{name: "", expected: ExporterNone},
+		{name: "none", expected: ExporterNone},
+		{name: "OTLP-GRPC", expected: ExporterOTLPGRPC},
+		{name: "otlp-http", expected: ExporterOTLPHTTP},
+		{name: "stdout", expected: ExporterStdout},
+		{name: "jaeger", wantErr: true},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			exporter, err := ParseExporter(spec.name)
+			if spec.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedExporter)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expected, exporter)
+		})
+	}
+}
+
+func TestParseResourceAttributes(t *testing.T) {
+	attrs, err := ParseResourceAttributes("deployment.environment=production, service.version = 1.2.0,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"deployment.environment": "production",
+		"service.version":        "1.2.0",
+	}, attrs)
+
+	attrs, err = ParseResourceAttributes("")
+	require.NoError(t, err)
+	assert.Empty(t, attrs)
+
+	_, err = ParseResourceAttributes("production")
+	assert.Error(t, err)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{}.Validate())
+	assert.NoError(t, Config{Exporter: ExporterStdout, SampleRatio: 0.25}.Validate())
+	assert.ErrorIs(t, Config{Exporter: "jaeger"}.Validate(), ErrUnsupportedExporter)
+	assert.Error(t, Config{SampleRatio: 1.5}.Validate())
+}
+
+func TestNewOtelTracerProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("none", func(t *testing.T) {
+		tracer, stop, err := NewOtelTracerProvider(ctx, "test", Config{Exporter: ExporterNone})
+		require.NoError(t, err)
+
+		_, span := tracer.Start(ctx, "noop")
+		span.End()
+		assert.False(t, span.SpanContext().IsValid())
+		assert.NoError(t, stop())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tracer, stop, err := NewOtelTracerProvider(ctx, "test", Config{Exporter: "jaeger"})
+		assert.ErrorIs(t, err, ErrUnsupportedExporter)
+
+		// the tracer and the stop function are still safe to use
+		_, span := tracer.Start(ctx, "noop")
+		span.End()
+		assert.NoError(t, stop())
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		tracer, stop, err := NewOtelTracerProvider(ctx, "test", Config{
+			Exporter:           ExporterStdout,
+			SampleRatio:        1,
+			ResourceAttributes: map[string]string{"deployment.environment": "test"},
+		})
+		require.NoError(t, err)
+
+		_, span := tracer.Start(ctx, "sampled")
+		assert.True(t, span.SpanContext().IsSampled())
+		span.End()
+		assert.NoError(t, stop())
+	})
+
+	t.Run("exporter name is case insensitive", func(t *testing.T) {
+		tracer, stop, err := NewOtelTracerProvider(ctx, "test", Config{Exporter: "STDOUT", SampleRatio: 1})
+		require.NoError(t, err)
+
+		_, span := tracer.Start(ctx, "sampled")
+		assert.True(t, span.SpanContext().IsValid())
+		span.End()
+		assert.NoError(t, stop())
+	})
+}
@@ -0,0 +1,94 @@
+package xtracing
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes of the spans of the repositories and event stores.
+const (
+	AggregateIDKey      = attribute.Key("aggregate.id")
+	AggregateTypeKey    = attribute.Key("aggregate.type")
+	AggregateVersionKey = attribute.Key("aggregate.version")
+	EventCountKey       = attribute.Key("event.count")
+)
+
+// Tracer returns the tracer of an instrumented package from the global provider,
+// which does nothing until a provider is set with NewOtelTracerProvider.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// ChangesAttributes returns the attributes of a batch of changes: their number and the id,
+// type and resulting version of their aggregate, taken from the last change.
+func ChangesAttributes(changes []aggregate.Change) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{EventCountKey.Int(len(changes))}
+	if len(changes) == 0 {
+		return attrs
+	}
+
+	ref := changes[len(changes)-1].Aggregate()
+	if ref == nil {
+		return attrs
+	}
+
+	return append(attrs,
+		AggregateIDKey.String(fmt.Sprint(ref.ID)),
+		AggregateTypeKey.String(ref.Name),
+		AggregateVersionKey.Int(ref.Version),
+	)
+}
+
+// End records the error of the operation on the span, if any, and ends it.
+// It is meant to be deferred with a pointer to the named error of the operation.
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// EventStream traces the iteration of a stream of events as a span started when the iteration
+// begins and ended when it stops, with the number of events yielded and the error, if any.
+// The stream is created with the context of the span, so the database calls made while
+// iterating are its children.
+func EventStream(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name string,
+	stream func(ctx context.Context) iter.Seq2[aggregate.Change, error],
+	attrs ...attribute.KeyValue,
+) iter.Seq2[aggregate.Change, error] {
+	return func(yield func(aggregate.Change, error) bool) {
+		ctx, span := tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+
+		var (
+			count int
+			err   error
+		)
+		defer func() {
+			span.SetAttributes(EventCountKey.Int(count))
+			End(span, &err)
+		}()
+
+		for e, serr := range stream(ctx) {
+			if serr != nil {
+				err = serr
+			} else {
+				count++
+			}
+
+			if !yield(e, serr) {
+				return
+			}
+		}
+	}
+}
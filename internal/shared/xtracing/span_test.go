@@ -0,0 +1,119 @@
+package xtracing_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xfrr/go-cqrsify/aggregate"
+	"github.com/xfrr/go-cqrsify/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	. "github.com/xfrr/finantrack/internal/shared/xtracing"
+)
+
+func newChange(aggregateID uuid.UUID, version int) aggregate.Change {
+	return event.New[any, any](uuid.New(), "asset.created", nil,
+		event.WithAggregate(aggregateID, "asset", version),
+	)
+}
+
+func newRecorder() (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	return recorder, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+}
+
+func TestChangesAttributes(t *testing.T) {
+	id := uuid.New()
+
+	assert.Equal(t, []attribute.KeyValue{EventCountKey.Int(0)}, ChangesAttributes(nil))
+	assert.Equal(t, []attribute.KeyValue{
+		EventCountKey.Int(2),
+		AggregateIDKey.String(id.String()),
+		AggregateTypeKey.String("asset"),
+		AggregateVersionKey.Int(2),
+	}, ChangesAttributes([]aggregate.Change{newChange(id, 1), newChange(id, 2)}))
+}
+
+func TestEnd(t *testing.T) {
+	recorder, provider := newRecorder()
+	tracer := provider.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	var err error
+	End(span, &err)
+
+	_, span = tracer.Start(context.Background(), "failed")
+	err = errors.New("boom")
+	End(span, &err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "boom", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "exception", spans[1].Events()[0].Name)
+}
+
+func TestEventStream(t *testing.T) {
+	recorder, provider := newRecorder()
+	tracer := provider.Tracer("test")
+	id := uuid.New()
+
+	stream := func(failure error) func(context.Context) iter.Seq2[aggregate.Change, error] {
+		return func(ctx context.Context) iter.Seq2[aggregate.Change, error] {
+			return func(yield func(aggregate.Change, error) bool) {
+				// the stream runs within the span
+				_, child := tracer.Start(ctx, "query")
+				child.End()
+
+				for v := 1; v <= 3; v++ {
+					if !yield(newChange(id, v), nil) {
+						return
+					}
+				}
+				if failure != nil {
+					yield(nil, failure)
+				}
+			}
+		}
+	}
+
+	seq := EventStream(context.Background(), tracer, "stream", stream(nil))
+	assert.Empty(t, recorder.Started(), "the span starts with the iteration")
+
+	var count int
+	for _, err := range seq {
+		require.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 3, count)
+
+	for _, err := range EventStream(context.Background(), tracer, "failed", stream(errors.New("boom"))) {
+		if err != nil {
+			break
+		}
+	}
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+
+	query, ok := spans[0], spans[1]
+	assert.Equal(t, "stream", ok.Name())
+	assert.Equal(t, ok.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Contains(t, ok.Attributes(), EventCountKey.Int(3))
+	assert.Equal(t, codes.Unset, ok.Status().Code)
+
+	failed := spans[3]
+	assert.Equal(t, "failed", failed.Name())
+	assert.Contains(t, failed.Attributes(), EventCountKey.Int(3))
+	assert.Equal(t, codes.Error, failed.Status().Code)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/xfrr/go-cqrsify/cqrs"
 
 	assetscommands "github.com/xfrr/finantrack/internal/contexts/assets/commands"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
 )
 
 const CreateAssetPath = "/assets/:id"
@@ -29,14 +30,23 @@ func (h *CreateAssetHandler) Path() string {
 // @Accept			json
 // @Produce		json
 // @Success		200	{object}	string
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		409	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
 // @Router			/assets/{id} [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
 // @Param			id		path	string				true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
 // @Param			body	body	CreateAssetRequest	true	"Asset data"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
 func (h *CreateAssetHandler) Handle(c *gin.Context) {
 	var req CreateAssetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		c.AbortWithError(http.StatusBadRequest, err)
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
 		return
 	}
 
@@ -47,10 +57,10 @@ func (h *CreateAssetHandler) Handle(c *gin.Context) {
 		AssetType:          req.AssetType,
 		AssetMoneyAmount:   req.AssetMoneyAmount,
 		AssetMoneyCurrency: req.AssetMoneyCurrency,
+		AssetIBAN:          req.IBAN,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		c.AbortWithError(http.StatusInternalServerError, err)
+		xhttp.AbortWithError(c, err)
 		return
 	}
 
@@ -68,4 +78,5 @@ type CreateAssetRequest struct {
 	AssetType          string  `json:"assetType" example:"cash"`
 	AssetMoneyAmount   float64 `json:"assetMoneyAmount" example:"1000.00"`
 	AssetMoneyCurrency string  `json:"assetMoneyCurrency" example:"USD"`
+	IBAN               string  `json:"iban,omitempty" example:"DE89370400440532013000"`
 }
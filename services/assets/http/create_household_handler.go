@@ -0,0 +1,72 @@
+package assetshttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	householdscommands "github.com/xfrr/finantrack/internal/contexts/households/commands"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const CreateHouseholdPath = "/households/:id"
+
+type CreateHouseholdHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *CreateHouseholdHandler) Method() string {
+	return "POST"
+}
+
+func (h *CreateHouseholdHandler) Path() string {
+	return CreateHouseholdPath
+}
+
+func NewCreateHouseholdHandler(cmdbus cqrs.Bus) *CreateHouseholdHandler {
+	return &CreateHouseholdHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Create a new household
+// @Description	Create a new household owned by the authenticated user
+// @Tags			households
+// @Accept			json
+// @Produce		json
+// @Success		201	{object}	string
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		409	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/households/{id} [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path	string					true	"Household ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			body	body	CreateHouseholdRequest	true	"Household data"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *CreateHouseholdHandler) Handle(c *gin.Context) {
+	var req CreateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	// dispatch command to create household
+	_, err := cqrs.Dispatch(c.Request.Context(), h.bus, householdscommands.CreateHouseholdCommand{
+		HouseholdID:   c.Param("id"),
+		HouseholdName: req.HouseholdName,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Household created"})
+}
+
+type CreateHouseholdRequest struct {
+	HouseholdName string `json:"householdName" example:"Home"`
+}
@@ -1,12 +1,14 @@
 package assetshttp
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/xfrr/go-cqrsify/cqrs"
 
 	assetscommands "github.com/xfrr/finantrack/internal/contexts/assets/commands"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
 )
 
 const DeleteAssetPath = "/assets/:id"
@@ -35,12 +37,21 @@ func NewDeleteAssetHandler(cmdbus cqrs.Bus) *DeleteAssetHandler {
 // @Accept			json
 // @Produce		json
 // @Success		200	{object}	string
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
 // @Router			/assets/{id} [delete]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
 // @Param			id	path	string	true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
 func (h *DeleteAssetHandler) Handle(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "missing asset ID"})
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(errors.New("missing asset ID")))
 		return
 	}
 
@@ -49,7 +60,7 @@ func (h *DeleteAssetHandler) Handle(c *gin.Context) {
 		AssetID: id,
 	})
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		xhttp.AbortWithError(c, err)
 		return
 	}
 
@@ -0,0 +1,113 @@
+package assetshttp
+
+import (
+	"net/http"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	transactions "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+)
+
+// Stable problem codes returned by the assets API.
+const (
+	CodeInvalidAssetID            = "invalid_asset_id"
+	CodeAssetNotFound             = "asset_not_found"
+	CodeAssetAlreadyExists        = "asset_already_exists"
+	CodeAssetNameRequired         = "asset_name_required"
+	CodeAssetTypeRequired         = "asset_type_required"
+	CodeInvalidAssetType          = "invalid_asset_type"
+	CodeInvalidAssetMoney         = "invalid_asset_money"
+	CodeNegativeMoneyAmount       = "negative_money_amount"
+	CodeUnsupportedCurrency       = "unsupported_currency"
+	CodeAssetCurrencyMismatch     = "asset_currency_mismatch"
+	CodeInvalidIBAN               = "invalid_iban"
+	CodeAssetAccountAlreadyLinked = "asset_account_already_linked"
+)
+
+// Stable problem codes returned by the households API.
+const (
+	CodeHouseholdRequired       = "household_required"
+	CodeInvalidHouseholdID      = "invalid_household_id"
+	CodeHouseholdNotFound       = "household_not_found"
+	CodeHouseholdAlreadyExists  = "household_already_exists"
+	CodeHouseholdNameRequired   = "household_name_required"
+	CodeHouseholdOwnerRequired  = "household_owner_required"
+	CodeHouseholdMemberNotFound = "household_member_not_found"
+	CodeHouseholdLastOwner      = "household_last_owner"
+	CodeInvalidHouseholdRole    = "invalid_household_role"
+	CodeHouseholdForbidden      = "household_forbidden"
+)
+
+// Stable problem codes returned by the transactions API.
+const (
+	CodeInvalidStatement            = "invalid_statement"
+	CodeUnknownCSVProfile           = "unknown_csv_profile"
+	CodeStatementAccountNotLinked   = "statement_account_not_linked"
+	CodeStatementAccountMismatch    = "statement_account_mismatch"
+	CodeTransactionNotFound         = "transaction_not_found"
+	CodeTransactionDateRequired     = "transaction_date_required"
+	CodeTransactionCurrencyRequired = "transaction_currency_required"
+	CodeTransactionCurrencyMismatch = "transaction_currency_mismatch"
+	CodeTransactionSplitsMismatch   = "transaction_splits_mismatch"
+	CodeCategoryNameRequired        = "category_name_required"
+	CodeInvalidCategoryKind         = "invalid_category_kind"
+	CodeCategoryNotFound            = "category_not_found"
+)
+
+// Stable problem codes returned by the reports API.
+const (
+	CodeInvalidReportGranularity = "invalid_report_granularity"
+	CodeInvalidReportPeriod      = "invalid_report_period"
+)
+
+// errorMappings maps the assets, households and transactions domain errors to their HTTP status and problem code.
+var errorMappings = []xhttp.ErrorMapping{
+	xhttp.MapError(assets.ErrInvalidAssetID, http.StatusBadRequest, CodeInvalidAssetID),
+	xhttp.MapError(assets.ErrAssetNotFound, http.StatusNotFound, CodeAssetNotFound),
+	xhttp.MapError(assets.ErrAssetAlreadyExists, http.StatusConflict, CodeAssetAlreadyExists),
+	xhttp.MapError(assets.ErrAssetNameIsRequired, http.StatusUnprocessableEntity, CodeAssetNameRequired),
+	xhttp.MapError(assets.ErrAssetTypeIsRequired, http.StatusUnprocessableEntity, CodeAssetTypeRequired),
+	xhttp.MapError(assets.ErrInvalidAssetType, http.StatusUnprocessableEntity, CodeInvalidAssetType),
+	xhttp.MapError(assets.ErrAssetMoneyIsInvalid, http.StatusUnprocessableEntity, CodeInvalidAssetMoney),
+	xhttp.MapError(assets.ErrMoneyAmountCannotBeNegative, http.StatusUnprocessableEntity, CodeNegativeMoneyAmount),
+	xhttp.MapError(assets.ErrUnsupportedCurrency, http.StatusUnprocessableEntity, CodeUnsupportedCurrency),
+	// an asset of another household is reported as missing, not to disclose it exists
+	xhttp.MapError(assets.ErrAssetTenantMismatch, http.StatusNotFound, CodeAssetNotFound),
+	xhttp.MapError(assets.ErrAssetTenantRequired, http.StatusBadRequest, CodeHouseholdRequired),
+	xhttp.MapError(assets.ErrAssetCurrencyMismatch, http.StatusUnprocessableEntity, CodeAssetCurrencyMismatch),
+	xhttp.MapError(assets.ErrInvalidIBAN, http.StatusUnprocessableEntity, CodeInvalidIBAN),
+	xhttp.MapError(assets.ErrAssetAccountAlreadyLinked, http.StatusConflict, CodeAssetAccountAlreadyLinked),
+
+	xhttp.MapError(xtenant.ErrTenantRequired, http.StatusBadRequest, CodeHouseholdRequired),
+	xhttp.MapError(households.ErrInvalidHouseholdID, http.StatusBadRequest, CodeInvalidHouseholdID),
+	xhttp.MapError(households.ErrHouseholdNotFound, http.StatusNotFound, CodeHouseholdNotFound),
+	xhttp.MapError(households.ErrHouseholdAlreadyExists, http.StatusConflict, CodeHouseholdAlreadyExists),
+	xhttp.MapError(households.ErrHouseholdNameIsRequired, http.StatusUnprocessableEntity, CodeHouseholdNameRequired),
+	xhttp.MapError(households.ErrHouseholdOwnerIsRequired, http.StatusUnprocessableEntity, CodeHouseholdOwnerRequired),
+	xhttp.MapError(households.ErrMemberNotFound, http.StatusNotFound, CodeHouseholdMemberNotFound),
+	xhttp.MapError(households.ErrLastOwner, http.StatusConflict, CodeHouseholdLastOwner),
+	xhttp.MapError(households.ErrInvalidRole, http.StatusUnprocessableEntity, CodeInvalidHouseholdRole),
+	xhttp.MapError(households.ErrForbidden, http.StatusForbidden, CodeHouseholdForbidden),
+
+	xhttp.MapError(statements.ErrUnknownCSVProfile, http.StatusUnprocessableEntity, CodeUnknownCSVProfile),
+	xhttp.MapError(statements.ErrInvalidStatement, http.StatusUnprocessableEntity, CodeInvalidStatement),
+	xhttp.MapError(transactions.ErrStatementAccountNotLinked, http.StatusUnprocessableEntity, CodeStatementAccountNotLinked),
+	xhttp.MapError(transactions.ErrStatementAccountMismatch, http.StatusUnprocessableEntity, CodeStatementAccountMismatch),
+	xhttp.MapError(transactions.ErrTransactionNotFound, http.StatusNotFound, CodeTransactionNotFound),
+	xhttp.MapError(transactions.ErrTransactionDateIsRequired, http.StatusUnprocessableEntity, CodeTransactionDateRequired),
+	xhttp.MapError(transactions.ErrTransactionCurrencyIsRequired, http.StatusUnprocessableEntity, CodeTransactionCurrencyRequired),
+	xhttp.MapError(transactions.ErrTransactionCurrencyMismatch, http.StatusUnprocessableEntity, CodeTransactionCurrencyMismatch),
+	xhttp.MapError(transactions.ErrTransactionTenantMismatch, http.StatusNotFound, CodeTransactionNotFound),
+	xhttp.MapError(transactions.ErrTransactionSplitsMismatch, http.StatusUnprocessableEntity, CodeTransactionSplitsMismatch),
+	xhttp.MapError(transactions.ErrCategoryNameIsRequired, http.StatusUnprocessableEntity, CodeCategoryNameRequired),
+	xhttp.MapError(transactions.ErrInvalidCategoryKind, http.StatusUnprocessableEntity, CodeInvalidCategoryKind),
+	xhttp.MapError(transactions.ErrCategoryNotFound, http.StatusNotFound, CodeCategoryNotFound),
+	xhttp.MapError(transactions.ErrCategoryTenantMismatch, http.StatusNotFound, CodeCategoryNotFound),
+
+	xhttp.MapError(reports.ErrInvalidGranularity, http.StatusBadRequest, CodeInvalidReportGranularity),
+	xhttp.MapError(reports.ErrInvalidPeriod, http.StatusBadRequest, CodeInvalidReportPeriod),
+}
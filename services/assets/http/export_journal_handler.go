@@ -0,0 +1,79 @@
+package assetshttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	"github.com/xfrr/finantrack/internal/contexts/transactions/exports"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const ExportJournalPath = "/exports/"
+
+type ExportJournalHandler struct {
+	bus    cqrs.Bus
+	format exports.Format
+}
+
+func (h *ExportJournalHandler) Method() string {
+	return "GET"
+}
+
+func (h *ExportJournalHandler) Path() string {
+	return ExportJournalPath + string(h.format)
+}
+
+// NewExportJournalHandler creates the handler exporting the books in the given plain-text accounting format.
+func NewExportJournalHandler(querybus cqrs.Bus, format exports.Format) *ExportJournalHandler {
+	return &ExportJournalHandler{
+		bus:    querybus,
+		format: format,
+	}
+}
+
+// @Summary		Export a plain-text accounting journal
+// @Description	Export the assets, transactions, transfers and prices of the household as a ledger or hledger journal, or as a beancount file, with the commodity and account declarations. Both legs of the transfers between assets are merged into one transaction, and the exchange rates of the transfers between currencies are exported as prices.
+// @Tags			transactions
+// @Produce		plain
+// @Success		200	{file}	file
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/exports/ledger [get]
+// @Router			/exports/hledger [get]
+// @Router			/exports/beancount [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			assetId	query	string	false	"Export only the given asset"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *ExportJournalHandler) Handle(c *gin.Context) {
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, transactionsqueries.GetJournalQuery{
+		AssetID: c.Query("assetId"),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	journal, ok := res.(transactionsqueries.JournalView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = exports.Write(&buf, h.format, journal); err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="finantrack`+h.format.Extension()+`"`)
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", buf.Bytes())
+}
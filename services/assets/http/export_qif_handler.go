@@ -0,0 +1,151 @@
+package assetshttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assets "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const ExportQIFPath = "/exports/qif"
+
+type ExportQIFHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *ExportQIFHandler) Method() string {
+	return "GET"
+}
+
+func (h *ExportQIFHandler) Path() string {
+	return ExportQIFPath
+}
+
+func NewExportQIFHandler(querybus cqrs.Bus) *ExportQIFHandler {
+	return &ExportQIFHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Export a QIF file
+// @Description	Export the assets, categories and transactions of the household as a QIF file, one account per asset. Investment transactions are exported as cash movements.
+// @Tags			transactions
+// @Produce		application/qif
+// @Success		200	{file}	file
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/exports/qif [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			assetId		query	string	false	"Export only the given asset"
+// @Param			dateOrder	query	string	false	"Order of the dates"	Enums(mdy, dmy)	default(mdy)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *ExportQIFHandler) Handle(c *gin.Context) {
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, transactionsqueries.GetJournalQuery{
+		AssetID: c.Query("assetId"),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	journal, ok := res.(transactionsqueries.JournalView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	var buf bytes.Buffer
+	err = statements.WriteQIF(&buf, newQIFFile(journal), statements.QIFOptions{
+		DateOrder: statements.QIFDateOrder(c.Query("dateOrder")),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="finantrack.qif"`)
+	c.Data(http.StatusOK, "application/qif", buf.Bytes())
+}
+
+// newQIFFile converts the journal into a QIF file, one account per asset.
+func newQIFFile(journal transactionsqueries.JournalView) statements.QIFFile {
+	var (
+		file  statements.QIFFile
+		index = make(map[string]int, len(journal.Assets))
+	)
+
+	for _, category := range journal.Categories {
+		file.Categories = append(file.Categories, statements.QIFCategory{
+			Name:        category.Name,
+			Description: category.Description,
+			Income:      category.Kind == "income",
+		})
+	}
+
+	for _, asset := range journal.Assets {
+		index[asset.ID] = len(file.Accounts)
+		file.Accounts = append(file.Accounts, statements.QIFAccount{
+			Name: asset.Name,
+			Type: qifAccountType(assets.AssetType(asset.Type)),
+			Statement: statements.Statement{
+				AccountID: asset.IBAN,
+				Currency:  asset.MoneyCurrency,
+			},
+		})
+	}
+
+	for _, t := range journal.Transactions {
+		i, ok := index[t.AssetID]
+		if !ok {
+			continue
+		}
+
+		entry := statements.Entry{
+			BookingDate: t.BookingDate,
+			ValueDate:   t.ValueDate,
+			Amount:      t.Amount,
+			Currency:    t.Currency,
+			Description: t.Description,
+			Payee:       t.Payee,
+			Reference:   t.Reference,
+			Category:    t.Category,
+			Transfer:    t.Transfer,
+			ExternalID:  t.ExternalID,
+		}
+		for _, split := range t.Splits {
+			entry.Splits = append(entry.Splits, statements.Split(split))
+		}
+
+		file.Accounts[i].Statement.Entries = append(file.Accounts[i].Statement.Entries, entry)
+	}
+
+	return file
+}
+
+// qifAccountType returns the QIF account type of an asset type.
+func qifAccountType(assetType assets.AssetType) statements.QIFAccountType {
+	switch assetType {
+	case assets.AssetTypeBank:
+		return statements.QIFBank
+	case assets.AssetTypeCash:
+		return statements.QIFCash
+	case assets.AssetTypeCreditCard:
+		return statements.QIFCreditCard
+	case assets.AssetTypeInvestment:
+		return statements.QIFInvestment
+	default:
+		return statements.QIFOtherAsset
+	}
+}
@@ -0,0 +1,116 @@
+package assetshttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+	"github.com/xfrr/finantrack/internal/contexts/reports/render"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetAllocationPath = "/reports/allocation"
+
+type GetAllocationHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetAllocationHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetAllocationHandler) Path() string {
+	return GetAllocationPath
+}
+
+func NewGetAllocationHandler(querybus cqrs.Bus) *GetAllocationHandler {
+	return &GetAllocationHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the asset allocation
+// @Description	Get how the money of the household is distributed among the asset types, per currency. Credit cards hold debts and are left out. The html and pdf formats lay the report out with charts, ready to be printed or shared.
+// @Tags			reports
+// @Produce		json
+// @Produce		text/csv
+// @Produce		text/html
+// @Produce		application/pdf
+// @Success		200	{object}	AllocationResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/reports/allocation [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			asOf	query	string	false	"Point in time (RFC 3339)"	example(2024-12-31T23:59:59Z)
+// @Param			format	query	string	false	"Format of the report"	Enums(json, csv, html, pdf)	default(json)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetAllocationHandler) Handle(c *gin.Context) {
+	asOf, err := parseAsOf(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	format, err := parseReportFormat(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, reportsqueries.GetAllocationQuery{
+		AsOf: asOf,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	view, ok := res.(reportsqueries.AllocationView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	if format != reportFormatJSON {
+		var buf bytes.Buffer
+		if err = render.WriteAllocation(&buf, format, view); err != nil {
+			xhttp.AbortWithError(c, err)
+			return
+		}
+		writeReport(c, "allocation", format, buf.Bytes())
+		return
+	}
+
+	response := AllocationResponse{
+		Allocations: make([]AllocationItemResponse, 0, len(view.Allocations)),
+	}
+	if !view.AsOf.IsZero() {
+		response.AsOf = view.AsOf.Format(time.RFC3339)
+	}
+	for _, allocation := range view.Allocations {
+		response.Allocations = append(response.Allocations, AllocationItemResponse(allocation))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type AllocationResponse struct {
+	AsOf        string                   `json:"asOf,omitempty" example:"2024-12-31T23:59:59Z"`
+	Allocations []AllocationItemResponse `json:"allocations"`
+}
+
+type AllocationItemResponse struct {
+	Currency  string  `json:"currency" example:"EUR"`
+	AssetType string  `json:"assetType" example:"bank"`
+	Assets    int     `json:"assets" example:"2"`
+	Amount    float64 `json:"amount" example:"12500.00"`
+	Share     float64 `json:"share" example:"0.625"`
+}
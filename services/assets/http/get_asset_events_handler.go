@@ -0,0 +1,183 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assetsqueries "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const (
+	GetAssetEventsPath = "/assets/:id/events"
+
+	// MaxAssetEventsLimit is the maximum number of events returned per page.
+	MaxAssetEventsLimit = 500
+)
+
+type GetAssetEventsHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetAssetEventsHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetAssetEventsHandler) Path() string {
+	return GetAssetEventsPath
+}
+
+func NewGetAssetEventsHandler(querybus cqrs.Bus) *GetAssetEventsHandler {
+	return &GetAssetEventsHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the events of an asset
+// @Description	Get the ordered list of changes of an asset. When asOf is given, only the changes made up to that instant are returned along with the asset state at that time.
+// @Tags			assets
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	GetAssetEventsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets/{id}/events [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id			path	string	true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			fromVersion	query	int		false	"First version to return"
+// @Param			limit		query	int		false	"Maximum number of events to return"	maximum(500)
+// @Param			asOf		query	string	false	"Point in time (RFC 3339)"	example(2024-12-31T23:59:59Z)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetAssetEventsHandler) Handle(c *gin.Context) {
+	query, err := parseGetAssetEventsQuery(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	// dispatch query to get the asset history
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, query)
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	history, ok := res.(*assetsqueries.AssetHistory)
+	if !ok {
+		err = errors.New("unexpected query response")
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newGetAssetEventsResponse(history))
+}
+
+func parseGetAssetEventsQuery(c *gin.Context) (assetsqueries.GetAssetHistoryQuery, error) {
+	query := assetsqueries.GetAssetHistoryQuery{
+		AssetID: c.Param("id"),
+		Limit:   assetsqueries.DefaultHistoryLimit,
+	}
+
+	if v := c.Query("fromVersion"); v != "" {
+		fromVersion, err := strconv.Atoi(v)
+		if err != nil || fromVersion < 0 {
+			return query, errors.New("fromVersion must be a non-negative integer")
+		}
+		query.FromVersion = fromVersion
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > MaxAssetEventsLimit {
+			return query, errors.New("limit must be an integer between 1 and 500")
+		}
+		query.Limit = limit
+	}
+
+	asOf, err := parseAsOf(c)
+	if err != nil {
+		return query, err
+	}
+	query.AsOf = asOf
+
+	return query, nil
+}
+
+// GetAssetEventsResponse represents a page of the events of an asset
+type GetAssetEventsResponse struct {
+	AssetID         string               `json:"assetId"`
+	Events          []AssetEventResponse `json:"events"`
+	NextFromVersion int                  `json:"nextFromVersion,omitempty"`
+	Asset           *AssetResponse       `json:"asset,omitempty"`
+}
+
+// AssetEventResponse represents a single event of an asset
+type AssetEventResponse struct {
+	ID        string                      `json:"id"`
+	Type      string                      `json:"type" example:"asset.created"`
+	Version   int                         `json:"version" example:"1"`
+	Timestamp time.Time                   `json:"timestamp"`
+	Payload   any                         `json:"payload"`
+	Metadata  *AssetEventMetadataResponse `json:"metadata,omitempty"`
+}
+
+// AssetEventMetadataResponse represents the metadata stored with an event
+type AssetEventMetadataResponse struct {
+	CorrelationID string `json:"correlationId,omitempty"`
+	CausationID   string `json:"causationId,omitempty"`
+	RequestID     string `json:"requestId,omitempty"`
+	UserID        string `json:"userId,omitempty"`
+	Source        string `json:"source,omitempty" example:"assets"`
+	TraceID       string `json:"traceId,omitempty"`
+}
+
+func newGetAssetEventsResponse(history *assetsqueries.AssetHistory) GetAssetEventsResponse {
+	res := GetAssetEventsResponse{
+		AssetID:         history.AssetID,
+		Events:          make([]AssetEventResponse, 0, len(history.Changes)),
+		NextFromVersion: history.NextFromVersion,
+	}
+
+	for _, change := range history.Changes {
+		res.Events = append(res.Events, AssetEventResponse{
+			ID:        change.ID,
+			Type:      change.Type,
+			Version:   change.Version,
+			Timestamp: change.Timestamp,
+			Payload:   change.Payload,
+			Metadata:  newAssetEventMetadataResponse(change.Metadata),
+		})
+	}
+
+	if history.State != nil {
+		asset := newAssetResponse(*history.State)
+		res.Asset = &asset
+	}
+
+	return res
+}
+
+func newAssetEventMetadataResponse(md *xevent.Metadata) *AssetEventMetadataResponse {
+	if md == nil {
+		return nil
+	}
+
+	return &AssetEventMetadataResponse{
+		CorrelationID: md.CorrelationID,
+		CausationID:   md.CausationID,
+		RequestID:     md.RequestID,
+		UserID:        md.UserID,
+		Source:        md.Source,
+		TraceID:       md.TraceID,
+	}
+}
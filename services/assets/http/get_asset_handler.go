@@ -0,0 +1,133 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assetsqueries "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetAssetPath = "/assets/:id"
+
+type GetAssetHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetAssetHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetAssetHandler) Path() string {
+	return GetAssetPath
+}
+
+func NewGetAssetHandler(querybus cqrs.Bus) *GetAssetHandler {
+	return &GetAssetHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get an asset
+// @Description	Get the current state of an asset, or its state as of a point in time or version.
+// @Tags			assets
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	AssetResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets/{id} [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path	string	true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			asOf	query	string	false	"Point in time (RFC 3339)"	example(2024-12-31T23:59:59Z)
+// @Param			version	query	int		false	"Asset version"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetAssetHandler) Handle(c *gin.Context) {
+	query := assetsqueries.GetAssetQuery{
+		AssetID: c.Param("id"),
+	}
+
+	var err error
+	if query.AsOf, err = parseAsOf(c); err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	if v := c.Query("version"); v != "" {
+		query.Version, err = strconv.Atoi(v)
+		if err != nil || query.Version <= 0 {
+			err = errors.New("version must be a positive integer")
+			xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+			return
+		}
+	}
+
+	// dispatch query to get the asset
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, query)
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	view, ok := res.(*assetsqueries.AssetView)
+	if !ok {
+		err = errors.New("unexpected query response")
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAssetResponse(*view))
+}
+
+// parseAsOf parses the optional asOf query parameter.
+func parseAsOf(c *gin.Context) (time.Time, error) {
+	v := c.Query("asOf")
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, errors.New("asOf must be a RFC 3339 timestamp")
+	}
+
+	return asOf, nil
+}
+
+// AssetResponse represents the state of an asset
+type AssetResponse struct {
+	AssetID            string  `json:"assetId"`
+	HouseholdID        string  `json:"householdId,omitempty"`
+	OwnerID            string  `json:"ownerId,omitempty"`
+	AssetName          string  `json:"assetName" example:"My Asset"`
+	AssetType          string  `json:"assetType" example:"cash"`
+	AssetMoneyAmount   float64 `json:"assetMoneyAmount" example:"1000.00"`
+	AssetMoneyCurrency string  `json:"assetMoneyCurrency" example:"USD"`
+	IBAN               string  `json:"iban,omitempty" example:"DE89370400440532013000"`
+	Deleted            bool    `json:"deleted"`
+	Version            int     `json:"version" example:"1"`
+}
+
+func newAssetResponse(view assetsqueries.AssetView) AssetResponse {
+	return AssetResponse{
+		AssetID:            view.ID,
+		HouseholdID:        view.TenantID,
+		OwnerID:            view.OwnerID,
+		AssetName:          view.Name,
+		AssetType:          view.Type,
+		AssetMoneyAmount:   view.MoneyAmount,
+		AssetMoneyCurrency: view.MoneyCurrency,
+		IBAN:               view.IBAN,
+		Deleted:            view.Deleted,
+		Version:            view.Version,
+	}
+}
@@ -0,0 +1,111 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assetsqueries "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetAssetsPath = "/assets"
+
+type GetAssetsHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetAssetsHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetAssetsHandler) Path() string {
+	return GetAssetsPath
+}
+
+func NewGetAssetsHandler(querybus cqrs.Bus) *GetAssetsHandler {
+	return &GetAssetsHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get all the assets
+// @Description	Get the portfolio with all the assets and their totals per currency, currently or as of a point in time.
+// @Tags			assets
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	GetAssetsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			asOf	query	string	false	"Point in time (RFC 3339)"	example(2024-12-31T23:59:59Z)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetAssetsHandler) Handle(c *gin.Context) {
+	asOf, err := parseAsOf(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	// dispatch query to get the portfolio
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, assetsqueries.GetPortfolioQuery{
+		AsOf: asOf,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	portfolio, ok := res.(*assetsqueries.PortfolioView)
+	if !ok {
+		err = errors.New("unexpected query response")
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newGetAssetsResponse(portfolio))
+}
+
+// GetAssetsResponse represents the assets owned at a point in time
+type GetAssetsResponse struct {
+	AsOf   *time.Time      `json:"asOf,omitempty"`
+	Assets []AssetResponse `json:"assets"`
+	Totals []MoneyResponse `json:"totals"`
+}
+
+// MoneyResponse represents an amount of money in a currency
+type MoneyResponse struct {
+	Amount   float64 `json:"amount" example:"1000.00"`
+	Currency string  `json:"currency" example:"USD"`
+}
+
+func newGetAssetsResponse(portfolio *assetsqueries.PortfolioView) GetAssetsResponse {
+	res := GetAssetsResponse{
+		Assets: make([]AssetResponse, 0, len(portfolio.Assets)),
+		Totals: make([]MoneyResponse, 0, len(portfolio.Totals)),
+	}
+
+	if !portfolio.AsOf.IsZero() {
+		res.AsOf = &portfolio.AsOf
+	}
+
+	for _, asset := range portfolio.Assets {
+		res.Assets = append(res.Assets, newAssetResponse(asset))
+	}
+
+	for _, total := range portfolio.Totals {
+		res.Totals = append(res.Totals, MoneyResponse{
+			Amount:   total.Amount,
+			Currency: total.Currency,
+		})
+	}
+
+	return res
+}
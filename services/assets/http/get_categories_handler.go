@@ -0,0 +1,85 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetCategoriesPath = "/categories"
+
+type GetCategoriesHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetCategoriesHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetCategoriesHandler) Path() string {
+	return GetCategoriesPath
+}
+
+func NewGetCategoriesHandler(querybus cqrs.Bus) *GetCategoriesHandler {
+	return &GetCategoriesHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the categories
+// @Description	Get the transaction categories of the household sorted by name
+// @Tags			transactions
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	GetCategoriesResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/categories [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetCategoriesHandler) Handle(c *gin.Context) {
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, transactionsqueries.GetCategoriesQuery{})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	views, ok := res.([]transactionsqueries.CategoryView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	response := GetCategoriesResponse{
+		Categories: make([]CategoryResponse, 0, len(views)),
+	}
+	for _, view := range views {
+		response.Categories = append(response.Categories, CategoryResponse{
+			ID:          view.ID,
+			Name:        view.Name,
+			Kind:        view.Kind,
+			Description: view.Description,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type GetCategoriesResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+}
+
+type CategoryResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name" example:"Food:Groceries"`
+	Kind        string `json:"kind" example:"expense"`
+	Description string `json:"description,omitempty"`
+}
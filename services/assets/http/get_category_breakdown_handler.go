@@ -0,0 +1,134 @@
+package assetshttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+	"github.com/xfrr/finantrack/internal/contexts/reports/render"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetCategoryBreakdownPath = "/reports/categories"
+
+type GetCategoryBreakdownHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetCategoryBreakdownHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetCategoryBreakdownHandler) Path() string {
+	return GetCategoryBreakdownPath
+}
+
+func NewGetCategoryBreakdownHandler(querybus cqrs.Bus) *GetCategoryBreakdownHandler {
+	return &GetCategoryBreakdownHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the category breakdown
+// @Description	Get the money earned and spent by the household per month, quarter or year and category, along with the share of each category in the income or expenses of the period. Without range, the last twelve months, four quarters or five years are returned. The html and pdf formats lay the report out with charts, ready to be printed or shared.
+// @Tags			reports
+// @Produce		json
+// @Produce		text/csv
+// @Produce		text/html
+// @Produce		application/pdf
+// @Success		200	{object}	CategoryBreakdownResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/reports/categories [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			period	query	string	false	"Length of the periods"	Enums(monthly, quarterly, yearly)	default(monthly)
+// @Param			from	query	string	false	"First day of the report"	example(2024-01-01)
+// @Param			to		query	string	false	"Last day of the report, today by default"	example(2024-12-31)
+// @Param			format	query	string	false	"Format of the report"	Enums(json, csv, html, pdf)	default(json)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetCategoryBreakdownHandler) Handle(c *gin.Context) {
+	granularity, from, to, err := parseReportRange(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	format, err := parseReportFormat(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, reportsqueries.GetCategoryBreakdownQuery{
+		Granularity: granularity,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	view, ok := res.(reportsqueries.CategoryBreakdownView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	if format != reportFormatJSON {
+		var buf bytes.Buffer
+		if err = render.WriteCategoryBreakdown(&buf, format, view); err != nil {
+			xhttp.AbortWithError(c, err)
+			return
+		}
+		writeReport(c, "categories", format, buf.Bytes())
+		return
+	}
+
+	response := CategoryBreakdownResponse{
+		Granularity: view.Granularity,
+		From:        view.From.Format(time.DateOnly),
+		To:          view.To.Format(time.DateOnly),
+		Categories:  make([]PeriodCategoryResponse, 0, len(view.Categories)),
+	}
+	for _, category := range view.Categories {
+		response.Categories = append(response.Categories, PeriodCategoryResponse{
+			Period:   category.Period,
+			Start:    category.Start.Format(time.DateOnly),
+			End:      category.End.Format(time.DateOnly),
+			Currency: category.Currency,
+			Category: category.Category,
+			Kind:     category.Kind,
+			Amount:   category.Amount,
+			Share:    category.Share,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type CategoryBreakdownResponse struct {
+	Granularity string                   `json:"granularity" example:"monthly"`
+	From        string                   `json:"from" example:"2024-01-01"`
+	To          string                   `json:"to" example:"2024-12-31"`
+	Categories  []PeriodCategoryResponse `json:"categories"`
+}
+
+type PeriodCategoryResponse struct {
+	Period   string  `json:"period" example:"2024-01"`
+	Start    string  `json:"start" example:"2024-01-01"`
+	End      string  `json:"end" example:"2024-01-31"`
+	Currency string  `json:"currency" example:"EUR"`
+	Category string  `json:"category" example:"Food:Groceries"`
+	Kind     string  `json:"kind" example:"expense"`
+	Amount   float64 `json:"amount" example:"420.50"`
+	Share    float64 `json:"share" example:"0.2336"`
+}
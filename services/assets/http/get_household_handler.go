@@ -0,0 +1,97 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	householdsqueries "github.com/xfrr/finantrack/internal/contexts/households/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetHouseholdPath = "/households/:id"
+
+type GetHouseholdHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetHouseholdHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetHouseholdHandler) Path() string {
+	return GetHouseholdPath
+}
+
+func NewGetHouseholdHandler(querybus cqrs.Bus) *GetHouseholdHandler {
+	return &GetHouseholdHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get a household
+// @Description	Get a household and its members. Only the members can read it.
+// @Tags			households
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	HouseholdResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/households/{id} [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Household ID"	default(00000000-0000-0000-0000-000000000000)
+func (h *GetHouseholdHandler) Handle(c *gin.Context) {
+	// dispatch query to get the household
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, householdsqueries.GetHouseholdQuery{
+		HouseholdID: c.Param("id"),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	view, ok := res.(*householdsqueries.HouseholdView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	c.JSON(http.StatusOK, newHouseholdResponse(*view))
+}
+
+// HouseholdResponse represents a household and its members
+type HouseholdResponse struct {
+	HouseholdID   string           `json:"householdId"`
+	HouseholdName string           `json:"householdName" example:"Home"`
+	Members       []MemberResponse `json:"members"`
+	Version       int              `json:"version" example:"1"`
+}
+
+// MemberResponse represents a member of a household
+type MemberResponse struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role" example:"editor"`
+}
+
+func newHouseholdResponse(view householdsqueries.HouseholdView) HouseholdResponse {
+	res := HouseholdResponse{
+		HouseholdID:   view.ID,
+		HouseholdName: view.Name,
+		Members:       make([]MemberResponse, 0, len(view.Members)),
+		Version:       view.Version,
+	}
+
+	for _, m := range view.Members {
+		res.Members = append(res.Members, MemberResponse{
+			UserID: m.UserID,
+			Role:   m.Role,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,65 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	householdsqueries "github.com/xfrr/finantrack/internal/contexts/households/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetHouseholdsPath = "/households"
+
+type GetHouseholdsHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetHouseholdsHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetHouseholdsHandler) Path() string {
+	return GetHouseholdsPath
+}
+
+func NewGetHouseholdsHandler(querybus cqrs.Bus) *GetHouseholdsHandler {
+	return &GetHouseholdsHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the households of the user
+// @Description	Get the households the authenticated user is a member of
+// @Tags			households
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		HouseholdResponse
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/households [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+func (h *GetHouseholdsHandler) Handle(c *gin.Context) {
+	// dispatch query to get the households
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, householdsqueries.GetHouseholdsQuery{})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	views, ok := res.([]householdsqueries.HouseholdView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	households := make([]HouseholdResponse, 0, len(views))
+	for _, view := range views {
+		households = append(households, newHouseholdResponse(view))
+	}
+
+	c.JSON(http.StatusOK, households)
+}
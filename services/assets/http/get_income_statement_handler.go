@@ -0,0 +1,186 @@
+package assetshttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	reports "github.com/xfrr/finantrack/internal/contexts/reports/domain"
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+	"github.com/xfrr/finantrack/internal/contexts/reports/render"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetIncomeStatementPath = "/reports/income-statement"
+
+type GetIncomeStatementHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetIncomeStatementHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetIncomeStatementHandler) Path() string {
+	return GetIncomeStatementPath
+}
+
+func NewGetIncomeStatementHandler(querybus cqrs.Bus) *GetIncomeStatementHandler {
+	return &GetIncomeStatementHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the income statement
+// @Description	Get the income, expenses, net savings and savings rate of the household per month, quarter or year, one row per currency. Transfers between accounts are neither income nor expenses. Without range, the last twelve months, four quarters or five years are returned. The html and pdf formats lay the report out with charts, ready to be printed or shared.
+// @Tags			reports
+// @Produce		json
+// @Produce		text/csv
+// @Produce		text/html
+// @Produce		application/pdf
+// @Success		200	{object}	IncomeStatementResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/reports/income-statement [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			period	query	string	false	"Length of the periods"	Enums(monthly, quarterly, yearly)	default(monthly)
+// @Param			from	query	string	false	"First day of the report"	example(2024-01-01)
+// @Param			to		query	string	false	"Last day of the report, today by default"	example(2024-12-31)
+// @Param			format	query	string	false	"Format of the report"	Enums(json, csv, html, pdf)	default(json)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetIncomeStatementHandler) Handle(c *gin.Context) {
+	granularity, from, to, err := parseReportRange(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	format, err := parseReportFormat(c)
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, reportsqueries.GetIncomeStatementQuery{
+		Granularity: granularity,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	view, ok := res.(reportsqueries.IncomeStatementView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	if format != reportFormatJSON {
+		var buf bytes.Buffer
+		if err = render.WriteIncomeStatement(&buf, format, view); err != nil {
+			xhttp.AbortWithError(c, err)
+			return
+		}
+		writeReport(c, "income-statement", format, buf.Bytes())
+		return
+	}
+
+	response := IncomeStatementResponse{
+		Granularity: view.Granularity,
+		From:        view.From.Format(time.DateOnly),
+		To:          view.To.Format(time.DateOnly),
+		Periods:     make([]PeriodSummaryResponse, 0, len(view.Periods)),
+	}
+	for _, p := range view.Periods {
+		response.Periods = append(response.Periods, PeriodSummaryResponse{
+			Period:      p.Period,
+			Start:       p.Start.Format(time.DateOnly),
+			End:         p.End.Format(time.DateOnly),
+			Currency:    p.Currency,
+			Income:      p.Income,
+			Expenses:    p.Expenses,
+			Net:         p.Net,
+			SavingsRate: p.SavingsRate,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type IncomeStatementResponse struct {
+	Granularity string                  `json:"granularity" example:"monthly"`
+	From        string                  `json:"from" example:"2024-01-01"`
+	To          string                  `json:"to" example:"2024-12-31"`
+	Periods     []PeriodSummaryResponse `json:"periods"`
+}
+
+type PeriodSummaryResponse struct {
+	Period      string  `json:"period" example:"2024-01"`
+	Start       string  `json:"start" example:"2024-01-01"`
+	End         string  `json:"end" example:"2024-01-31"`
+	Currency    string  `json:"currency" example:"EUR"`
+	Income      float64 `json:"income" example:"2500.00"`
+	Expenses    float64 `json:"expenses" example:"1800.00"`
+	Net         float64 `json:"net" example:"700.00"`
+	SavingsRate float64 `json:"savingsRate" example:"0.28"`
+}
+
+// reportFormatJSON is the default format of the reports, the others are rendered by the render package.
+const reportFormatJSON render.Format = "json"
+
+// parseReportRange parses the optional period, from and to query parameters of the reports.
+func parseReportRange(c *gin.Context) (reports.Granularity, time.Time, time.Time, error) {
+	granularity := reports.Granularity(c.DefaultQuery("period", string(reports.GranularityMonthly)))
+
+	var from, to time.Time
+	if v := c.Query("from"); v != "" {
+		var err error
+		if from, err = time.Parse(time.DateOnly, v); err != nil {
+			return "", time.Time{}, time.Time{}, errors.New("from must be a date formatted as YYYY-MM-DD")
+		}
+	}
+
+	if v := c.Query("to"); v != "" {
+		var err error
+		if to, err = time.Parse(time.DateOnly, v); err != nil {
+			return "", time.Time{}, time.Time{}, errors.New("to must be a date formatted as YYYY-MM-DD")
+		}
+	}
+
+	return granularity, from, to, nil
+}
+
+// parseReportFormat parses the optional format query parameter of the reports.
+func parseReportFormat(c *gin.Context) (render.Format, error) {
+	name := c.DefaultQuery("format", string(reportFormatJSON))
+	if name == string(reportFormatJSON) {
+		return reportFormatJSON, nil
+	}
+
+	format, err := render.ParseFormat(name)
+	if err != nil {
+		return "", errors.New("format must be json, csv, html or pdf")
+	}
+	return format, nil
+}
+
+// writeReport sends the rendered report. HTML pages are displayed by the browser,
+// the other formats are downloaded as files.
+func writeReport(c *gin.Context, name string, format render.Format, data []byte) {
+	disposition := "attachment"
+	if format == render.FormatHTML {
+		disposition = "inline"
+	}
+
+	c.Header("Content-Disposition", disposition+`; filename="`+name+format.Extension()+`"`)
+	c.Data(http.StatusOK, format.ContentType(), data)
+}
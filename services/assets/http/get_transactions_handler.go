@@ -0,0 +1,126 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const GetTransactionsPath = "/assets/:id/transactions"
+
+type GetTransactionsHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *GetTransactionsHandler) Method() string {
+	return "GET"
+}
+
+func (h *GetTransactionsHandler) Path() string {
+	return GetTransactionsPath
+}
+
+func NewGetTransactionsHandler(querybus cqrs.Bus) *GetTransactionsHandler {
+	return &GetTransactionsHandler{
+		bus: querybus,
+	}
+}
+
+// @Summary		Get the transactions of an asset
+// @Description	Get the transactions of an asset sorted by booking date
+// @Tags			transactions
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	GetTransactionsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets/{id}/transactions [get]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id	path	string	true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			X-Household-ID	header	string	true	"Household ID"
+func (h *GetTransactionsHandler) Handle(c *gin.Context) {
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, transactionsqueries.GetTransactionsQuery{
+		AssetID: c.Param("id"),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	views, ok := res.([]transactionsqueries.TransactionView)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected query response"))
+		return
+	}
+
+	response := GetTransactionsResponse{
+		Transactions: make([]TransactionResponse, 0, len(views)),
+	}
+	for _, view := range views {
+		response.Transactions = append(response.Transactions, newTransactionResponse(view))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type GetTransactionsResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+type TransactionResponse struct {
+	ID          string          `json:"id"`
+	AssetID     string          `json:"assetId"`
+	BookingDate time.Time       `json:"bookingDate"`
+	ValueDate   *time.Time      `json:"valueDate,omitempty"`
+	Amount      float64         `json:"amount" example:"-42.50"`
+	Currency    string          `json:"currency" example:"EUR"`
+	Description string          `json:"description,omitempty"`
+	Payee       string          `json:"payee,omitempty"`
+	Reference   string          `json:"reference,omitempty"`
+	Category    string          `json:"category,omitempty" example:"Food:Groceries"`
+	Transfer    string          `json:"transfer,omitempty" example:"Savings"`
+	Splits      []SplitResponse `json:"splits,omitempty"`
+	Source      string          `json:"source,omitempty" example:"csv"`
+	ExternalID  string          `json:"externalId,omitempty"`
+}
+
+type SplitResponse struct {
+	Category string  `json:"category,omitempty" example:"Food:Groceries"`
+	Transfer string  `json:"transfer,omitempty"`
+	Memo     string  `json:"memo,omitempty"`
+	Amount   float64 `json:"amount" example:"-42.50"`
+}
+
+func newTransactionResponse(view transactionsqueries.TransactionView) TransactionResponse {
+	res := TransactionResponse{
+		ID:          view.ID,
+		AssetID:     view.AssetID,
+		BookingDate: view.BookingDate,
+		ValueDate:   optionalTime(view.ValueDate),
+		Amount:      view.Amount,
+		Currency:    view.Currency,
+		Description: view.Description,
+		Payee:       view.Payee,
+		Reference:   view.Reference,
+		Category:    view.Category,
+		Transfer:    view.Transfer,
+		Source:      view.Source,
+		ExternalID:  view.ExternalID,
+	}
+
+	for _, split := range view.Splits {
+		res.Splits = append(res.Splits, SplitResponse(split))
+	}
+
+	return res
+}
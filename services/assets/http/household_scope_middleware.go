@@ -0,0 +1,79 @@
+package assetshttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	householdsqueries "github.com/xfrr/finantrack/internal/contexts/households/queries"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+)
+
+// HouseholdIDHeader is the header used to select the household the request is scoped to.
+const HouseholdIDHeader = "X-Household-ID"
+
+// DefaultHouseholdID is the single household of the service when authentication is disabled, e.g. locally.
+const DefaultHouseholdID = "00000000-0000-0000-0000-000000000001"
+
+// GinHouseholdScope scopes the request to the household given in the X-Household-ID header.
+// Safe methods require the read permission of the principal and the rest the write permission.
+//
+// When authentication is disabled there is no principal to authorize, so every request is scoped
+// to the DefaultHouseholdID, rejecting the ones naming another household. When it is enabled,
+// the requests without principal are rejected.
+func GinHouseholdScope(querybus cqrs.Bus, authenticated bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		householdID := c.GetHeader(HouseholdIDHeader)
+
+		if !authenticated {
+			if householdID != "" && householdID != DefaultHouseholdID {
+				xhttp.AbortWithError(c, households.ErrForbidden)
+				return
+			}
+
+			c.Request = c.Request.WithContext(xtenant.WithTenant(c.Request.Context(), DefaultHouseholdID))
+			c.Next()
+			return
+		}
+
+		if householdID == "" {
+			xhttp.AbortWithError(c, xtenant.ErrTenantRequired)
+			return
+		}
+
+		if _, err := uuid.Parse(householdID); err != nil {
+			xhttp.AbortWithError(c, fmt.Errorf("%w: %w", households.ErrInvalidHouseholdID, err))
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		if _, ok := xauth.PrincipalFromContext(ctx); !ok {
+			xhttp.AbortWithError(c, xauth.ErrUnauthenticated)
+			return
+		}
+
+		permission := households.PermissionWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			permission = households.PermissionRead
+		}
+
+		_, err := cqrs.Dispatch(ctx, querybus, householdsqueries.AuthorizeHouseholdQuery{
+			HouseholdID: householdID,
+			Permission:  permission,
+		})
+		if err != nil {
+			xhttp.AbortWithError(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(xtenant.WithTenant(ctx, householdID))
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package assetshttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	households "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+
+	. "github.com/xfrr/finantrack/services/assets/http"
+)
+
+func TestGinHouseholdScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const householdID = "6f1d1c58-8f0a-4a36-9d8c-7a0d0f3b9c11"
+
+	var specs = []struct {
+		name          string
+		authenticated bool
+		header        string
+		tenant        string
+		err           error
+	}{
+		{
+			name:   "without authentication the request is scoped to the default household",
+			tenant: DefaultHouseholdID,
+		},
+		{
+			name:   "without authentication the default household can be named",
+			header: DefaultHouseholdID,
+			tenant: DefaultHouseholdID,
+		},
+		{
+			name:   "without authentication another household should be forbidden",
+			header: householdID,
+			err:    households.ErrForbidden,
+		},
+		{
+			name:          "with authentication a request without principal should be unauthenticated",
+			authenticated: true,
+			header:        householdID,
+			err:           xauth.ErrUnauthenticated,
+		},
+		{
+			name:          "with authentication a request without household should be rejected",
+			authenticated: true,
+			err:           xtenant.ErrTenantRequired,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			var (
+				tenant string
+				err    error
+			)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				if last := c.Errors.Last(); last != nil {
+					err = last.Err
+				}
+			})
+			router.GET("/assets", GinHouseholdScope(nil, spec.authenticated), func(c *gin.Context) {
+				tenant, _ = xtenant.FromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/assets", nil)
+			if spec.header != "" {
+				req.Header.Set(HouseholdIDHeader, spec.header)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.ErrorIs(t, err, spec.err)
+			assert.Equal(t, spec.tenant, tenant)
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package assetshttp
 
 import (
+	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/exports"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
 	"github.com/xfrr/finantrack/internal/shared/xhttp"
 	"github.com/xfrr/go-cqrsify/cqrs"
 )
@@ -23,22 +27,89 @@ import (
 
 //	@schemes	http
 
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				JWT sent as "Bearer <token>"
+
 const BasePath = "/api/v1"
 
 func NewServer(
 	serviceName string,
 	commandBus cqrs.Bus,
+	queryBus cqrs.Bus,
 	logger zerolog.Logger,
+	health *xhealth.Health,
+	csvProfiles []statements.CSVProfile,
+	maxBodyBytes int64,
+	idempotencyStore xhttp.IdempotencyStore,
+	authOpts ...xhttp.AuthOption,
 ) xhttp.Server {
-	return xhttp.NewGinServer(
-		BasePath,
+	opts := []xhttp.Option{
 		xhttp.WithHealthCheck(),
+		xhttp.WithProbes(health),
 		xhttp.WithOpenTracing(serviceName),
+		xhttp.WithRequestMetadata(serviceName),
+		xhttp.WithMetrics(),
 		xhttp.WithZeroLogger(&logger),
+		xhttp.WithErrorTranslation(errorMappings...),
+	}
+
+	// authentication is disabled when no authenticator is given
+	authenticated := len(authOpts) > 0
+	if authenticated {
+		opts = append(opts, xhttp.WithAuthentication(authOpts...))
+	}
+
+	// request bodies, such as the uploaded statements, are limited before they are read
+	if maxBodyBytes == 0 {
+		maxBodyBytes = xhttp.DefaultMaxBodyBytes
+	}
+	opts = append(opts, xhttp.WithMaxBodyBytes(maxBodyBytes))
+
+	// mutating requests are safe to retry with an idempotency key
+	if idempotencyStore != nil {
+		opts = append(opts, xhttp.WithIdempotency(idempotencyStore))
+	}
+
+	opts = append(opts,
 		xhttp.WithHandlers(
+			NewCreateHouseholdHandler(commandBus),
+			NewSetHouseholdMemberHandler(commandBus),
+			NewRemoveHouseholdMemberHandler(commandBus),
+			NewGetHouseholdHandler(queryBus),
+			NewGetHouseholdsHandler(queryBus),
+		),
+		// assets are scoped to the household of the request
+		xhttp.WithScopedHandlers(
+			[]gin.HandlerFunc{GinHouseholdScope(queryBus, authenticated)},
 			NewCreateAssetHandler(commandBus),
 			NewModifyAssetHandler(commandBus),
 			NewDeleteAssetHandler(commandBus),
+			NewLinkAssetAccountHandler(commandBus),
+			NewGetAssetHandler(queryBus),
+			NewGetAssetsHandler(queryBus),
+			NewGetAssetEventsHandler(queryBus),
+			NewImportCSVStatementHandler(commandBus, csvProfiles),
+			NewImportOFXStatementHandler(commandBus),
+			NewImportCAMT053StatementHandler(commandBus),
+			NewImportMT940StatementHandler(commandBus),
+			NewImportQIFHandler(commandBus),
+			NewExportQIFHandler(queryBus),
+			NewExportJournalHandler(queryBus, exports.FormatLedger),
+			NewExportJournalHandler(queryBus, exports.FormatHLedger),
+			NewExportJournalHandler(queryBus, exports.FormatBeancount),
+			NewGetTransactionsHandler(queryBus),
+			NewGetCategoriesHandler(queryBus),
+			NewGetIncomeStatementHandler(queryBus),
+			NewGetCategoryBreakdownHandler(queryBus),
+			NewGetAllocationHandler(queryBus),
 		),
 	)
+
+	return xhttp.NewGinServer(BasePath, opts...)
 }
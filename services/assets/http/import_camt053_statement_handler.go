@@ -0,0 +1,58 @@
+package assetshttp
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const ImportCAMT053StatementPath = "/imports/camt053"
+
+type ImportCAMT053StatementHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *ImportCAMT053StatementHandler) Method() string {
+	return "POST"
+}
+
+func (h *ImportCAMT053StatementHandler) Path() string {
+	return ImportCAMT053StatementPath
+}
+
+func NewImportCAMT053StatementHandler(cmdbus cqrs.Bus) *ImportCAMT053StatementHandler {
+	return &ImportCAMT053StatementHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Import a ISO 20022 camt.053 statement
+// @Description	Import the booked entries of a camt.053 XML statement into the asset linked to the IBAN of the statement account. Entries already imported are detected by their bank references and skipped. Booking and value dates are preserved, and the asset balance is updated with the closing balance. With dryRun the import is previewed without recording any change.
+// @Tags			transactions
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		200	{object}	ImportTransactionsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		413	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/imports/camt053 [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			file	formData	file	true	"camt.053 XML statement"
+// @Param			dryRun	query		bool	false	"Preview the import without recording any change"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *ImportCAMT053StatementHandler) Handle(c *gin.Context) {
+	statement, err := parseStatementFile(c, statements.NewCAMT053Parser())
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	importStatement(c, h.bus, "camt053", statement)
+}
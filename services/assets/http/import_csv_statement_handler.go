@@ -0,0 +1,215 @@
+package assetshttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	transactionscommands "github.com/xfrr/finantrack/internal/contexts/transactions/commands"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const (
+	ImportCSVStatementPath = "/assets/:id/imports/csv"
+
+	// DefaultCSVProfile is the profile used when the request does not name one.
+	DefaultCSVProfile = "generic"
+)
+
+type ImportCSVStatementHandler struct {
+	bus      cqrs.Bus
+	profiles []statements.CSVProfile
+}
+
+func (h *ImportCSVStatementHandler) Method() string {
+	return "POST"
+}
+
+func (h *ImportCSVStatementHandler) Path() string {
+	return ImportCSVStatementPath
+}
+
+func NewImportCSVStatementHandler(cmdbus cqrs.Bus, profiles []statements.CSVProfile) *ImportCSVStatementHandler {
+	return &ImportCSVStatementHandler{
+		bus:      cmdbus,
+		profiles: profiles,
+	}
+}
+
+// @Summary		Import a CSV bank statement
+// @Description	Import the entries of a CSV bank statement as transactions of the asset. The columns are mapped by a named profile or by an inline mapping. Entries already imported are detected by their fingerprint and skipped. With dryRun the import is previewed without recording the transactions.
+// @Tags			transactions
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		200	{object}	ImportTransactionsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		413	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets/{id}/imports/csv [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path		string	true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			file	formData	file	true	"CSV statement"
+// @Param			profile	formData	string	false	"Name of the CSV profile"	default(generic)
+// @Param			mapping	formData	string	false	"CSV profile as JSON, overrides the named profile"
+// @Param			dryRun	query		bool	false	"Preview the import without recording the transactions"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *ImportCSVStatementHandler) Handle(c *gin.Context) {
+	profile, err := h.profile(c)
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	statement, err := parseStatementFile(c, statements.NewCSVParser(profile))
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	importStatement(c, h.bus, "csv", statement)
+}
+
+// profile returns the inline mapping of the request or the named profile.
+func (h *ImportCSVStatementHandler) profile(c *gin.Context) (statements.CSVProfile, error) {
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		var profile statements.CSVProfile
+		if err := json.Unmarshal([]byte(mapping), &profile); err != nil {
+			return statements.CSVProfile{}, xhttp.InvalidRequest(err)
+		}
+		return profile, nil
+	}
+
+	return statements.FindCSVProfile(h.profiles, c.DefaultPostForm("profile", DefaultCSVProfile))
+}
+
+// parseStatementFile parses the statement uploaded in the file field of the form.
+func parseStatementFile(c *gin.Context, parser statements.Parser) (statements.Statement, error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return statements.Statement{}, xhttp.InvalidRequest(err)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return statements.Statement{}, xhttp.InvalidRequest(err)
+	}
+	defer file.Close()
+
+	return parser.Parse(file)
+}
+
+// importStatement dispatches the import of the statement entries into the asset of the path,
+// or into the asset linked to the statement account when the path has no asset.
+func importStatement(c *gin.Context, bus cqrs.Bus, source string, statement statements.Statement) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	res, err := cqrs.Dispatch(c.Request.Context(), bus, transactionscommands.ImportTransactionsCommand{
+		AssetID:   c.Param("id"),
+		Source:    source,
+		Statement: statement,
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	result, ok := res.(*transactionscommands.ImportResult)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected command response"))
+		return
+	}
+
+	c.JSON(http.StatusOK, newImportTransactionsResponse(result))
+}
+
+type ImportTransactionsResponse struct {
+	AssetID      string                        `json:"assetId"`
+	DryRun       bool                          `json:"dryRun"`
+	Imported     int                           `json:"imported"`
+	Duplicates   int                           `json:"duplicates"`
+	Transactions []ImportedTransactionResponse `json:"transactions"`
+	Balance      *ImportedBalanceResponse      `json:"balance,omitempty"`
+}
+
+type ImportedBalanceResponse struct {
+	Amount   float64   `json:"amount" example:"1250.00"`
+	Currency string    `json:"currency" example:"EUR"`
+	AsOf     time.Time `json:"asOf"`
+	Updated  bool      `json:"updated"`
+	Outdated bool      `json:"outdated"`
+}
+
+type ImportedTransactionResponse struct {
+	TransactionID string     `json:"transactionId"`
+	Fingerprint   string     `json:"fingerprint"`
+	BookingDate   time.Time  `json:"bookingDate"`
+	ValueDate     *time.Time `json:"valueDate,omitempty"`
+	Amount        float64    `json:"amount" example:"-42.50"`
+	Currency      string     `json:"currency" example:"EUR"`
+	Description   string     `json:"description,omitempty"`
+	Payee         string     `json:"payee,omitempty"`
+	Reference     string     `json:"reference,omitempty"`
+	Duplicate     bool       `json:"duplicate"`
+}
+
+func newImportTransactionsResponse(result *transactionscommands.ImportResult) ImportTransactionsResponse {
+	res := ImportTransactionsResponse{
+		AssetID:      result.AssetID,
+		DryRun:       result.DryRun,
+		Imported:     result.Imported,
+		Duplicates:   result.Duplicates,
+		Transactions: make([]ImportedTransactionResponse, 0, len(result.Transactions)),
+	}
+
+	if result.Balance != nil {
+		res.Balance = &ImportedBalanceResponse{
+			Amount:   result.Balance.Amount,
+			Currency: result.Balance.Currency,
+			AsOf:     result.Balance.AsOf,
+			Updated:  result.Balance.Updated,
+			Outdated: result.Balance.Outdated,
+		}
+	}
+
+	for _, t := range result.Transactions {
+		res.Transactions = append(res.Transactions, ImportedTransactionResponse{
+			TransactionID: t.TransactionID,
+			Fingerprint:   t.Fingerprint,
+			BookingDate:   t.BookingDate,
+			ValueDate:     optionalTime(t.ValueDate),
+			Amount:        t.Amount,
+			Currency:      t.Currency,
+			Description:   t.Description,
+			Payee:         t.Payee,
+			Reference:     t.Reference,
+			Duplicate:     t.Duplicate,
+		})
+	}
+
+	return res
+}
+
+// optionalTime returns nil for the zero time, so it is omitted from the responses.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,58 @@
+package assetshttp
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const ImportMT940StatementPath = "/imports/mt940"
+
+type ImportMT940StatementHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *ImportMT940StatementHandler) Method() string {
+	return "POST"
+}
+
+func (h *ImportMT940StatementHandler) Path() string {
+	return ImportMT940StatementPath
+}
+
+func NewImportMT940StatementHandler(cmdbus cqrs.Bus) *ImportMT940StatementHandler {
+	return &ImportMT940StatementHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Import a SWIFT MT940 statement
+// @Description	Import the booked entries of a MT940 statement into the asset linked to the IBAN of the statement account. Entries already imported are detected by their bank references and skipped. Booking and value dates are preserved, and the asset balance is updated with the closing balance. With dryRun the import is previewed without recording any change.
+// @Tags			transactions
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		200	{object}	ImportTransactionsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		413	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/imports/mt940 [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			file	formData	file	true	"MT940 statement"
+// @Param			dryRun	query		bool	false	"Preview the import without recording any change"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *ImportMT940StatementHandler) Handle(c *gin.Context) {
+	statement, err := parseStatementFile(c, statements.NewMT940Parser())
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	importStatement(c, h.bus, "mt940", statement)
+}
@@ -0,0 +1,60 @@
+package assetshttp
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const ImportOFXStatementPath = "/assets/:id/imports/ofx"
+
+type ImportOFXStatementHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *ImportOFXStatementHandler) Method() string {
+	return "POST"
+}
+
+func (h *ImportOFXStatementHandler) Path() string {
+	return ImportOFXStatementPath
+}
+
+func NewImportOFXStatementHandler(cmdbus cqrs.Bus) *ImportOFXStatementHandler {
+	return &ImportOFXStatementHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Import an OFX/QFX bank statement
+// @Description	Import the transactions of an OFX 1.x (SGML) or 2.x (XML) statement into the asset. Transactions already imported are detected by their FITID and skipped. The asset balance is updated with the ledger balance of the statement. With dryRun the import is previewed without recording any change.
+// @Tags			transactions
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		200	{object}	ImportTransactionsResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		413	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets/{id}/imports/ofx [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path		string	true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			file	formData	file	true	"OFX or QFX statement"
+// @Param			dryRun	query		bool	false	"Preview the import without recording any change"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *ImportOFXStatementHandler) Handle(c *gin.Context) {
+	statement, err := parseStatementFile(c, statements.NewOFXParser())
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	importStatement(c, h.bus, "ofx", statement)
+}
@@ -0,0 +1,161 @@
+package assetshttp
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	transactionscommands "github.com/xfrr/finantrack/internal/contexts/transactions/commands"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const ImportQIFPath = "/imports/qif"
+
+type ImportQIFHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *ImportQIFHandler) Method() string {
+	return "POST"
+}
+
+func (h *ImportQIFHandler) Path() string {
+	return ImportQIFPath
+}
+
+func NewImportQIFHandler(cmdbus cqrs.Bus) *ImportQIFHandler {
+	return &ImportQIFHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Import a QIF file
+// @Description	Import the accounts, categories and transactions of a QIF file. Bank, Cash, CCard, Invst, Oth A and Oth L accounts are supported, including split transactions and transfers. Transactions are imported into the asset named after their account, which is created with the given currency when missing, and the categories are created when missing. Investment transactions are imported as their cash movement. With dryRun the import is previewed without recording any change.
+// @Tags			transactions
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		200	{object}	ImportQIFResponse
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		413	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/imports/qif [post]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			file				formData	file	true	"QIF file"
+// @Param			currency			formData	string	false	"Currency of the assets created for unknown accounts"	example(EUR)
+// @Param			account				formData	string	false	"Account of the transactions without account record, the file name by default"
+// @Param			dateOrder			formData	string	false	"Order of the dates"	Enums(mdy, dmy)	default(mdy)
+// @Param			decimalSeparator	formData	string	false	"Decimal separator of the amounts, a dot or a comma"	default(.)
+// @Param			encoding			formData	string	false	"Character encoding of the file"	example(windows-1252)
+// @Param			dryRun				query		bool	false	"Preview the import without recording any change"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *ImportQIFHandler) Handle(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+	defer file.Close()
+
+	currency := strings.ToUpper(c.PostForm("currency"))
+	qif, err := statements.ReadQIF(file, statements.QIFOptions{
+		DateOrder:        statements.QIFDateOrder(c.PostForm("dateOrder")),
+		DecimalSeparator: c.PostForm("decimalSeparator"),
+		Encoding:         c.PostForm("encoding"),
+		Currency:         currency,
+		Account:          c.DefaultPostForm("account", strings.TrimSuffix(header.Filename, filepath.Ext(header.Filename))),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	res, err := cqrs.Dispatch(c.Request.Context(), h.bus, transactionscommands.ImportQIFCommand{
+		File:     qif,
+		Currency: currency,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	result, ok := res.(*transactionscommands.ImportQIFResult)
+	if !ok {
+		xhttp.AbortWithError(c, errors.New("unexpected command response"))
+		return
+	}
+
+	c.JSON(http.StatusOK, newImportQIFResponse(result))
+}
+
+type ImportQIFResponse struct {
+	DryRun     bool                       `json:"dryRun"`
+	Accounts   []ImportedAccountResponse  `json:"accounts"`
+	Categories []ImportedCategoryResponse `json:"categories"`
+}
+
+type ImportedAccountResponse struct {
+	ImportTransactionsResponse
+
+	Name      string `json:"name" example:"Checking"`
+	AssetType string `json:"assetType" example:"bank"`
+	Created   bool   `json:"created"`
+}
+
+type ImportedCategoryResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name" example:"Food:Groceries"`
+	Kind    string `json:"kind" example:"expense"`
+	Created bool   `json:"created"`
+}
+
+func newImportQIFResponse(result *transactionscommands.ImportQIFResult) ImportQIFResponse {
+	res := ImportQIFResponse{
+		DryRun:     result.DryRun,
+		Accounts:   make([]ImportedAccountResponse, 0, len(result.Accounts)),
+		Categories: make([]ImportedCategoryResponse, 0, len(result.Categories)),
+	}
+
+	for _, account := range result.Accounts {
+		res.Accounts = append(res.Accounts, ImportedAccountResponse{
+			ImportTransactionsResponse: newImportTransactionsResponse(&account.ImportResult),
+			Name:                       account.Name,
+			AssetType:                  account.AssetType,
+			Created:                    account.Created,
+		})
+	}
+
+	for _, category := range result.Categories {
+		res.Categories = append(res.Categories, ImportedCategoryResponse{
+			ID:      category.CategoryID,
+			Name:    category.Name,
+			Kind:    category.Kind,
+			Created: category.Created,
+		})
+	}
+
+	return res
+}
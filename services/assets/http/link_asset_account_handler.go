@@ -0,0 +1,74 @@
+package assetshttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assetscommands "github.com/xfrr/finantrack/internal/contexts/assets/commands"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const LinkAssetAccountPath = "/assets/:id/account"
+
+type LinkAssetAccountHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *LinkAssetAccountHandler) Method() string {
+	return "PUT"
+}
+
+func (h *LinkAssetAccountHandler) Path() string {
+	return LinkAssetAccountPath
+}
+
+func NewLinkAssetAccountHandler(cmdbus cqrs.Bus) *LinkAssetAccountHandler {
+	return &LinkAssetAccountHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Link a bank account to an asset
+// @Description	Link the bank account with the given IBAN to the asset, so the statements of the account are imported into it
+// @Tags			assets
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	string
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		409	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/assets/{id}/account [put]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path	string					true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			body	body	LinkAssetAccountRequest	true	"Bank account"
+// @Param			X-Household-ID	header	string	true	"Household ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *LinkAssetAccountHandler) Handle(c *gin.Context) {
+	var req LinkAssetAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	_, err := cqrs.Dispatch(c.Request.Context(), h.bus, assetscommands.LinkAssetAccountCommand{
+		AssetID: c.Param("id"),
+		IBAN:    req.IBAN,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account linked"})
+}
+
+type LinkAssetAccountRequest struct {
+	IBAN string `json:"iban" binding:"required" example:"DE89 3704 0044 0532 0130 00"`
+}
@@ -1,6 +1,8 @@
 package assetshttp
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/xfrr/go-cqrsify/cqrs"
 )
@@ -26,16 +28,17 @@ func NewModifyAssetHandler(cmdbus cqrs.Bus) *ModifyAssetHandler {
 }
 
 // @Summary		Modify an asset
-// @Description	Modify an asset
+// @Description	Modify an asset, not implemented yet
 // @Tags			assets
 // @Accept			json
 // @Produce		json
-// @Success		200	{object}	string
+// @Failure		501	{object}	string
 // @Router			/assets/{id} [put]
 // @Param			id		path	string				true	"Asset ID"	default(00000000-0000-0000-0000-000000000000)
 // @Param			body	body	ModifyAssetRequest	true	"Asset data"
 func (h *ModifyAssetHandler) Handle(c *gin.Context) {
 	// TODO: dispatch command to modify asset
+	c.AbortWithStatus(http.StatusNotImplemented)
 }
 
 // ModifyAssetRequest represents the request to modify an asset
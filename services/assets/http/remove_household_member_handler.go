@@ -0,0 +1,63 @@
+package assetshttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	householdscommands "github.com/xfrr/finantrack/internal/contexts/households/commands"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const RemoveHouseholdMemberPath = "/households/:id/members/:userId"
+
+type RemoveHouseholdMemberHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *RemoveHouseholdMemberHandler) Method() string {
+	return "DELETE"
+}
+
+func (h *RemoveHouseholdMemberHandler) Path() string {
+	return RemoveHouseholdMemberPath
+}
+
+func NewRemoveHouseholdMemberHandler(cmdbus cqrs.Bus) *RemoveHouseholdMemberHandler {
+	return &RemoveHouseholdMemberHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Remove a household member
+// @Description	Stop sharing the household with a user. Members can leave, but only the owners can remove others.
+// @Tags			households
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	string
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		409	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/households/{id}/members/{userId} [delete]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path	string	true	"Household ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			userId	path	string	true	"User ID"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *RemoveHouseholdMemberHandler) Handle(c *gin.Context) {
+	// dispatch command to remove the household member
+	_, err := cqrs.Dispatch(c.Request.Context(), h.bus, householdscommands.RemoveHouseholdMemberCommand{
+		HouseholdID: c.Param("id"),
+		UserID:      c.Param("userId"),
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Household member removed"})
+}
@@ -0,0 +1,76 @@
+package assetshttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	householdscommands "github.com/xfrr/finantrack/internal/contexts/households/commands"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+)
+
+const SetHouseholdMemberPath = "/households/:id/members/:userId"
+
+type SetHouseholdMemberHandler struct {
+	bus cqrs.Bus
+}
+
+func (h *SetHouseholdMemberHandler) Method() string {
+	return "PUT"
+}
+
+func (h *SetHouseholdMemberHandler) Path() string {
+	return SetHouseholdMemberPath
+}
+
+func NewSetHouseholdMemberHandler(cmdbus cqrs.Bus) *SetHouseholdMemberHandler {
+	return &SetHouseholdMemberHandler{
+		bus: cmdbus,
+	}
+}
+
+// @Summary		Add or update a household member
+// @Description	Share the household with a user, or change its role. Only the owners can manage the members.
+// @Tags			households
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	string
+// @Failure		400	{object}	xhttp.Problem
+// @Failure		401	{object}	xhttp.Problem
+// @Failure		403	{object}	xhttp.Problem
+// @Failure		404	{object}	xhttp.Problem
+// @Failure		409	{object}	xhttp.Problem
+// @Failure		422	{object}	xhttp.Problem
+// @Failure		500	{object}	xhttp.Problem
+// @Router			/households/{id}/members/{userId} [put]
+// @Security		ApiKeyAuth
+// @Security		BearerAuth
+// @Param			id		path	string						true	"Household ID"	default(00000000-0000-0000-0000-000000000000)
+// @Param			userId	path	string						true	"User ID"
+// @Param			body	body	SetHouseholdMemberRequest	true	"Member role"
+// @Param			Idempotency-Key	header	string	false	"Key to safely retry the request"
+func (h *SetHouseholdMemberHandler) Handle(c *gin.Context) {
+	var req SetHouseholdMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		xhttp.AbortWithError(c, xhttp.InvalidRequest(err))
+		return
+	}
+
+	// dispatch command to set the household member
+	_, err := cqrs.Dispatch(c.Request.Context(), h.bus, householdscommands.SetHouseholdMemberCommand{
+		HouseholdID: c.Param("id"),
+		UserID:      c.Param("userId"),
+		Role:        req.Role,
+	})
+	if err != nil {
+		xhttp.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Household member updated"})
+}
+
+type SetHouseholdMemberRequest struct {
+	Role string `json:"role" example:"editor" enums:"owner,editor,viewer"`
+}
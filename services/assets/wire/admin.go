@@ -0,0 +1,229 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+	"github.com/xfrr/finantrack/internal/shared/xmigrate"
+	"github.com/xfrr/finantrack/services"
+
+	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+)
+
+// migrator applies the pending migrations of a database engine: the indexes of MongoDB and the tables of immudb.
+// It fails with xmigrate.ErrDrift when the migrations applied to the database differ from the ones of the service.
+type migrator func(ctx context.Context, opts ...xmigrate.Option) (xmigrate.Plan, error)
+
+// projectionsChecker checks the projections of the events, the event index and the snapshots of
+// the assets: they are down when the migrations building them are pending or drifted, e.g. while
+// another instance of the service applies newer ones. The service still serves requests without
+// them up to date, so they are an optional component of its health.
+func projectionsChecker(migrate migrator) xhealth.Checker {
+	return xhealth.CheckerFunc(func(ctx context.Context) error {
+		plan, err := migrate(ctx, xmigrate.WithDryRun())
+		if err != nil {
+			return err
+		}
+
+		if len(plan.Pending) > 0 {
+			return fmt.Errorf("%d pending migrations", len(plan.Pending))
+		}
+		return nil
+	})
+}
+
+// Migrate applies the pending migrations of the given engine, so the database is ready before the service starts,
+// and returns the plan of the run. With xmigrate.WithDryRun the plan is returned without applying them.
+func (s Service) Migrate(ctx context.Context, engine services.DatabaseEngineType, opts ...xmigrate.Option) (plan xmigrate.Plan, err error) {
+	migrate, stop, err := s.migratorFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return xmigrate.Plan{}, err
+	}
+	defer func() { err = errors.Join(err, stop()) }()
+
+	return migrate(ctx, opts...)
+}
+
+// projector replays the events stored in a database engine into one of their projections,
+// returning the number of events written to it.
+type projector func(ctx context.Context) (int, error)
+
+// EventIndexProjection is the projection indexing the events by the date and the parent of their payloads,
+// which the reports and the transactions of an asset read. See xevent.Dated and xevent.Child.
+const EventIndexProjection = "event-index"
+
+// Projections are the names of the projections that can be replayed.
+var Projections = []string{EventIndexProjection}
+
+// ReplayProjection replays all the events into the projection with the given name, see Projections,
+// e.g. after the way it is computed changed, and returns the number of events written to it.
+func (s Service) ReplayProjection(ctx context.Context, name string) (n int, err error) {
+	var factory services.RepositoryFactory[projector]
+	switch name {
+	case EventIndexProjection:
+		factory = s.eventIndexFactory
+	default:
+		return 0, fmt.Errorf("unknown projection %q, must be one of %s", name, strings.Join(Projections, ", "))
+	}
+
+	// the database may predate the projection, it needs its schema before it is replayed
+	if _, err = s.Migrate(ctx, s.Config().DatabaseEngine); err != nil {
+		return 0, fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	project, stop, err := factory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Join(err, stop()) }()
+
+	return project(ctx)
+}
+
+// RebuildSnapshots saves the snapshot of every asset, in any household, at its current version,
+// so the assets are restored from it instead of replaying all their changes. It returns the
+// assets whose snapshot was saved. The snapshots are derived from the events, rebuilding them
+// again only refreshes them.
+func (s Service) RebuildSnapshots(ctx context.Context) (rebuilt []*assetdomain.Asset, err error) {
+	// the database may predate the snapshots, it needs their table before they are saved
+	if _, err = s.Migrate(ctx, s.Config().DatabaseEngine); err != nil {
+		return nil, fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	repository, stopRepository, err := s.repoFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, stopRepository()) }()
+
+	assets, err := repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, asset := range assets {
+		if err = repository.SaveSnapshot(ctx, asset); err != nil {
+			return rebuilt, fmt.Errorf("failed to save the snapshot of asset %s: %w", asset.ID(), err)
+		}
+		rebuilt = append(rebuilt, asset)
+	}
+	return rebuilt, nil
+}
+
+// AssignAssets assigns the assets created before households were introduced, which no household
+// can read or modify, to the household with the given ID, and returns them. With dryRun the assets
+// are returned without assigning them. Running it again once they are assigned does nothing.
+func (s Service) AssignAssets(ctx context.Context, householdID string, dryRun bool) (assigned []*assetdomain.Asset, err error) {
+	id, err := uuid.Parse(householdID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", householddomain.ErrInvalidHouseholdID, err)
+	}
+
+	households, stopHouseholds, err := s.householdRepoFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, stopHouseholds()) }()
+
+	if _, err = households.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	repository, stopRepository, err := s.repoFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, stopRepository()) }()
+
+	unassigned, err := repository.Unassigned(ctx)
+	if err != nil || dryRun {
+		return unassigned, err
+	}
+
+	for _, asset := range unassigned {
+		if err = asset.AssignTenant(householdID); err != nil {
+			return assigned, err
+		}
+		if err = repository.Save(ctx, asset); err != nil {
+			return assigned, fmt.Errorf("failed to assign asset %s: %w", asset.ID(), err)
+		}
+		assigned = append(assigned, asset)
+	}
+	return assigned, nil
+}
+
+// CreateAPIKey issues a new API key authenticating the subject, e.g. a member of a household,
+// and stores its hash. It returns the plain key, which cannot be recovered afterwards.
+// A zero expiration issues a key that never expires.
+func (s Service) CreateAPIKey(ctx context.Context, name, subject string, scopes []string, expiresAt time.Time) (key string, apiKey xauth.APIKey, err error) {
+	if subject == "" {
+		return "", xauth.APIKey{}, errors.New("the subject of the api key is required")
+	}
+
+	// the database may be empty, it needs the api keys table before the key is stored
+	if _, err = s.Migrate(ctx, s.Config().DatabaseEngine); err != nil {
+		return "", xauth.APIKey{}, fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	store, stopStore, err := s.apiKeyStoreFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return "", xauth.APIKey{}, err
+	}
+	defer func() { err = errors.Join(err, stopStore()) }()
+
+	key, apiKey, err = xauth.NewAPIKey(name, subject, scopes, expiresAt)
+	if err != nil {
+		return "", xauth.APIKey{}, err
+	}
+
+	if err = store.Save(ctx, apiKey); err != nil {
+		return "", xauth.APIKey{}, err
+	}
+	return key, apiKey, nil
+}
+
+// Dump writes the events of the aggregate, in any household, reading them straight
+// from the database of the service. It returns the number of events written.
+func (s Service) Dump(ctx context.Context, w io.Writer, aggregateID uuid.UUID) (n int, err error) {
+	log, stopLog, err := s.eventLogFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Join(err, stopLog()) }()
+
+	return xbackup.Dump(ctx, w, log, aggregateID)
+}
+
+// Verify checks the integrity of the event log of the household of the context,
+// reading it straight from the database of the service.
+func (s Service) Verify(ctx context.Context) (v xbackup.Verification, err error) {
+	log, stopLog, err := s.eventLogFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return xbackup.Verification{}, err
+	}
+	defer func() { err = errors.Join(err, stopLog()) }()
+
+	return xbackup.Verify(ctx, log)
+}
+
+// VerifyArchive checks the checksums and the event log of the archive, and that its events
+// can be decoded by the service, without writing anything.
+func (s Service) VerifyArchive(r io.Reader) (v xbackup.Verification, err error) {
+	archive, err := xbackup.Open(r)
+	if err != nil {
+		return xbackup.Verification{}, err
+	}
+	defer func() { err = errors.Join(err, archive.Close()) }()
+
+	return xbackup.VerifyArchive(archive, s.eventsRegistry)
+}
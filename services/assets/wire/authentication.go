@@ -0,0 +1,57 @@
+package assets
+
+import (
+	"context"
+	"os"
+
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+	"github.com/xfrr/finantrack/services"
+)
+
+// newAuthOptions creates the authenticators of the http server from the service config.
+// API keys are always verified against the database, while bearer tokens are only
+// accepted when a JWKS file or an HMAC secret is configured.
+func newAuthOptions(
+	ctx context.Context,
+	cfg services.Config,
+	storeFactory services.RepositoryFactory[xauth.APIKeyStore],
+) ([]xhttp.AuthOption, func() error, error) {
+	store, closer, err := storeFactory.CreateRepository(ctx, cfg.DatabaseEngine)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := []xhttp.AuthOption{
+		xhttp.WithAPIKeyAuthenticator(xauth.NewAPIKeyAuthenticator(store)),
+	}
+
+	jwtOpts := []xauth.JWTOption{
+		xauth.WithIssuer(cfg.AuthJWTIssuer),
+		xauth.WithAudience(cfg.AuthJWTAudience),
+	}
+
+	var bearer *xauth.JWTAuthenticator
+	switch {
+	case cfg.AuthJWKSFile != "":
+		var jwks []byte
+		jwks, err = os.ReadFile(cfg.AuthJWKSFile)
+		if err != nil {
+			_ = closer()
+			return nil, nil, err
+		}
+		bearer, err = xauth.NewJWKSAuthenticator(jwks, jwtOpts...)
+	case cfg.AuthJWTSecret != "":
+		bearer, err = xauth.NewHMACAuthenticator([]byte(cfg.AuthJWTSecret), jwtOpts...)
+	}
+	if err != nil {
+		_ = closer()
+		return nil, nil, err
+	}
+
+	if bearer != nil {
+		opts = append(opts, xhttp.WithBearerAuthenticator(bearer))
+	}
+
+	return opts, closer, nil
+}
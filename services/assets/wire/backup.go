@@ -0,0 +1,132 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
+	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xtenant"
+	"github.com/xfrr/finantrack/services"
+)
+
+// Backup writes the archive of the whole event log of the household of the context,
+// reading it straight from the database of the service.
+func (s Service) Backup(ctx context.Context, w io.Writer) (manifest xbackup.Manifest, err error) {
+	engine := s.Config().DatabaseEngine
+
+	log, stopLog, err := s.eventLogFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return xbackup.Manifest{}, err
+	}
+	defer func() { err = errors.Join(err, stopLog()) }()
+
+	return xbackup.Backup(ctx, w, log, string(engine))
+}
+
+// Restore replays the archive into the event log of the given engine, which
+// may differ from the one the backup was taken from. The archive is verified
+// before any event is written.
+func (s Service) Restore(ctx context.Context, r io.Reader, engine services.DatabaseEngineType) (result xbackup.RestoreResult, err error) {
+	archive, err := xbackup.Open(r)
+	if err != nil {
+		return xbackup.RestoreResult{}, err
+	}
+	defer func() { err = errors.Join(err, archive.Close()) }()
+
+	// the target database may be empty, it needs its schema before the events are written
+	if _, err = s.Migrate(ctx, engine); err != nil {
+		return xbackup.RestoreResult{}, fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	log, stopLog, err := s.eventLogFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return xbackup.RestoreResult{}, err
+	}
+	defer func() { err = errors.Join(err, stopLog()) }()
+
+	return xbackup.Restore(ctx, archive, log, s.eventsRegistry)
+}
+
+// mongoEventLog is the event log of the MongoDB engine.
+type mongoEventLog struct {
+	xmongo.EventStore
+}
+
+// Stream returns the events of the tenant of the context, ordered by aggregate and version.
+func (l mongoEventLog) Stream(ctx context.Context) iter.Seq2[xbackup.Event, error] {
+	tenantID, _ := xtenant.FromContext(ctx)
+	return l.EventStore.Stream(ctx,
+		xmongo.WithTenantIDCriteria(tenantID)(),
+		xmongo.WithSort(xmongo.SortByAggregateID, xmongo.Ascending),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	)
+}
+
+// StreamAggregate returns the events of the aggregate in any tenant, ordered by version.
+func (l mongoEventLog) StreamAggregate(ctx context.Context, aggregateID uuid.UUID) iter.Seq2[xbackup.Event, error] {
+	return l.EventStore.Stream(ctx,
+		xmongo.WithAggregateIDCriteria(aggregateID.String())(),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Ascending),
+	)
+}
+
+// AggregateVersion returns the version of the last event of the aggregate in any tenant,
+// zero if it has no events.
+func (l mongoEventLog) AggregateVersion(ctx context.Context, aggregateID uuid.UUID) (int, error) {
+	return lastVersion(l.EventStore.Stream(ctx,
+		xmongo.WithAggregateIDCriteria(aggregateID.String())(),
+		xmongo.WithSort(xmongo.SortByAggregateVersion, xmongo.Descending),
+		xmongo.WithLimit(1),
+	))
+}
+
+// immudbEventLog is the event log of the immudb engine.
+type immudbEventLog struct {
+	ximmudb.EventStore
+}
+
+// Stream returns the events of the tenant of the context, ordered by aggregate and version.
+func (l immudbEventLog) Stream(ctx context.Context) iter.Seq2[xbackup.Event, error] {
+	tenantID, _ := xtenant.FromContext(ctx)
+	return l.EventStore.Stream(ctx,
+		ximmudb.WithTenantIDCriteria(tenantID)(),
+		ximmudb.WithSort(ximmudb.SortByAggregateID, ximmudb.Ascending),
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Ascending),
+	)
+}
+
+// StreamAggregate returns the events of the aggregate in any tenant, ordered by version.
+func (l immudbEventLog) StreamAggregate(ctx context.Context, aggregateID uuid.UUID) iter.Seq2[xbackup.Event, error] {
+	return l.EventStore.Stream(ctx,
+		ximmudb.WithAggregateIDCriteria(aggregateID.String())(),
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Ascending),
+	)
+}
+
+// AggregateVersion returns the version of the last event of the aggregate in any tenant,
+// zero if it has no events.
+func (l immudbEventLog) AggregateVersion(ctx context.Context, aggregateID uuid.UUID) (int, error) {
+	return lastVersion(l.EventStore.Stream(ctx,
+		ximmudb.WithAggregateIDCriteria(aggregateID.String())(),
+		ximmudb.WithSort(ximmudb.SortByAggregateVersion, ximmudb.Descending),
+		ximmudb.WithLimit(1),
+	))
+}
+
+// lastVersion returns the highest aggregate version of the events, or zero if there are none.
+func lastVersion(events iter.Seq2[xbackup.Event, error]) (int, error) {
+	var version int
+	for e, err := range events {
+		if err != nil {
+			return 0, err
+		}
+		version = max(version, e.Aggregate().Version)
+	}
+	return version, nil
+}
@@ -0,0 +1,120 @@
+package assets
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xfrr/go-cqrsify/cqrs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+	"github.com/xfrr/finantrack/internal/shared/xmetrics"
+)
+
+// tracingMiddleware starts a new span for every request dispatched to the bus.
+// The kind is used to name the span and its attributes, i.e. "command" or "query".
+func tracingMiddleware(tracer trace.Tracer, kind string) cqrs.Middleware {
+	return func(f func(context.Context, interface{}) (interface{}, error)) func(context.Context, interface{}) (interface{}, error) {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			var attrs []attribute.KeyValue
+
+			name := kind
+			if requestName := nameOf(request); requestName != "" {
+				name = requestName
+				attrs = append(attrs, attribute.String(kind+".name", requestName))
+				attrs = append(attrs, attribute.String(kind+".bus.type", "inmemory"))
+				attrs = append(attrs, attribute.String(kind+".bus.name", "cqrsify"))
+				attrs = append(attrs, attribute.String(kind+".bus.service", "assets"))
+			}
+
+			ctx, span := tracer.Start(ctx, name)
+			defer span.End()
+			span.SetAttributes(attrs...)
+
+			return f(ctx, request)
+		}
+	}
+}
+
+// metricsMiddleware records the rate, errors and duration of the requests dispatched
+// to the bus per name. The kind labels the metrics, i.e. "command" or "query".
+func metricsMiddleware(kind string) cqrs.Middleware {
+	return func(f func(context.Context, interface{}) (interface{}, error)) func(context.Context, interface{}) (interface{}, error) {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			start := time.Now()
+			res, err := f(ctx, request)
+			xmetrics.ObserveBusRequest(kind, nameOf(request), start, err)
+			return res, err
+		}
+	}
+}
+
+// loggingMiddleware adds the name of the request dispatched to the bus to the logger
+// carried by the context, along with the ID of the commands, so the handlers log through it.
+// The kind names the field, i.e. "command" or "query". Failures are logged at debug level,
+// the requests that caused them are already logged by the HTTP server.
+func loggingMiddleware(kind string) cqrs.Middleware {
+	return func(f func(context.Context, interface{}) (interface{}, error)) func(context.Context, interface{}) (interface{}, error) {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			lctx := xlog.FromContext(ctx).With().Str(kind, nameOf(request))
+			if md, ok := xevent.MetadataFromContext(ctx); ok && kind == "command" && md.CausationID != "" {
+				lctx = lctx.Str("command_id", md.CausationID)
+			}
+			logger := lctx.Logger()
+
+			res, err := f(xlog.WithLogger(ctx, logger), request)
+			if err != nil {
+				logger.Debug().Err(err).Msg(kind + " failed")
+			}
+			return res, err
+		}
+	}
+}
+
+// eventMetadataMiddleware enriches the event metadata carried by the context
+// before the command is handled, so repositories can store it with every event.
+// Each dispatched command gets a new ID which becomes the causation ID of the
+// events it produces. Missing correlation IDs default to the command ID.
+func eventMetadataMiddleware(source string) cqrs.Middleware {
+	return func(f func(context.Context, interface{}) (interface{}, error)) func(context.Context, interface{}) (interface{}, error) {
+		return func(ctx context.Context, command interface{}) (interface{}, error) {
+			md, _ := xevent.MetadataFromContext(ctx)
+
+			commandID := uuid.NewString()
+			md.CausationID = commandID
+			if md.CorrelationID == "" {
+				md.CorrelationID = commandID
+			}
+
+			if md.Source == "" {
+				md.Source = source
+			}
+
+			if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+				md.TraceID = spanCtx.TraceID().String()
+			}
+
+			trace.SpanFromContext(ctx).SetAttributes(
+				attribute.String("command.id", commandID),
+				attribute.String("command.correlation_id", md.CorrelationID),
+			)
+
+			return f(xevent.WithMetadata(ctx, md), command)
+		}
+	}
+}
+
+// nameOf returns the name of the given command or query.
+func nameOf(request interface{}) string {
+	switch r := request.(type) {
+	case cqrs.Command:
+		return r.CommandName()
+	case interface{ QueryName() string }:
+		return r.QueryName()
+	default:
+		return ""
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/xfrr/go-cqrsify/cqrs"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
 	assetscommands "github.com/xfrr/finantrack/internal/contexts/assets/commands"
@@ -19,26 +18,15 @@ func newAssetCommandBus(
 	tracer trace.Tracer,
 ) (cqrs.Bus, error) {
 	bus := cqrs.NewBus()
-	bus.Use(func(f func(context.Context, interface{}) (interface{}, error)) func(context.Context, interface{}) (interface{}, error) {
-		return func(ctx context.Context, command interface{}) (interface{}, error) {
-			var attrs []attribute.KeyValue
-
-			name := "command"
-			if cmd, isCommand := command.(cqrs.Command); isCommand {
-				name = cmd.CommandName()
-				attrs = append(attrs, attribute.String("command.name", cmd.CommandName()))
-				attrs = append(attrs, attribute.String("command.bus.type", "inmemory"))
-				attrs = append(attrs, attribute.String("command.bus.name", "cqrsify"))
-				attrs = append(attrs, attribute.String("command.bus.service", "assets"))
-			}
-
-			ctx, span := tracer.Start(ctx, name)
-			defer span.End()
-			span.SetAttributes(attrs...)
-
-			return f(ctx, command)
-		}
-	})
+
+	// Middlewares registered first wrap the handler first, so the event metadata
+	// middleware runs inside the command span and can capture its trace ID,
+	// the logger gets the command ID it generates, and the metrics include
+	// the time spent in the other middlewares.
+	bus.Use(loggingMiddleware("command"))
+	bus.Use(eventMetadataMiddleware("assets"))
+	bus.Use(tracingMiddleware(tracer, "command"))
+	bus.Use(metricsMiddleware("command"))
 
 	// Register command handlers
 	err := cqrs.Handle(ctx, bus, assetscommands.NewCreateAssetCommandHandler(repository).Handle)
@@ -51,5 +39,10 @@ func newAssetCommandBus(
 		return nil, err
 	}
 
+	err = cqrs.Handle(ctx, bus, assetscommands.NewLinkAssetAccountCommandHandler(repository).Handle)
+	if err != nil {
+		return nil, err
+	}
+
 	return bus, nil
 }
@@ -0,0 +1,127 @@
+package assets
+
+import (
+	"strconv"
+
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+	"github.com/xfrr/finantrack/services"
+)
+
+// Config is the configuration of the assets service, loaded with xconfig from a YAML or TOML file,
+// the environment and the command line flags. The secrets have no defaults, they can be read
+// from files with the _FILE suffix of their environment variables.
+type Config struct {
+	Environment     string         `config:"environment" env:"FINANCES_MANAGER_ENVIRONMENT" usage:"environment of the service, e.g. development or production"`
+	CSVProfilesFile string         `config:"csv_profiles_file" env:"FINANCES_MANAGER_CSV_PROFILES_FILE" usage:"file with the CSV profiles of the banks"`
+	HTTP            HTTPConfig     `config:"http"`
+	Database        DatabaseConfig `config:"database"`
+	Auth            AuthConfig     `config:"auth"`
+	Logging         LoggingConfig  `config:"logging"`
+	Tracing         TracingConfig  `config:"tracing"`
+}
+
+type HTTPConfig struct {
+	Port         int   `config:"port" env:"FINANCES_MANAGER_HTTP_SERVER_PORT" usage:"port of the http server"`
+	MaxBodyBytes int64 `config:"max_body_bytes" env:"FINANCES_MANAGER_HTTP_MAX_BODY_BYTES" usage:"size limit in bytes of the request bodies, such as the uploaded statements"`
+}
+
+type DatabaseConfig struct {
+	Engine   services.DatabaseEngineType `config:"engine" env:"FINANCES_MANAGER_DB_ENGINE" usage:"database engine, mongodb or immudb"`
+	Host     string                      `config:"host" env:"FINANCES_MANAGER_DB_HOST" usage:"host of the database"`
+	Port     int                         `config:"port" env:"FINANCES_MANAGER_DB_PORT" usage:"port of the database"`
+	User     string                      `config:"user" env:"FINANCES_MANAGER_DB_USER" usage:"user of the database"`
+	Password string                      `config:"password" env:"FINANCES_MANAGER_DB_PASS" usage:"password of the database user" json:"-"`
+	Name     string                      `config:"name" env:"FINANCES_MANAGER_DB_NAME" usage:"name of the database"`
+}
+
+type AuthConfig struct {
+	Enabled     bool   `config:"enabled" env:"FINANCES_MANAGER_AUTH_ENABLED" usage:"authenticate the api requests, requires a jwt_secret or a jwks_file"`
+	JWTSecret   string `config:"jwt_secret" env:"FINANCES_MANAGER_AUTH_JWT_SECRET" usage:"HMAC secret of the bearer tokens" json:"-"`
+	JWKSFile    string `config:"jwks_file" env:"FINANCES_MANAGER_AUTH_JWKS_FILE" usage:"file with the JWKS of the bearer tokens"`
+	JWTIssuer   string `config:"jwt_issuer" env:"FINANCES_MANAGER_AUTH_JWT_ISSUER" usage:"expected issuer of the bearer tokens"`
+	JWTAudience string `config:"jwt_audience" env:"FINANCES_MANAGER_AUTH_JWT_AUDIENCE" usage:"expected audience of the bearer tokens"`
+}
+
+type LoggingConfig struct {
+	Format xlog.Format `config:"format" env:"FINANCES_MANAGER_LOG_FORMAT" usage:"log format, json or console"`
+	Level  string      `config:"level" env:"FINANCES_MANAGER_LOG_LEVEL" usage:"minimum log level, e.g. info"`
+}
+
+type TracingConfig struct {
+	Exporter           xtracing.Exporter `config:"exporter" env:"FINANCES_MANAGER_TRACES_EXPORTER" usage:"traces exporter, otlp-grpc, otlp-http, stdout or none"`
+	Endpoint           string            `config:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" usage:"host and port of the traces collector"`
+	SampleRatio        float64           `config:"sample_ratio" env:"FINANCES_MANAGER_TRACES_SAMPLE_RATIO" usage:"ratio of the sampled traces, from 0 to 1"`
+	ResourceAttributes map[string]string `config:"resource_attributes" env:"FINANCES_MANAGER_TRACES_RESOURCE_ATTRIBUTES" usage:"key=value attributes describing the service"`
+}
+
+// DefaultConfig returns the configuration of a local development environment, without the database credentials.
+func DefaultConfig() Config {
+	return Config{
+		Environment: "development",
+		HTTP: HTTPConfig{
+			Port:         6000,
+			MaxBodyBytes: xhttp.DefaultMaxBodyBytes,
+		},
+		Database: DatabaseConfig{
+			Engine: services.MongoDatabaseEngine,
+			Host:   "localhost",
+			Port:   27017,
+			Name:   "finantrack",
+		},
+		Auth: AuthConfig{
+			Enabled: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    xtracing.ExporterOTLPGRPC,
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Options returns the options initializing the service with the configuration.
+func (c Config) Options() []services.InitializeOption {
+	opts := []services.InitializeOption{
+		services.Environment(c.Environment),
+		services.Logging(
+			services.LogFormat(c.Logging.Format),
+			services.LogLevel(c.Logging.Level),
+		),
+		services.Tracing(
+			services.TracesExporter(c.Tracing.Exporter),
+			services.TracesEndpoint(c.Tracing.Endpoint),
+			services.TracesSampleRatio(c.Tracing.SampleRatio),
+			services.TracesResourceAttributes(c.Tracing.ResourceAttributes),
+		),
+		services.CSVProfiles(c.CSVProfilesFile),
+		services.HTTPServer(
+			services.Port(strconv.Itoa(c.HTTP.Port)),
+			services.MaxBodyBytes(c.HTTP.MaxBodyBytes),
+		),
+		services.Database(
+			services.DatabaseEngine(c.Database.Engine),
+			services.DatabaseHost(c.Database.Host),
+			services.DatabasePort(strconv.Itoa(c.Database.Port)),
+			services.DatabaseUser(c.Database.User),
+			services.DatabasePass(c.Database.Password),
+			services.DatabaseName(c.Database.Name),
+		),
+	}
+
+	if c.Auth.Enabled {
+		opts = append(opts, services.Authentication(
+			services.JWTSecret(c.Auth.JWTSecret),
+			services.JWKSFile(c.Auth.JWKSFile),
+			services.JWTIssuer(c.Auth.JWTIssuer),
+			services.JWTAudience(c.Auth.JWTAudience),
+		))
+	}
+
+	return opts
+}
+
+// Validate checks the configuration as the service does when it is created.
+func (c Config) Validate() error {
+	return services.NewService("assets", c.Options()...).Config().Validate()
+}
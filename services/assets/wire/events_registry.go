@@ -4,6 +4,8 @@ import (
 	"github.com/xfrr/finantrack/internal/shared/xevent"
 
 	assetevents "github.com/xfrr/finantrack/internal/contexts/assets/domain/events"
+	householdevents "github.com/xfrr/finantrack/internal/contexts/households/domain/events"
+	transactionevents "github.com/xfrr/finantrack/internal/contexts/transactions/domain/events"
 )
 
 func newAssetEventsRegistry() xevent.Registry {
@@ -14,5 +16,32 @@ func newAssetEventsRegistry() xevent.Registry {
 	xevent.Register(eventsRegistry, assetevents.AssetDeletedEventType, func() interface{} {
 		return &assetevents.AssetDeletedEvent{}
 	})
+	xevent.Register(eventsRegistry, assetevents.AssetBalanceUpdatedEventType, func() interface{} {
+		return &assetevents.AssetBalanceUpdatedEvent{}
+	})
+	xevent.Register(eventsRegistry, assetevents.AssetAccountLinkedEventType, func() interface{} {
+		return &assetevents.AssetAccountLinkedEvent{}
+	})
+	xevent.Register(eventsRegistry, assetevents.AssetTenantAssignedEventType, func() interface{} {
+		return &assetevents.AssetTenantAssignedEvent{}
+	})
+	xevent.Register(eventsRegistry, householdevents.HouseholdCreatedEventType, func() interface{} {
+		return &householdevents.HouseholdCreatedEvent{}
+	})
+	xevent.Register(eventsRegistry, householdevents.HouseholdMemberAddedEventType, func() interface{} {
+		return &householdevents.HouseholdMemberAddedEvent{}
+	})
+	xevent.Register(eventsRegistry, householdevents.HouseholdMemberRoleChangedEventType, func() interface{} {
+		return &householdevents.HouseholdMemberRoleChangedEvent{}
+	})
+	xevent.Register(eventsRegistry, householdevents.HouseholdMemberRemovedEventType, func() interface{} {
+		return &householdevents.HouseholdMemberRemovedEvent{}
+	})
+	xevent.Register(eventsRegistry, transactionevents.TransactionRecordedEventType, func() interface{} {
+		return &transactionevents.TransactionRecordedEvent{}
+	})
+	xevent.Register(eventsRegistry, transactionevents.CategoryCreatedEventType, func() interface{} {
+		return &transactionevents.CategoryCreatedEvent{}
+	})
 	return eventsRegistry
 }
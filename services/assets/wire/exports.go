@@ -0,0 +1,47 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xfrr/finantrack/internal/contexts/transactions/exports"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+)
+
+// ExportJournal writes the books in the given plain-text accounting format, reading them
+// straight from the database of the service. The household is taken from the context.
+func (s Service) ExportJournal(ctx context.Context, w io.Writer, format exports.Format, query transactionsqueries.GetJournalQuery) (err error) {
+	engine := s.Config().DatabaseEngine
+
+	repository, stopDatabase, err := s.repoFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, stopDatabase()) }()
+
+	transactionRepository, stopTransactions, err := s.transactionFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, stopTransactions()) }()
+
+	categoryRepository, stopCategories, err := s.categoryFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, stopCategories()) }()
+
+	res, err := transactionsqueries.NewGetJournalQueryHandler(transactionRepository, categoryRepository, repository).Handle(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	journal, ok := res.(transactionsqueries.JournalView)
+	if !ok {
+		return fmt.Errorf("unexpected query response %T", res)
+	}
+
+	return exports.Write(w, format, journal)
+}
@@ -0,0 +1,51 @@
+package assets
+
+import (
+	"context"
+
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	householdscommands "github.com/xfrr/finantrack/internal/contexts/households/commands"
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	householdsqueries "github.com/xfrr/finantrack/internal/contexts/households/queries"
+)
+
+// registerHouseholdCommandHandlers registers the command handlers
+// of the households context in the given bus.
+func registerHouseholdCommandHandlers(
+	ctx context.Context,
+	bus cqrs.Bus,
+	repository householddomain.Repository,
+) error {
+	err := cqrs.Handle(ctx, bus, householdscommands.NewCreateHouseholdCommandHandler(repository).Handle)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.Handle(ctx, bus, householdscommands.NewSetHouseholdMemberCommandHandler(repository).Handle)
+	if err != nil {
+		return err
+	}
+
+	return cqrs.Handle(ctx, bus, householdscommands.NewRemoveHouseholdMemberCommandHandler(repository).Handle)
+}
+
+// registerHouseholdQueryHandlers registers the query handlers
+// of the households context in the given bus.
+func registerHouseholdQueryHandlers(
+	ctx context.Context,
+	bus cqrs.Bus,
+	repository householddomain.Repository,
+) error {
+	err := cqrs.Handle(ctx, bus, householdsqueries.NewGetHouseholdQueryHandler(repository).Handle)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.Handle(ctx, bus, householdsqueries.NewGetHouseholdsQueryHandler(repository).Handle)
+	if err != nil {
+		return err
+	}
+
+	return cqrs.Handle(ctx, bus, householdsqueries.NewAuthorizeHouseholdQueryHandler(repository).Handle)
+}
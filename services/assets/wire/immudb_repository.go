@@ -2,16 +2,28 @@ package assets
 
 import (
 	"context"
+	"database/sql"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
 	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
 	"github.com/xfrr/finantrack/internal/shared/ximmudb"
+	"github.com/xfrr/finantrack/internal/shared/xmigrate"
+	"github.com/xfrr/finantrack/internal/shared/xsnapshot"
 	"github.com/xfrr/finantrack/services"
 
 	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
 	assetimmudb "github.com/xfrr/finantrack/internal/contexts/assets/immudb"
 	assetimmudbmigrations "github.com/xfrr/finantrack/internal/contexts/assets/immudb/migrations"
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	householdsimmudb "github.com/xfrr/finantrack/internal/contexts/households/immudb"
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	transactionsimmudb "github.com/xfrr/finantrack/internal/contexts/transactions/immudb"
 )
 
 const (
@@ -32,45 +44,184 @@ type immudbRepositoryFactory struct {
 
 func (f immudbRepositoryFactory) NewAssetEventRepository() services.RepositoryFactoryFunc[assetdomain.Repository] {
 	return func(ctx context.Context) (assetdomain.Repository, func() error, error) {
-		port, err := strconv.Atoi(f.dbPort)
+		db, err := f.connect(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		db, err := ximmudb.NewImmuDBClient(ctx, ximmudb.Config{
-			Host:         f.dbHost,
-			Port:         port,
-			User:         f.dbUser,
-			Pass:         f.dbPass,
-			DB:           "defaultdb",
-			Timeout:      DefaultTimeout,
-			MaxOpenConns: DefaultMaxOpenConns,
-			MaxLife:      DefaultMaxLife,
-			MaxIdleCons:  DefaultMaxIdleCons,
-		})
+		eventStore := ximmudb.NewImmuEventStore(db, f.eventsRegistry)
+
+		repo, err := assetimmudb.NewImmuRepository(eventStore,
+			assetimmudb.WithSnapshots(xsnapshot.NewImmuSnapshotStore(db)),
+		)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = ximmudb.Migrate(db, []ximmudb.Migration{
-			assetimmudbmigrations.NewCreateAssetsDatabase(),
-			assetimmudbmigrations.NewCreateAssetEventsTable(),
-		})
+		return repo, func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+func (f immudbRepositoryFactory) NewHouseholdRepository() services.RepositoryFactoryFunc[householddomain.Repository] {
+	return func(ctx context.Context) (householddomain.Repository, func() error, error) {
+		db, err := f.connect(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		repo, err := assetimmudb.NewImmuRepository(db)
+		eventStore := ximmudb.NewImmuEventStore(db, f.eventsRegistry)
+
+		return householdsimmudb.NewRepository(eventStore), func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+func (f immudbRepositoryFactory) NewTransactionRepository() services.RepositoryFactoryFunc[transactiondomain.Repository] {
+	return func(ctx context.Context) (transactiondomain.Repository, func() error, error) {
+		db, err := f.connect(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		return repo, func() error {
+		eventStore := ximmudb.NewImmuEventStore(db, f.eventsRegistry)
+
+		return transactionsimmudb.NewRepository(eventStore), func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+func (f immudbRepositoryFactory) NewCategoryRepository() services.RepositoryFactoryFunc[transactiondomain.CategoryRepository] {
+	return func(ctx context.Context) (transactiondomain.CategoryRepository, func() error, error) {
+		db, err := f.connect(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := ximmudb.NewImmuEventStore(db, f.eventsRegistry)
+
+		return transactionsimmudb.NewCategoryRepository(eventStore), func() error {
 			return db.Close()
 		}, nil
 	}
 }
 
+// NewEventLog returns the event log shared by every aggregate, used by backups and restores.
+func (f immudbRepositoryFactory) NewEventLog() services.RepositoryFactoryFunc[xbackup.EventLog] {
+	return func(ctx context.Context) (xbackup.EventLog, func() error, error) {
+		db, err := f.connect(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := ximmudb.NewImmuEventStore(db, f.eventsRegistry)
+
+		return immudbEventLog{eventStore}, func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+func (f immudbRepositoryFactory) NewAPIKeyStore() services.RepositoryFactoryFunc[xauth.APIKeyStore] {
+	return func(ctx context.Context) (xauth.APIKeyStore, func() error, error) {
+		db, err := f.connect(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return xauth.NewImmuAPIKeyStore(db), func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+// NewIdempotencyStore keeps the idempotency records in memory,
+// as immudb cannot expire or remove them.
+func (f immudbRepositoryFactory) NewIdempotencyStore() services.RepositoryFactoryFunc[xhttp.IdempotencyStore] {
+	return func(_ context.Context) (xhttp.IdempotencyStore, func() error, error) {
+		return xhttp.NewInMemoryIdempotencyStore(xhttp.DefaultIdempotencyTTL), func() error {
+			return nil
+		}, nil
+	}
+}
+
+// NewEventStoreChecker returns the health checker of the immudb server of the event store.
+func (f immudbRepositoryFactory) NewEventStoreChecker() services.RepositoryFactoryFunc[xhealth.Checker] {
+	return func(ctx context.Context) (xhealth.Checker, func() error, error) {
+		db, err := f.connect(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return xhealth.CheckerFunc(db.PingContext), func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+// NewEventIndexProjector returns the projector of the date and parent index of the events table.
+func (f immudbRepositoryFactory) NewEventIndexProjector() services.RepositoryFactoryFunc[projector] {
+	return func(ctx context.Context) (projector, func() error, error) {
+		db, err := f.connect(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := ximmudb.NewImmuEventStore(db, f.eventsRegistry)
+
+		return eventStore.Reindex, func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+// NewMigrator returns the migrator of the tables.
+func (f immudbRepositoryFactory) NewMigrator() services.RepositoryFactoryFunc[migrator] {
+	return func(ctx context.Context) (migrator, func() error, error) {
+		db, err := f.connect(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// every aggregate shares the events table
+		migrations := slices.Concat(
+			assetimmudbmigrations.Migrations(f.eventsRegistry),
+			xauth.ImmuAPIKeyStoreMigrations(),
+			xsnapshot.ImmuSnapshotStoreMigrations(),
+		)
+
+		migrate := func(ctx context.Context, opts ...xmigrate.Option) (xmigrate.Plan, error) {
+			return ximmudb.Migrate(ctx, db, migrations, opts...)
+		}
+
+		return migrate, func() error {
+			return db.Close()
+		}, nil
+	}
+}
+
+func (f immudbRepositoryFactory) connect(ctx context.Context) (*sql.DB, error) {
+	port, err := strconv.Atoi(f.dbPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return ximmudb.NewImmuDBClient(ctx, ximmudb.Config{
+		Host:         f.dbHost,
+		Port:         port,
+		User:         f.dbUser,
+		Pass:         f.dbPass,
+		DB:           "defaultdb",
+		Timeout:      DefaultTimeout,
+		MaxOpenConns: DefaultMaxOpenConns,
+		MaxLife:      DefaultMaxLife,
+		MaxIdleCons:  DefaultMaxIdleCons,
+	})
+}
+
 func newImmuDBRepositoryFactory(
 	cfg services.Config,
 	eventsRegistry xevent.Registry,
@@ -3,13 +3,24 @@ package assets
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
 	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
+	"github.com/xfrr/finantrack/internal/shared/xmigrate"
 	"github.com/xfrr/finantrack/internal/shared/xmongo"
+	"github.com/xfrr/finantrack/internal/shared/xsnapshot"
 	"github.com/xfrr/finantrack/services"
 
 	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
 	assetsmongo "github.com/xfrr/finantrack/internal/contexts/assets/mongodb"
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	householdsmongo "github.com/xfrr/finantrack/internal/contexts/households/mongodb"
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	transactionsmongo "github.com/xfrr/finantrack/internal/contexts/transactions/mongodb"
 )
 
 type mongoRepositoryFactory struct {
@@ -33,10 +44,7 @@ func (f mongoRepositoryFactory) NewAssetEventRepository() services.RepositoryFac
 			return repo, nil, err
 		}
 
-		eventStore, err := xmongo.NewMongoEventStore(ctx, mongoClient, f.eventsRegistry)
-		if err != nil {
-			return repo, nil, err
-		}
+		eventStore := xmongo.NewMongoEventStore(mongoClient, f.eventsRegistry)
 
 		closer := func() error {
 			err = mongoClient.Close(ctx)
@@ -46,7 +54,197 @@ func (f mongoRepositoryFactory) NewAssetEventRepository() services.RepositoryFac
 			return err
 		}
 
-		return assetsmongo.NewRepository(eventStore), closer, nil
+		return assetsmongo.NewRepository(eventStore,
+			assetsmongo.WithSnapshots(xsnapshot.NewMongoSnapshotStore(mongoClient)),
+		), closer, nil
+	}
+}
+
+func (f mongoRepositoryFactory) NewHouseholdRepository() services.RepositoryFactoryFunc[householddomain.Repository] {
+	return func(ctx context.Context) (householddomain.Repository, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := xmongo.NewMongoEventStore(mongoClient, f.eventsRegistry)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return householdsmongo.NewRepository(eventStore), closer, nil
+	}
+}
+
+func (f mongoRepositoryFactory) NewTransactionRepository() services.RepositoryFactoryFunc[transactiondomain.Repository] {
+	return func(ctx context.Context) (transactiondomain.Repository, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := xmongo.NewMongoEventStore(mongoClient, f.eventsRegistry)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return transactionsmongo.NewRepository(eventStore), closer, nil
+	}
+}
+
+func (f mongoRepositoryFactory) NewCategoryRepository() services.RepositoryFactoryFunc[transactiondomain.CategoryRepository] {
+	return func(ctx context.Context) (transactiondomain.CategoryRepository, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := xmongo.NewMongoEventStore(mongoClient, f.eventsRegistry)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return transactionsmongo.NewCategoryRepository(eventStore), closer, nil
+	}
+}
+
+// NewEventLog returns the event log shared by every aggregate, used by backups and restores.
+func (f mongoRepositoryFactory) NewEventLog() services.RepositoryFactoryFunc[xbackup.EventLog] {
+	return func(ctx context.Context) (xbackup.EventLog, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := xmongo.NewMongoEventStore(mongoClient, f.eventsRegistry)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return mongoEventLog{eventStore}, closer, nil
+	}
+}
+
+func (f mongoRepositoryFactory) NewAPIKeyStore() services.RepositoryFactoryFunc[xauth.APIKeyStore] {
+	return func(ctx context.Context) (xauth.APIKeyStore, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		store := xauth.NewMongoAPIKeyStore(mongoClient)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return store, closer, nil
+	}
+}
+
+func (f mongoRepositoryFactory) NewIdempotencyStore() services.RepositoryFactoryFunc[xhttp.IdempotencyStore] {
+	return func(ctx context.Context) (xhttp.IdempotencyStore, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		store := xhttp.NewMongoIdempotencyStore(mongoClient)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return store, closer, nil
+	}
+}
+
+// NewEventStoreChecker returns the health checker of the MongoDB deployment of the event store.
+func (f mongoRepositoryFactory) NewEventStoreChecker() services.RepositoryFactoryFunc[xhealth.Checker] {
+	return func(ctx context.Context) (xhealth.Checker, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return xhealth.CheckerFunc(mongoClient.Ping), closer, nil
+	}
+}
+
+// NewEventIndexProjector returns the projector of the date and parent index of the events collection.
+func (f mongoRepositoryFactory) NewEventIndexProjector() services.RepositoryFactoryFunc[projector] {
+	return func(ctx context.Context) (projector, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventStore := xmongo.NewMongoEventStore(mongoClient, f.eventsRegistry)
+
+		closer := func() error {
+			return mongoClient.Close(context.Background())
+		}
+
+		return eventStore.Reindex, closer, nil
+	}
+}
+
+// NewMigrator returns the migrator of the indexes of the collections.
+func (f mongoRepositoryFactory) NewMigrator() services.RepositoryFactoryFunc[migrator] {
+	return func(ctx context.Context) (migrator, func() error, error) {
+		ctx, cancel := context.WithTimeout(ctx, xmongo.MongoConnectDefaultTimeout)
+		defer cancel()
+
+		mongoClient, err := xmongo.NewClient(ctx, f.buildURI(), f.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		migrations := slices.Concat(
+			xmongo.EventStoreMigrations(f.eventsRegistry),
+			xauth.MongoAPIKeyStoreMigrations(),
+			xhttp.MongoIdempotencyStoreMigrations(xhttp.DefaultIdempotencyTTL),
+		)
+
+		migrate := func(ctx context.Context, opts ...xmigrate.Option) (xmigrate.Plan, error) {
+			return xmongo.Migrate(ctx, mongoClient, migrations, opts...)
+		}
+
+		return migrate, func() error {
+			return mongoClient.Close(context.Background())
+		}, nil
 	}
 }
 
@@ -0,0 +1,42 @@
+package assets
+
+import (
+	"context"
+
+	"github.com/xfrr/go-cqrsify/cqrs"
+	"go.opentelemetry.io/otel/trace"
+
+	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	assetsqueries "github.com/xfrr/finantrack/internal/contexts/assets/queries"
+)
+
+// newAssetQueryBus creates a new query bus for the assets context
+// and registers all query handlers.
+func newAssetQueryBus(
+	ctx context.Context,
+	repository assetdomain.Repository,
+	tracer trace.Tracer,
+) (cqrs.Bus, error) {
+	bus := cqrs.NewBus()
+	bus.Use(loggingMiddleware("query"))
+	bus.Use(tracingMiddleware(tracer, "query"))
+	bus.Use(metricsMiddleware("query"))
+
+	// Register query handlers
+	err := cqrs.Handle(ctx, bus, assetsqueries.NewGetAssetQueryHandler(repository).Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cqrs.Handle(ctx, bus, assetsqueries.NewGetPortfolioQueryHandler(repository).Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cqrs.Handle(ctx, bus, assetsqueries.NewGetAssetHistoryQueryHandler(repository).Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return bus, nil
+}
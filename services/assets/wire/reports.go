@@ -0,0 +1,107 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	reportsqueries "github.com/xfrr/finantrack/internal/contexts/reports/queries"
+	"github.com/xfrr/finantrack/internal/contexts/reports/render"
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+)
+
+// registerReportQueryHandlers registers the query handlers
+// of the reports context in the given bus.
+func registerReportQueryHandlers(
+	ctx context.Context,
+	bus cqrs.Bus,
+	transactions transactiondomain.Repository,
+	categories transactiondomain.CategoryRepository,
+	assets assetdomain.Repository,
+) error {
+	err := cqrs.Handle(ctx, bus, reportsqueries.NewGetIncomeStatementQueryHandler(transactions, categories, assets).Handle)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.Handle(ctx, bus, reportsqueries.NewGetCategoryBreakdownQueryHandler(transactions, categories, assets).Handle)
+	if err != nil {
+		return err
+	}
+
+	return cqrs.Handle(ctx, bus, reportsqueries.NewGetAllocationQueryHandler(assets).Handle)
+}
+
+// RenderReport renders the report of the given query, reading it straight from the database
+// of the service. The household is taken from the context.
+func (s Service) RenderReport(ctx context.Context, w io.Writer, format render.Format, query any) (err error) {
+	engine := s.Config().DatabaseEngine
+
+	assetRepository, stopDatabase, err := s.repoFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, stopDatabase()) }()
+
+	if q, ok := query.(reportsqueries.GetAllocationQuery); ok {
+		res, err := reportsqueries.NewGetAllocationQueryHandler(assetRepository).Handle(ctx, q)
+		if err != nil {
+			return err
+		}
+		view, err := viewOf[reportsqueries.AllocationView](res)
+		if err != nil {
+			return err
+		}
+		return render.WriteAllocation(w, format, view)
+	}
+
+	transactionRepository, stopTransactions, err := s.transactionFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, stopTransactions()) }()
+
+	categoryRepository, stopCategories, err := s.categoryFactory.CreateRepository(ctx, engine)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, stopCategories()) }()
+
+	switch q := query.(type) {
+	case reportsqueries.GetIncomeStatementQuery:
+		res, err := reportsqueries.NewGetIncomeStatementQueryHandler(transactionRepository, categoryRepository, assetRepository).Handle(ctx, q)
+		if err != nil {
+			return err
+		}
+		view, err := viewOf[reportsqueries.IncomeStatementView](res)
+		if err != nil {
+			return err
+		}
+		return render.WriteIncomeStatement(w, format, view)
+	case reportsqueries.GetCategoryBreakdownQuery:
+		res, err := reportsqueries.NewGetCategoryBreakdownQueryHandler(transactionRepository, categoryRepository, assetRepository).Handle(ctx, q)
+		if err != nil {
+			return err
+		}
+		view, err := viewOf[reportsqueries.CategoryBreakdownView](res)
+		if err != nil {
+			return err
+		}
+		return render.WriteCategoryBreakdown(w, format, view)
+	default:
+		return fmt.Errorf("unsupported report query %T", query)
+	}
+}
+
+// viewOf returns the view of a query response.
+func viewOf[V any](res interface{}) (V, error) {
+	view, ok := res.(V)
+	if !ok {
+		return view, fmt.Errorf("unexpected query response %T", res)
+	}
+	return view, nil
+}
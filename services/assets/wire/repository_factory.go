@@ -1,10 +1,16 @@
 package assets
 
 import (
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
 	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
 	"github.com/xfrr/finantrack/services"
 
 	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
 )
 
 func newRepositoryFactory(
@@ -39,3 +45,300 @@ func newRepositoryFactory(
 
 	return repoFactory, nil
 }
+
+func newAPIKeyStoreFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[xauth.APIKeyStore], error) {
+	storeFactory := services.NewRepositoryFactory[xauth.APIKeyStore]()
+
+	// Register the MongoDB store
+	err := storeFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewAPIKeyStore(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb store
+	err = storeFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewAPIKeyStore(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return storeFactory, nil
+}
+
+func newIdempotencyStoreFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[xhttp.IdempotencyStore], error) {
+	storeFactory := services.NewRepositoryFactory[xhttp.IdempotencyStore]()
+
+	// Register the MongoDB store
+	err := storeFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewIdempotencyStore(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb store
+	err = storeFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewIdempotencyStore(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return storeFactory, nil
+}
+
+func newHouseholdRepositoryFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[householddomain.Repository], error) {
+	repoFactory := services.NewRepositoryFactory[householddomain.Repository]()
+
+	// Register the MongoDB repository
+	err := repoFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewHouseholdRepository(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb repository
+	err = repoFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewHouseholdRepository(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return repoFactory, nil
+}
+
+func newTransactionRepositoryFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[transactiondomain.Repository], error) {
+	repoFactory := services.NewRepositoryFactory[transactiondomain.Repository]()
+
+	// Register the MongoDB repository
+	err := repoFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewTransactionRepository(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb repository
+	err = repoFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewTransactionRepository(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return repoFactory, nil
+}
+
+func newCategoryRepositoryFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[transactiondomain.CategoryRepository], error) {
+	repoFactory := services.NewRepositoryFactory[transactiondomain.CategoryRepository]()
+
+	// Register the MongoDB repository
+	err := repoFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewCategoryRepository(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb repository
+	err = repoFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewCategoryRepository(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return repoFactory, nil
+}
+
+func newEventLogFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[xbackup.EventLog], error) {
+	logFactory := services.NewRepositoryFactory[xbackup.EventLog]()
+
+	// Register the MongoDB event log
+	err := logFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewEventLog(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb event log
+	err = logFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewEventLog(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return logFactory, nil
+}
+
+func newEventStoreCheckerFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[xhealth.Checker], error) {
+	checkerFactory := services.NewRepositoryFactory[xhealth.Checker]()
+
+	// Register the MongoDB checker
+	err := checkerFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewEventStoreChecker(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb checker
+	err = checkerFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewEventStoreChecker(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkerFactory, nil
+}
+
+func newEventIndexProjectorFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[projector], error) {
+	projectorFactory := services.NewRepositoryFactory[projector]()
+
+	// Register the MongoDB projector
+	err := projectorFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewEventIndexProjector(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb projector
+	err = projectorFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewEventIndexProjector(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectorFactory, nil
+}
+
+func newMigratorFactory(
+	cfg services.Config,
+	eventsRegistry xevent.Registry,
+) (services.RepositoryFactory[migrator], error) {
+	migratorFactory := services.NewRepositoryFactory[migrator]()
+
+	// Register the MongoDB migrator
+	err := migratorFactory.RegisterRepository(
+		services.MongoDatabaseEngine,
+		newMongoRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewMigrator(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the immudb migrator
+	err = migratorFactory.RegisterRepository(
+		services.ImmuDBDatabaseEngine,
+		newImmuDBRepositoryFactory(
+			cfg,
+			eventsRegistry,
+		).NewMigrator(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return migratorFactory, nil
+}
@@ -2,8 +2,16 @@ package assets
 
 import (
 	"context"
+	"fmt"
 
 	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	householddomain "github.com/xfrr/finantrack/internal/contexts/households/domain"
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	"github.com/xfrr/finantrack/internal/shared/xauth"
+	"github.com/xfrr/finantrack/internal/shared/xbackup"
+	"github.com/xfrr/finantrack/internal/shared/xevent"
+	"github.com/xfrr/finantrack/internal/shared/xhealth"
+	"github.com/xfrr/finantrack/internal/shared/xhttp"
 	"github.com/xfrr/finantrack/internal/shared/xlog"
 	"github.com/xfrr/finantrack/internal/shared/xtracing"
 	"github.com/xfrr/finantrack/services"
@@ -13,38 +21,154 @@ import (
 type Service struct {
 	services.Base
 
-	repoFactory services.RepositoryFactory[assetdomain.Repository]
+	repoFactory          services.RepositoryFactory[assetdomain.Repository]
+	householdRepoFactory services.RepositoryFactory[householddomain.Repository]
+	transactionFactory   services.RepositoryFactory[transactiondomain.Repository]
+	categoryFactory      services.RepositoryFactory[transactiondomain.CategoryRepository]
+	apiKeyStoreFactory   services.RepositoryFactory[xauth.APIKeyStore]
+	idempotencyFactory   services.RepositoryFactory[xhttp.IdempotencyStore]
+	eventLogFactory      services.RepositoryFactory[xbackup.EventLog]
+	eventStoreChecker    services.RepositoryFactory[xhealth.Checker]
+	eventIndexFactory    services.RepositoryFactory[projector]
+	migratorFactory      services.RepositoryFactory[migrator]
+
+	eventsRegistry xevent.Registry
 }
 
 func (s Service) Start(ctx context.Context) error {
-	logger := xlog.NewZerologger(s.Name(), s.Config().Environment)
+	logger := xlog.NewZerologger(s.Name(), s.Config().Environment, s.Config().Logging)
 	logger.Debug().
 		Any("config", s.Config()).
 		Msg("starting assets service...")
 
-	// create new tracer provider
-	tracer, stopTracer, err := xtracing.NewOtelTracerProvider(ctx, s.Name(), s.Config().OtelCollectorURL)
+	// create new tracer provider, the service runs without traces if it fails
+	tracer, stopTracer, err := xtracing.NewOtelTracerProvider(ctx, s.Name(), s.Config().Tracing)
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to create tracer provider")
+		logger.Error().Err(err).Msg("failed to create tracer provider, tracing is disabled")
 	}
 
+	// apply the pending migrations, refusing to start when they drifted from the ones applied
+	plan, err := s.Migrate(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return fmt.Errorf("failed to migrate the database: %w", err)
+	}
+	logger.Info().
+		Int("applied", len(plan.Applied)).
+		Int("pending", len(plan.Pending)).
+		Msg("database migrated")
+
 	// create database based on the engine type
 	repository, stopDatabase, err := s.repoFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
 	if err != nil {
 		return err
 	}
 
+	// create the households repository, the tenants of the assets
+	householdRepository, stopHouseholds, err := s.householdRepoFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	// create the transactions repository, the movements of the assets
+	transactionRepository, stopTransactions, err := s.transactionFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	// create the categories repository, the classification of the transactions
+	categoryRepository, stopCategories, err := s.categoryFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	// load the csv profiles of the statement imports
+	csvProfiles, err := newCSVProfiles(s.Config())
+	if err != nil {
+		return err
+	}
+
 	// creates new command bus and register all commands
 	cmdbus, err := newAssetCommandBus(ctx, repository, tracer)
 	if err != nil {
 		return err
 	}
 
+	err = registerHouseholdCommandHandlers(ctx, cmdbus, householdRepository)
+	if err != nil {
+		return err
+	}
+
+	err = registerTransactionCommandHandlers(ctx, cmdbus, transactionRepository, categoryRepository, repository)
+	if err != nil {
+		return err
+	}
+
+	// creates new query bus and register all queries
+	querybus, err := newAssetQueryBus(ctx, repository, tracer)
+	if err != nil {
+		return err
+	}
+
+	err = registerHouseholdQueryHandlers(ctx, querybus, householdRepository)
+	if err != nil {
+		return err
+	}
+
+	err = registerTransactionQueryHandlers(ctx, querybus, transactionRepository, categoryRepository, repository)
+	if err != nil {
+		return err
+	}
+
+	err = registerReportQueryHandlers(ctx, querybus, transactionRepository, categoryRepository, repository)
+	if err != nil {
+		return err
+	}
+
+	// create the authenticators of the http server
+	var (
+		authOpts []xhttp.AuthOption
+		stopAuth = func() error { return nil }
+	)
+	if s.Config().AuthEnabled {
+		authOpts, stopAuth, err = newAuthOptions(ctx, s.Config(), s.apiKeyStoreFactory)
+		if err != nil {
+			return err
+		}
+	}
+
+	// create the store of the responses replayed on retries
+	idempotencyStore, stopIdempotency, err := s.idempotencyFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	// create the health checks of the readiness probe
+	eventStoreChecker, stopEventStoreChecker, err := s.eventStoreChecker.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	// the projections are checked on the schema they are built on
+	migrate, stopMigrator, err := s.migratorFactory.CreateRepository(ctx, s.Config().DatabaseEngine)
+	if err != nil {
+		return err
+	}
+
+	health := xhealth.New()
+	health.Register("event_store", eventStoreChecker)
+	health.RegisterOptional("projections", projectionsChecker(migrate))
+
 	// create new http server instance
 	httpServer := assetshttp.NewServer(
 		s.Name(),
 		cmdbus,
+		querybus,
 		logger,
+		health,
+		csvProfiles,
+		s.Config().HTTPMaxBodyBytes,
+		idempotencyStore,
+		authOpts...,
 	)
 
 	go func() {
@@ -63,8 +187,53 @@ func (s Service) Start(ctx context.Context) error {
 			logger.Error().Err(err).Msg("failed to close database connection")
 		}
 
-		// stop tracer
-		stopTracer()
+		// stop households database connection
+		err = stopHouseholds()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close households database connection")
+		}
+
+		// stop transactions database connection
+		err = stopTransactions()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close transactions database connection")
+		}
+
+		// stop categories database connection
+		err = stopCategories()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close categories database connection")
+		}
+
+		// stop api key store connection
+		err = stopAuth()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close api key store connection")
+		}
+
+		// stop idempotency store connection
+		err = stopIdempotency()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close idempotency store connection")
+		}
+
+		// stop event store health checker connection
+		err = stopEventStoreChecker()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close event store health checker connection")
+		}
+
+		// stop projections health checker connection
+		err = stopMigrator()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to close projections health checker connection")
+		}
+
+		// stop tracer, flushing the pending spans
+		err = stopTracer()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to stop tracer provider")
+		}
 	}()
 
 	return httpServer.Run(s.Config().HTTPServerPort)
@@ -79,8 +248,13 @@ func NewService(opts ...services.InitializeOption) (*Service, error) {
 		Base: *services.NewService("assets", opts...),
 	}
 
+	if err = service.Config().Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// register all events for the assets context
 	eventsRegistry := newAssetEventsRegistry()
+	service.eventsRegistry = eventsRegistry
 
 	// Register asset repository factory
 	service.repoFactory, err = newRepositoryFactory(
@@ -91,5 +265,86 @@ func NewService(opts ...services.InitializeOption) (*Service, error) {
 		return nil, err
 	}
 
+	// Register household repository factory
+	service.householdRepoFactory, err = newHouseholdRepositoryFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register transaction repository factory
+	service.transactionFactory, err = newTransactionRepositoryFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register category repository factory
+	service.categoryFactory, err = newCategoryRepositoryFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register api key store factory
+	service.apiKeyStoreFactory, err = newAPIKeyStoreFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register idempotency store factory
+	service.idempotencyFactory, err = newIdempotencyStoreFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register event log factory
+	service.eventLogFactory, err = newEventLogFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register event store health checker factory
+	service.eventStoreChecker, err = newEventStoreCheckerFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register event index projector factory
+	service.eventIndexFactory, err = newEventIndexProjectorFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register migrator factory
+	service.migratorFactory, err = newMigratorFactory(
+		service.Config(),
+		eventsRegistry,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return service, nil
 }
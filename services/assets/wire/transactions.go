@@ -0,0 +1,76 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/xfrr/go-cqrsify/cqrs"
+
+	assetdomain "github.com/xfrr/finantrack/internal/contexts/assets/domain"
+	transactionscommands "github.com/xfrr/finantrack/internal/contexts/transactions/commands"
+	transactiondomain "github.com/xfrr/finantrack/internal/contexts/transactions/domain"
+	transactionsqueries "github.com/xfrr/finantrack/internal/contexts/transactions/queries"
+	"github.com/xfrr/finantrack/internal/contexts/transactions/statements"
+	"github.com/xfrr/finantrack/services"
+)
+
+// registerTransactionCommandHandlers registers the command handlers
+// of the transactions context in the given bus.
+func registerTransactionCommandHandlers(
+	ctx context.Context,
+	bus cqrs.Bus,
+	repository transactiondomain.Repository,
+	categories transactiondomain.CategoryRepository,
+	assets assetdomain.Repository,
+) error {
+	err := cqrs.Handle(ctx, bus, transactionscommands.NewImportTransactionsCommandHandler(repository, assets).Handle)
+	if err != nil {
+		return err
+	}
+
+	return cqrs.Handle(ctx, bus, transactionscommands.NewImportQIFCommandHandler(repository, categories, assets).Handle)
+}
+
+// registerTransactionQueryHandlers registers the query handlers
+// of the transactions context in the given bus.
+func registerTransactionQueryHandlers(
+	ctx context.Context,
+	bus cqrs.Bus,
+	repository transactiondomain.Repository,
+	categories transactiondomain.CategoryRepository,
+	assets assetdomain.Repository,
+) error {
+	err := cqrs.Handle(ctx, bus, transactionsqueries.NewGetTransactionsQueryHandler(repository, assets).Handle)
+	if err != nil {
+		return err
+	}
+
+	err = cqrs.Handle(ctx, bus, transactionsqueries.NewGetCategoriesQueryHandler(categories).Handle)
+	if err != nil {
+		return err
+	}
+
+	return cqrs.Handle(ctx, bus, transactionsqueries.NewGetJournalQueryHandler(repository, categories, assets).Handle)
+}
+
+// newCSVProfiles returns the default CSV profiles along with the ones of the configured file.
+func newCSVProfiles(cfg services.Config) ([]statements.CSVProfile, error) {
+	profiles := append([]statements.CSVProfile{}, statements.DefaultCSVProfiles...)
+	if cfg.CSVProfilesFile == "" {
+		return profiles, nil
+	}
+
+	f, err := os.Open(cfg.CSVProfilesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open csv profiles file: %w", err)
+	}
+	defer f.Close()
+
+	custom, err := statements.LoadCSVProfiles(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(profiles, custom...), nil
+}
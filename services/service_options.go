@@ -1,5 +1,14 @@
 package services
 
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/xfrr/finantrack/internal/shared/xlog"
+	"github.com/xfrr/finantrack/internal/shared/xtracing"
+)
+
 type Base struct {
 	name string
 	cfg  Config
@@ -28,14 +37,84 @@ func NewService(name string, opts ...InitializeOption) *Base {
 
 type Config struct {
 	HTTPServerPort   string
+	HTTPMaxBodyBytes int64
 	DatabaseHost     string
 	DatabasePort     string
 	DatabaseUser     string
-	DatabasePass     string
+	DatabasePass     string `json:"-"`
 	DatabaseName     string
 	DatabaseEngine   DatabaseEngineType
 	Environment      string
-	OtelCollectorURL string
+	Tracing          xtracing.Config
+	Logging          xlog.Config
+	AuthEnabled      bool
+	AuthJWTSecret    string `json:"-"`
+	AuthJWKSFile     string
+	AuthJWTIssuer    string
+	AuthJWTAudience  string
+	CSVProfilesFile  string
+}
+
+// Validate checks the configuration, reporting all the invalid values together,
+// so the service does not start with settings it would fail on later.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, errors.New("environment is required"))
+	}
+	if err := validatePort(c.HTTPServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("http server port: %w", err))
+	}
+	if c.HTTPMaxBodyBytes < 0 {
+		errs = append(errs, fmt.Errorf("http max body bytes: invalid size %d, must not be negative", c.HTTPMaxBodyBytes))
+	}
+
+	switch c.DatabaseEngine {
+	case MongoDatabaseEngine, ImmuDBDatabaseEngine:
+		if c.DatabaseHost == "" {
+			errs = append(errs, errors.New("database host is required"))
+		}
+		if err := validatePort(c.DatabasePort); err != nil {
+			errs = append(errs, fmt.Errorf("database port: %w", err))
+		}
+		if c.DatabaseUser == "" {
+			errs = append(errs, errors.New("database user is required"))
+		}
+		if c.DatabasePass == "" {
+			errs = append(errs, errors.New("database password is required"))
+		}
+		if c.DatabaseName == "" {
+			errs = append(errs, errors.New("database name is required"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported database engine %q, must be %q or %q",
+			c.DatabaseEngine, MongoDatabaseEngine, ImmuDBDatabaseEngine))
+	}
+
+	if err := c.Logging.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logging: %w", err))
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("tracing: %w", err))
+	}
+	if c.AuthEnabled {
+		switch {
+		case c.AuthJWTSecret != "" && c.AuthJWKSFile != "":
+			errs = append(errs, errors.New("authentication: either a JWT secret or a JWKS file can be set, not both"))
+		case c.AuthJWTSecret == "" && c.AuthJWKSFile == "":
+			errs = append(errs, errors.New("authentication: a JWT secret or a JWKS file is required to verify the bearer tokens, or the authentication must be disabled"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid port %q, must be between 1 and 65535", port)
+	}
+	return nil
 }
 
 type InitializeOption func(*Base)
@@ -92,9 +171,110 @@ func Environment(env string) InitializeOption {
 	}
 }
 
-func Traces(url string) InitializeOption {
+type LoggingOption func(*Base)
+
+// Logging configures the logger of the service, the defaults depend on the environment.
+func Logging(opts ...LoggingOption) InitializeOption {
+	return func(s *Base) {
+		for _, opt := range opts {
+			opt(s)
+		}
+	}
+}
+
+// LogFormat sets the encoding of the log lines.
+func LogFormat(format xlog.Format) LoggingOption {
+	return func(s *Base) {
+		s.cfg.Logging.Format = format
+	}
+}
+
+// LogLevel sets the name of the minimum level of the log lines, e.g. "info".
+func LogLevel(level string) LoggingOption {
 	return func(s *Base) {
-		s.cfg.OtelCollectorURL = url
+		s.cfg.Logging.Level = level
+	}
+}
+
+type TracingOption func(*Base)
+
+// Tracing configures the exporter of the traces, tracing is disabled without it.
+func Tracing(opts ...TracingOption) InitializeOption {
+	return func(s *Base) {
+		for _, opt := range opts {
+			opt(s)
+		}
+	}
+}
+
+// TracesExporter sets the destination of the spans.
+func TracesExporter(exporter xtracing.Exporter) TracingOption {
+	return func(s *Base) {
+		s.cfg.Tracing.Exporter = exporter
+	}
+}
+
+// TracesEndpoint sets the host and port of the collector of the OTLP exporters.
+func TracesEndpoint(endpoint string) TracingOption {
+	return func(s *Base) {
+		s.cfg.Tracing.Endpoint = endpoint
+	}
+}
+
+// TracesSampleRatio sets the ratio of the traces started by the service that are sampled.
+func TracesSampleRatio(ratio float64) TracingOption {
+	return func(s *Base) {
+		s.cfg.Tracing.SampleRatio = ratio
+	}
+}
+
+// TracesResourceAttributes sets the attributes added to the ones describing the service.
+func TracesResourceAttributes(attrs map[string]string) TracingOption {
+	return func(s *Base) {
+		s.cfg.Tracing.ResourceAttributes = attrs
+	}
+}
+
+type AuthOption func(*Base)
+
+func Authentication(opts ...AuthOption) InitializeOption {
+	return func(s *Base) {
+		s.cfg.AuthEnabled = true
+		for _, opt := range opts {
+			opt(s)
+		}
+	}
+}
+
+func JWTSecret(secret string) AuthOption {
+	return func(s *Base) {
+		s.cfg.AuthJWTSecret = secret
+	}
+}
+
+func JWKSFile(path string) AuthOption {
+	return func(s *Base) {
+		s.cfg.AuthJWKSFile = path
+	}
+}
+
+func JWTIssuer(issuer string) AuthOption {
+	return func(s *Base) {
+		s.cfg.AuthJWTIssuer = issuer
+	}
+}
+
+func JWTAudience(audience string) AuthOption {
+	return func(s *Base) {
+		s.cfg.AuthJWTAudience = audience
+	}
+}
+
+// CSVProfiles sets the file with the CSV profiles of the banks,
+// available for the statement imports along with the default ones.
+func CSVProfiles(path string) InitializeOption {
+	return func(s *Base) {
+		s.cfg.CSVProfilesFile = path
 	}
 }
 
@@ -113,3 +293,11 @@ func Port(port string) HTTPApiOption {
 		s.cfg.HTTPServerPort = port
 	}
 }
+
+// MaxBodyBytes sets the size limit of the request bodies, such as the uploaded statements.
+// The default limit of the server is used when it is zero.
+func MaxBodyBytes(n int64) HTTPApiOption {
+	return func(s *Base) {
+		s.cfg.HTTPMaxBodyBytes = n
+	}
+}
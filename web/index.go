@@ -38,6 +38,8 @@ func (a *App) getSection() app.UI {
 				},
 			},
 		}
+	case ReportsPath:
+		return &websections.ReportsSection{}
 	default:
 		return app.Text("Not found")
 	}
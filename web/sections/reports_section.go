@@ -0,0 +1,61 @@
+package websections
+
+import "github.com/maxence-charriere/go-app/v10/pkg/app"
+
+// ReportSummary represents the income and expenses of a period.
+type ReportSummary struct {
+	Period      string
+	Currency    string
+	Income      float64
+	Expenses    float64
+	SavingsRate float64
+}
+
+// ReportsSection is a component that renders the reports section.
+type ReportsSection struct {
+	app.Compo
+
+	Summaries []*ReportSummary
+}
+
+func (s *ReportsSection) renderSummaryTable() app.UI {
+	return app.Table().
+		Class("table table-striped").
+		Body(
+			app.THead().Body(
+				app.Tr().Body(
+					app.Th().Text("Period"),
+					app.Th().Class("text-end").Text("Income"),
+					app.Th().Class("text-end").Text("Expenses"),
+					app.Th().Class("text-end").Text("Savings rate"),
+				),
+			),
+			app.TBody().Body(
+				app.Range(s.Summaries).Slice(func(i int) app.UI {
+					summary := s.Summaries[i]
+					return app.Tr().Body(
+						app.Td().Text(summary.Period),
+						app.Td().Class("text-end").Text(app.FormatString("%.2f %s", summary.Income, summary.Currency)),
+						app.Td().Class("text-end").Text(app.FormatString("%.2f %s", summary.Expenses, summary.Currency)),
+						app.Td().Class("text-end").Text(app.FormatString("%.1f%%", summary.SavingsRate*100)),
+					)
+				}),
+			),
+		)
+}
+
+// Render renders the reports section.
+func (s *ReportsSection) Render() app.UI {
+	return app.Div().
+		Class("container").
+		Body(
+			app.H1().Text("Reports"),
+			app.If(len(s.Summaries) > 0, func() app.UI {
+				return s.renderSummaryTable()
+			}).Else(func() app.UI {
+				return app.P().
+					Class("text-muted").
+					Text("There are no transactions to report yet.")
+			}),
+		)
+}